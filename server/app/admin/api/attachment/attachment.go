@@ -13,8 +13,32 @@ import (
 type IAttachmentV1 interface {
 	GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error)
 	Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error)
+	InstantUpload(ctx context.Context, req *v1.InstantUploadReq) (res *v1.InstantUploadRes, err error)
 	Update(ctx context.Context, req *v1.UpdateReq) (res *v1.UpdateRes, err error)
 	Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error)
 	BatchDelete(ctx context.Context, req *v1.BatchDeleteReq) (res *v1.BatchDeleteRes, err error)
 	Download(ctx context.Context, req *v1.DownloadReq) (res *v1.DownloadRes, err error)
+	ChunkInit(ctx context.Context, req *v1.ChunkInitReq) (res *v1.ChunkInitRes, err error)
+	ChunkStatus(ctx context.Context, req *v1.ChunkStatusReq) (res *v1.ChunkStatusRes, err error)
+	ChunkUpload(ctx context.Context, req *v1.ChunkUploadReq) (res *v1.ChunkUploadRes, err error)
+	ChunkComplete(ctx context.Context, req *v1.ChunkCompleteReq) (res *v1.ChunkCompleteRes, err error)
+	ChunkAbort(ctx context.Context, req *v1.ChunkAbortReq) (res *v1.ChunkAbortRes, err error)
+	RegenerateVariants(ctx context.Context, req *v1.RegenerateVariantsReq) (res *v1.RegenerateVariantsRes, err error)
+	SignedUrl(ctx context.Context, req *v1.SignedUrlReq) (res *v1.SignedUrlRes, err error)
+	SignedDownload(ctx context.Context, req *v1.SignedDownloadReq) (res *v1.SignedDownloadRes, err error)
+	SetVisibility(ctx context.Context, req *v1.SetVisibilityReq) (res *v1.SetVisibilityRes, err error)
+	FolderList(ctx context.Context, req *v1.FolderListReq) (res *v1.FolderListRes, err error)
+	FolderCreate(ctx context.Context, req *v1.FolderCreateReq) (res *v1.FolderCreateRes, err error)
+	FolderUpdate(ctx context.Context, req *v1.FolderUpdateReq) (res *v1.FolderUpdateRes, err error)
+	FolderDelete(ctx context.Context, req *v1.FolderDeleteReq) (res *v1.FolderDeleteRes, err error)
+	Move(ctx context.Context, req *v1.MoveReq) (res *v1.MoveRes, err error)
+	TagList(ctx context.Context, req *v1.TagListReq) (res *v1.TagListRes, err error)
+	TagDelete(ctx context.Context, req *v1.TagDeleteReq) (res *v1.TagDeleteRes, err error)
+	SetTags(ctx context.Context, req *v1.SetTagsReq) (res *v1.SetTagsRes, err error)
+	References(ctx context.Context, req *v1.ReferencesReq) (res *v1.ReferencesRes, err error)
+	QuotaList(ctx context.Context, req *v1.QuotaListReq) (res *v1.QuotaListRes, err error)
+	QuotaSet(ctx context.Context, req *v1.QuotaSetReq) (res *v1.QuotaSetRes, err error)
+	QuotaDelete(ctx context.Context, req *v1.QuotaDeleteReq) (res *v1.QuotaDeleteRes, err error)
+	QuotaUsage(ctx context.Context, req *v1.QuotaUsageReq) (res *v1.QuotaUsageRes, err error)
+	Stats(ctx context.Context, req *v1.StatsReq) (res *v1.StatsRes, err error)
 }
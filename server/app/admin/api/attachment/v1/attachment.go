@@ -17,9 +17,13 @@ type AttachmentCommon struct {
 type GetListReq struct {
 	g.Meta `path:"/attachment" method:"get" tags:"附件管理" summary:"获取附件列表"`
 	page.ReqPage
-	FileName *string `json:"fileName,omitempty" dc:"附件名称"`
-	FileExt  *string `json:"fileExt,omitempty" dc:"文件扩展名"`
-	IsImage  *bool   `json:"isImage,omitempty" dc:"是否图片"`
+	FileName   *string `json:"fileName,omitempty" dc:"附件名称"`
+	FileExt    *string `json:"fileExt,omitempty" dc:"文件扩展名"`
+	IsImage    *bool   `json:"isImage,omitempty" dc:"是否图片"`
+	IsPrivate  *bool   `json:"isPrivate,omitempty" dc:"是否私有"`
+	FolderId   *uint64 `json:"folderId,omitempty" dc:"所属文件夹ID，0为根目录"`
+	TagId      *uint64 `json:"tagId,omitempty" dc:"标签ID"`
+	Referenced *bool   `json:"referenced,omitempty" dc:"是否被业务数据引用，为false时查询未被引用的附件，用于清理"`
 }
 
 // GetListRes 查询附件列表返回参数
@@ -30,17 +34,41 @@ type GetListRes struct {
 
 // UploadReq 上传附件请求参数
 type UploadReq struct {
-	g.Meta   `path:"/attachment/upload" method:"post" tags:"附件管理" summary:"上传附件"`
-	File     string  `json:"file" v:"required#请选择要上传的文件" dc:"文件内容（base64或multipart）"`
-	Name     *string `json:"name,omitempty" dc:"自定义附件名称"`
-	Category *string `json:"category,omitempty" dc:"文件分类"`
-	Remark   *string `json:"remark,omitempty" dc:"备注"`
+	g.Meta      `path:"/attachment/upload" method:"post" tags:"附件管理" summary:"上传附件"`
+	File        string  `json:"file" v:"required#请选择要上传的文件" dc:"文件内容（base64或multipart）"`
+	Name        *string `json:"name,omitempty" dc:"自定义附件名称"`
+	Category    *string `json:"category,omitempty" dc:"文件分类"`
+	Scene       string  `json:"scene,omitempty" dc:"上传场景，决定允许上传的文件分类，默认 default"`
+	IsPrivate   int     `json:"isPrivate,omitempty" v:"in:0,1#是否私有只能为0或1" dc:"是否私有，私有附件只能通过签名下载地址访问"`
+	AccessRoles string  `json:"accessRoles,omitempty" dc:"允许访问私有附件的角色编码，逗号分隔，为空时不限制"`
+	FolderId    uint64  `json:"folderId,omitempty" dc:"所属文件夹ID，为空时保存到根目录"`
+	Remark      *string `json:"remark,omitempty" dc:"备注"`
 }
 
 // UploadRes 上传附件返回参数
 type UploadRes struct {
 	Id  uint64 `json:"id" dc:"附件ID"`
-	Url string `json:"url" dc:"访问URL"`
+	Url string `json:"url" dc:"访问URL，私有附件为签名下载地址"`
+}
+
+// InstantUploadReq 秒传请求参数，上传前先提交文件内容的SHA-256，已存在相同内容的文件时无需上传
+type InstantUploadReq struct {
+	g.Meta      `path:"/attachment/upload/instant" method:"post" tags:"附件管理" summary:"秒传检查"`
+	Hash        string  `json:"hash" v:"required|regex:^[a-fA-F0-9]{64}$#请输入文件哈希|文件哈希应为64位SHA-256十六进制字符串" dc:"文件内容SHA-256"`
+	FileName    string  `json:"fileName" v:"required|max-length:255#请输入文件名|文件名不能超过255个字符" dc:"原始文件名"`
+	Name        *string `json:"name,omitempty" dc:"自定义附件名称"`
+	Scene       string  `json:"scene,omitempty" dc:"上传场景，决定允许上传的文件分类，默认 default"`
+	IsPrivate   int     `json:"isPrivate,omitempty" v:"in:0,1#是否私有只能为0或1" dc:"是否私有，私有附件只能通过签名下载地址访问"`
+	AccessRoles string  `json:"accessRoles,omitempty" dc:"允许访问私有附件的角色编码，逗号分隔，为空时不限制"`
+	FolderId    uint64  `json:"folderId,omitempty" dc:"所属文件夹ID，为空时保存到根目录"`
+	Remark      *string `json:"remark,omitempty" dc:"备注"`
+}
+
+// InstantUploadRes 秒传返回参数
+type InstantUploadRes struct {
+	Hit bool   `json:"hit" dc:"是否秒传成功，为false时需要上传文件"`
+	Id  uint64 `json:"id,omitempty" dc:"附件ID"`
+	Url string `json:"url,omitempty" dc:"访问URL，私有附件为签名下载地址"`
 }
 
 // UpdateReq 更新附件请求参数
@@ -73,15 +101,12 @@ type BatchDeleteRes struct{}
 
 // DownloadReq 下载附件请求参数
 type DownloadReq struct {
-	g.Meta `path:"/attachment/{id}/download" method:"get" tags:"附件管理" summary:"下载附件"`
+	g.Meta `path:"/attachment/{id}/download" method:"get,head" tags:"附件管理" summary:"下载附件"`
 	Id     uint64 `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
 }
 
-// DownloadRes 下载附件返回参数
-type DownloadRes struct {
-	FileName string `json:"fileName" dc:"文件名"`
-	Content  []byte `json:"content" dc:"文件内容"`
-}
+// DownloadRes 下载附件返回参数，文件内容直接写入响应，支持 Range 请求
+type DownloadRes struct{}
 
 // AttachmentInfo 附件信息
 type AttachmentInfo struct {
@@ -92,9 +117,15 @@ type AttachmentInfo struct {
 	FileType     string      `json:"fileType" dc:"文件类型（MIME）"`
 	FileExt      string      `json:"fileExt" dc:"文件扩展名"`
 	FilePath     string      `json:"filePath" dc:"文件路径"`
-	FileUrl      string      `json:"fileUrl" dc:"文件URL"`
+	FileUrl      string      `json:"fileUrl" dc:"文件URL，私有附件为签名下载地址，没有访问权限时为空"`
+	FileHash     string      `json:"fileHash" dc:"文件内容SHA-256"`
 	IsImage      int         `json:"isImage" dc:"是否为图片"`
-	ThumbnailUrl string      `json:"thumbnailUrl" dc:"缩略图URL"`
+	ThumbnailUrl string      `json:"thumbnailUrl" dc:"缩略图URL，私有附件为签名下载地址，没有访问权限时为空"`
+	IsPrivate    int         `json:"isPrivate" dc:"是否私有（1私有，0公开）"`
+	AccessRoles  string      `json:"accessRoles" dc:"允许访问的角色编码（逗号分隔，为空时不限制）"`
+	FolderId     uint64      `json:"folderId" dc:"所属文件夹ID（0为根目录）"`
+	Tags         []string    `json:"tags" dc:"标签"`
+	RefCount     int         `json:"refCount" dc:"被业务数据引用的次数，被引用的附件不能删除"`
 	UploaderId   uint64      `json:"uploaderId" dc:"上传者ID"`
 	UploaderName string      `json:"uploaderName" dc:"上传者名称"`
 	Status       int         `json:"status" dc:"状态（1正常，0禁用/删除）"`
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ChunkInitReq 初始化分片上传请求参数，提供文件哈希时可续传同一文件未完成的上传
+type ChunkInitReq struct {
+	g.Meta      `path:"/attachment/chunk/init" method:"post" tags:"附件管理" summary:"初始化分片上传"`
+	FileName    string  `json:"fileName" v:"required|max-length:255#请输入文件名|文件名不能超过255个字符" dc:"原始文件名"`
+	FileSize    uint64  `json:"fileSize" v:"required|min:1#请输入文件大小|文件不能为空" dc:"文件大小（字节）"`
+	FileType    string  `json:"fileType,omitempty" dc:"客户端识别的文件类型（MIME），仅供参考，实际类型根据文件扩展名与内容确定"`
+	Scene       string  `json:"scene,omitempty" dc:"上传场景，决定允许上传的文件分类，默认 default"`
+	FileHash    string  `json:"fileHash,omitempty" v:"regex:^([a-fA-F0-9]{64})?$#文件哈希应为64位SHA-256十六进制字符串" dc:"文件内容SHA-256，提供时用于续传与合并后校验"`
+	ChunkSize   uint    `json:"chunkSize,omitempty" dc:"分片大小（字节），为空时使用默认值"`
+	Name        *string `json:"name,omitempty" dc:"自定义附件名称"`
+	IsPrivate   int     `json:"isPrivate,omitempty" v:"in:0,1#是否私有只能为0或1" dc:"是否私有，私有附件只能通过签名下载地址访问"`
+	AccessRoles string  `json:"accessRoles,omitempty" dc:"允许访问私有附件的角色编码，逗号分隔，为空时不限制"`
+	FolderId    uint64  `json:"folderId,omitempty" dc:"所属文件夹ID，为空时保存到根目录"`
+	Remark      *string `json:"remark,omitempty" dc:"备注"`
+}
+
+// ChunkInitRes 初始化分片上传返回参数
+type ChunkInitRes struct {
+	ChunkSession
+}
+
+// ChunkSession 分片上传会话
+type ChunkSession struct {
+	UploadId      string      `json:"uploadId" dc:"上传ID"`
+	FileName      string      `json:"fileName" dc:"原始文件名"`
+	FileSize      uint64      `json:"fileSize" dc:"文件大小（字节）"`
+	ChunkSize     uint        `json:"chunkSize" dc:"分片大小（字节）"`
+	ChunkCount    uint        `json:"chunkCount" dc:"分片数量"`
+	UploadedParts []uint      `json:"uploadedParts" dc:"已上传的分片序号"`
+	ExpiresAt     *gtime.Time `json:"expiresAt" dc:"过期时间"`
+}
+
+// ChunkStatusReq 查询分片上传进度请求参数
+type ChunkStatusReq struct {
+	g.Meta   `path:"/attachment/chunk/{uploadId}" method:"get" tags:"附件管理" summary:"查询分片上传进度"`
+	UploadId string `json:"uploadId" v:"required#请输入上传ID" dc:"上传ID"`
+}
+
+// ChunkStatusRes 查询分片上传进度返回参数
+type ChunkStatusRes struct {
+	ChunkSession
+}
+
+// ChunkUploadReq 上传分片请求参数，分片内容通过 multipart 的 file 字段上传，不同分片可以并行上传
+type ChunkUploadReq struct {
+	g.Meta   `path:"/attachment/chunk/{uploadId}/part" method:"post" tags:"附件管理" summary:"上传分片"`
+	UploadId string `json:"uploadId" v:"required#请输入上传ID" dc:"上传ID"`
+	Index    *uint  `json:"index" v:"required#请输入分片序号" dc:"分片序号（从0开始）"`
+	Checksum string `json:"checksum" v:"required|regex:^[a-fA-F0-9]{64}$#请输入分片校验和|分片校验和应为64位SHA-256十六进制字符串" dc:"分片内容SHA-256"`
+}
+
+// ChunkUploadRes 上传分片返回参数
+type ChunkUploadRes struct {
+	Index uint `json:"index" dc:"分片序号"`
+	Size  uint `json:"size" dc:"分片大小（字节）"`
+}
+
+// ChunkCompleteReq 完成分片上传请求参数，所有分片上传后合并为附件
+type ChunkCompleteReq struct {
+	g.Meta   `path:"/attachment/chunk/{uploadId}/complete" method:"post" tags:"附件管理" summary:"完成分片上传"`
+	UploadId string `json:"uploadId" v:"required#请输入上传ID" dc:"上传ID"`
+}
+
+// ChunkCompleteRes 完成分片上传返回参数
+type ChunkCompleteRes struct {
+	Id  uint64 `json:"id" dc:"附件ID"`
+	Url string `json:"url" dc:"访问URL，私有附件为签名下载地址"`
+}
+
+// ChunkAbortReq 取消分片上传请求参数
+type ChunkAbortReq struct {
+	g.Meta   `path:"/attachment/chunk/{uploadId}" method:"delete" tags:"附件管理" summary:"取消分片上传"`
+	UploadId string `json:"uploadId" v:"required#请输入上传ID" dc:"上传ID"`
+}
+
+// ChunkAbortRes 取消分片上传返回参数
+type ChunkAbortRes struct{}
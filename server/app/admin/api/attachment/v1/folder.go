@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// FolderCommon 附件文件夹公共字段
+type FolderCommon struct {
+	ParentId *uint64 `json:"parentId,omitempty" dc:"上级文件夹ID，0为根目录"`
+	Name     *string `json:"name,omitempty" v:"max-length:64#文件夹名称不能超过64个字符" dc:"文件夹名称"`
+	Sort     *int    `json:"sort,omitempty" v:"min:0#排序号不能小于0" dc:"排序号"`
+}
+
+// FolderListReq 查询附件文件夹列表请求参数，返回全部文件夹，由前端按上级文件夹ID构建树
+type FolderListReq struct {
+	g.Meta `path:"/attachment/folder" method:"get" tags:"附件管理" summary:"获取附件文件夹列表"`
+}
+
+// FolderListRes 查询附件文件夹列表返回参数
+type FolderListRes struct {
+	List []FolderInfo `json:"list" dc:"文件夹列表"`
+}
+
+// FolderCreateReq 创建附件文件夹请求参数
+type FolderCreateReq struct {
+	g.Meta `path:"/attachment/folder" method:"post" tags:"附件管理" summary:"创建附件文件夹"`
+	FolderCommon
+}
+
+// FolderCreateRes 创建附件文件夹返回参数
+type FolderCreateRes struct {
+	Id uint64 `json:"id" dc:"文件夹ID"`
+}
+
+// FolderUpdateReq 更新附件文件夹请求参数
+type FolderUpdateReq struct {
+	g.Meta `path:"/attachment/folder/{id}" method:"put" tags:"附件管理" summary:"更新附件文件夹"`
+	Id     uint64 `json:"id" v:"required#请输入文件夹ID" dc:"文件夹ID"`
+	FolderCommon
+}
+
+// FolderUpdateRes 更新附件文件夹返回参数
+type FolderUpdateRes struct{}
+
+// FolderDeleteReq 删除附件文件夹请求参数，文件夹下还有子文件夹或附件时不能删除
+type FolderDeleteReq struct {
+	g.Meta `path:"/attachment/folder/{id}" method:"delete" tags:"附件管理" summary:"删除附件文件夹"`
+	Id     uint64 `json:"id" v:"required#请输入文件夹ID" dc:"文件夹ID"`
+}
+
+// FolderDeleteRes 删除附件文件夹返回参数
+type FolderDeleteRes struct{}
+
+// MoveReq 移动附件到文件夹请求参数
+type MoveReq struct {
+	g.Meta   `path:"/attachment/move" method:"put" tags:"附件管理" summary:"移动附件"`
+	Ids      []uint64 `json:"ids" v:"required#请选择要移动的附件" dc:"附件ID列表"`
+	FolderId uint64   `json:"folderId" dc:"目标文件夹ID，0为根目录"`
+}
+
+// MoveRes 移动附件到文件夹返回参数
+type MoveRes struct{}
+
+// FolderInfo 附件文件夹信息
+type FolderInfo struct {
+	Id        uint64      `json:"id" dc:"文件夹ID"`
+	ParentId  uint64      `json:"parentId" dc:"上级文件夹ID（0为根目录）"`
+	Name      string      `json:"name" dc:"文件夹名称"`
+	Sort      int         `json:"sort" dc:"排序号"`
+	Count     int         `json:"count" dc:"文件夹下（不含子文件夹）的附件数"`
+	CreatedAt *gtime.Time `json:"createdAt" dc:"创建时间"`
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SignedUrlReq 获取签名下载地址请求参数
+type SignedUrlReq struct {
+	g.Meta  `path:"/attachment/{id}/signed-url" method:"post" tags:"附件管理" summary:"获取签名下载地址"`
+	Id      uint64 `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
+	Variant string `json:"variant,omitempty" dc:"图片规格名称，如 thumb，为空时下载原文件"`
+	Expire  int    `json:"expire,omitempty" v:"min:0#有效期不能为负数" dc:"有效期（秒），为空时使用配置的默认有效期，最长7天"`
+}
+
+// SignedUrlRes 获取签名下载地址返回参数
+type SignedUrlRes struct {
+	Url       string      `json:"url" dc:"签名下载地址，无需登录即可在有效期内访问"`
+	ExpiresAt *gtime.Time `json:"expiresAt" dc:"过期时间"`
+}
+
+// SignedDownloadReq 通过签名下载地址下载附件请求参数，不需要登录认证
+type SignedDownloadReq struct {
+	g.Meta    `path:"/attachment/signed/{id}" method:"get,head" tags:"附件管理" summary:"签名下载附件"`
+	Id        uint64 `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
+	Variant   string `json:"variant,omitempty" dc:"图片规格名称，为空时下载原文件"`
+	Expires   int64  `json:"expires" v:"required#请输入过期时间" dc:"过期时间（Unix时间戳，秒）"`
+	Signature string `json:"signature" v:"required#请输入签名" dc:"签名"`
+}
+
+// SignedDownloadRes 通过签名下载地址下载附件返回参数，文件内容直接写入响应，支持 Range 请求
+type SignedDownloadRes struct{}
+
+// SetVisibilityReq 设置附件可见性请求参数
+type SetVisibilityReq struct {
+	g.Meta      `path:"/attachment/{id}/visibility" method:"put" tags:"附件管理" summary:"设置附件可见性"`
+	Id          uint64 `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
+	IsPrivate   *int   `json:"isPrivate" v:"required|in:0,1#请选择是否私有|是否私有只能为0或1" dc:"是否私有，修改后文件会移动到对应的存储目录"`
+	AccessRoles string `json:"accessRoles" dc:"允许访问私有附件的角色编码，逗号分隔，为空时不限制"`
+}
+
+// SetVisibilityRes 设置附件可见性返回参数
+type SetVisibilityRes struct{}
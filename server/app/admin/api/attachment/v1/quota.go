@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// QuotaListReq 获取存储配额列表请求参数
+type QuotaListReq struct {
+	g.Meta     `path:"/attachment/quota" method:"get" tags:"附件管理" summary:"获取存储配额列表" auth:"attachment:btn:quotaList"`
+	TargetType string `json:"targetType,omitempty" v:"in:user,department#配额对象类型只能为user或department" dc:"配额对象类型：user（用户）、department（部门），为空时查询全部"`
+}
+
+// QuotaListRes 获取存储配额列表返回参数
+type QuotaListRes struct {
+	List              []QuotaInfo `json:"list" dc:"单独设置的配额列表"`
+	DefaultUser       uint64      `json:"defaultUser" dc:"未单独设置时用户的默认配额（字节），0为不限制"`
+	DefaultDepartment uint64      `json:"defaultDepartment" dc:"未单独设置时部门的默认配额（字节），0为不限制"`
+}
+
+// QuotaSetReq 设置存储配额请求参数，已设置过的配额对象会覆盖原配额
+type QuotaSetReq struct {
+	g.Meta     `path:"/attachment/quota" method:"put" tags:"附件管理" summary:"设置存储配额" auth:"attachment:btn:quotaSet"`
+	TargetType string  `json:"targetType" v:"required|in:user,department#请选择配额对象类型|配额对象类型只能为user或department" dc:"配额对象类型：user（用户）、department（部门）"`
+	TargetId   uint64  `json:"targetId" v:"required#请选择配额对象" dc:"配额对象ID（用户ID或部门ID）"`
+	QuotaSize  *uint64 `json:"quotaSize" v:"required#请输入配额大小" dc:"配额大小（字节），0为不限制"`
+}
+
+// QuotaSetRes 设置存储配额返回参数
+type QuotaSetRes struct{}
+
+// QuotaDeleteReq 删除存储配额请求参数，删除后使用默认配额
+type QuotaDeleteReq struct {
+	g.Meta `path:"/attachment/quota/{id}" method:"delete" tags:"附件管理" summary:"删除存储配额" auth:"attachment:btn:quotaDelete"`
+	Id     uint64 `json:"id" v:"required#请输入配额ID" dc:"配额ID"`
+}
+
+// QuotaDeleteRes 删除存储配额返回参数
+type QuotaDeleteRes struct{}
+
+// QuotaUsageReq 获取当前用户存储空间使用情况请求参数
+type QuotaUsageReq struct {
+	g.Meta `path:"/attachment/quota/usage" method:"get" tags:"附件管理" summary:"获取当前用户存储空间使用情况"`
+}
+
+// QuotaUsageRes 获取当前用户存储空间使用情况返回参数
+type QuotaUsageRes struct {
+	User       QuotaUsage  `json:"user" dc:"当前用户的配额与已用空间"`
+	Department *QuotaUsage `json:"department" dc:"当前用户所属部门的配额与已用空间，未分配部门时为空"`
+}
+
+// QuotaUsage 存储空间使用情况
+type QuotaUsage struct {
+	QuotaSize uint64 `json:"quotaSize" dc:"配额大小（字节），0为不限制"`
+	UsedSize  uint64 `json:"usedSize" dc:"已使用空间（字节）"`
+}
+
+// QuotaInfo 存储配额信息
+type QuotaInfo struct {
+	Id         uint64      `json:"id" dc:"配额ID"`
+	TargetType string      `json:"targetType" dc:"配额对象类型：user（用户）、department（部门）"`
+	TargetId   uint64      `json:"targetId" dc:"配额对象ID"`
+	TargetName string      `json:"targetName" dc:"配额对象名称（用户名或部门名称）"`
+	QuotaSize  uint64      `json:"quotaSize" dc:"配额大小（字节），0为不限制"`
+	UsedSize   uint64      `json:"usedSize" dc:"已使用空间（字节）"`
+	UpdatedAt  *gtime.Time `json:"updatedAt" dc:"更新时间"`
+}
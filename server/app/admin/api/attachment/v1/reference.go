@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ReferencesReq 查询附件引用请求参数
+type ReferencesReq struct {
+	g.Meta `path:"/attachment/{id}/references" method:"get" tags:"附件管理" summary:"查询附件引用"`
+	Id     uint64 `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
+}
+
+// ReferencesRes 查询附件引用返回参数
+type ReferencesRes struct {
+	List []ReferenceInfo `json:"list" dc:"引用列表"`
+}
+
+// ReferenceInfo 附件引用信息，记录使用附件的业务数据
+type ReferenceInfo struct {
+	BizType   string      `json:"bizType" dc:"业务类型（业务表名）"`
+	BizId     string      `json:"bizId" dc:"业务记录ID"`
+	BizField  string      `json:"bizField" dc:"业务字段"`
+	CreatedAt *gtime.Time `json:"createdAt" dc:"引用时间"`
+}
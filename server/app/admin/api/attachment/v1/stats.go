@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// StatsReq 获取附件统计请求参数
+type StatsReq struct {
+	g.Meta `path:"/attachment/stats" method:"get" tags:"附件管理" summary:"获取附件统计"`
+	Days   int `json:"days,omitempty" v:"between:0,365#统计天数应在0到365之间" dc:"每日上传量统计的天数（含今天），为空或0时统计30天"`
+	Top    int `json:"top,omitempty" v:"between:0,100#排行数量应在0到100之间" dc:"扩展名、上传者排行返回的数量，为空或0时返回10个"`
+}
+
+// StatsRes 获取附件统计返回参数
+type StatsRes struct {
+	TotalCount   int             `json:"totalCount" dc:"附件总数"`
+	TotalSize    uint64          `json:"totalSize" dc:"附件总大小（字节），相同内容的附件分别计算"`
+	StorageSize  uint64          `json:"storageSize" dc:"实际占用的存储空间（字节），相同内容的附件共用一个文件，只计算一次"`
+	ByType       []StatsGroup    `json:"byType" dc:"按文件类型（MIME）统计，按数量降序"`
+	ByExt        []StatsGroup    `json:"byExt" dc:"按扩展名统计，按数量降序"`
+	TopUploaders []UploaderStats `json:"topUploaders" dc:"上传量最多的上传者，按大小降序"`
+	Daily        []DailyStats    `json:"daily" dc:"每日上传量，按日期升序，没有上传的日期数量为0"`
+}
+
+// StatsGroup 附件分组统计
+type StatsGroup struct {
+	Name  string `json:"name" dc:"分组名称"`
+	Count int    `json:"count" dc:"附件数"`
+	Size  uint64 `json:"size" dc:"附件总大小（字节）"`
+}
+
+// UploaderStats 上传者统计
+type UploaderStats struct {
+	UploaderId   uint64 `json:"uploaderId" dc:"上传者ID"`
+	UploaderName string `json:"uploaderName" dc:"上传者名称"`
+	Count        int    `json:"count" dc:"附件数"`
+	Size         uint64 `json:"size" dc:"附件总大小（字节）"`
+}
+
+// DailyStats 每日上传量
+type DailyStats struct {
+	Date  string `json:"date" dc:"日期（YYYY-MM-DD）"`
+	Count int    `json:"count" dc:"上传的附件数"`
+	Size  uint64 `json:"size" dc:"上传的附件总大小（字节）"`
+}
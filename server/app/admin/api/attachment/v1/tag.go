@@ -0,0 +1,39 @@
+package v1
+
+import "github.com/gogf/gf/v2/frame/g"
+
+// TagListReq 查询附件标签列表请求参数
+type TagListReq struct {
+	g.Meta `path:"/attachment/tag" method:"get" tags:"附件管理" summary:"获取附件标签列表"`
+}
+
+// TagListRes 查询附件标签列表返回参数
+type TagListRes struct {
+	List []TagInfo `json:"list" dc:"标签列表"`
+}
+
+// TagDeleteReq 删除附件标签请求参数，同时移除附件上的该标签
+type TagDeleteReq struct {
+	g.Meta `path:"/attachment/tag/{id}" method:"delete" tags:"附件管理" summary:"删除附件标签"`
+	Id     uint64 `json:"id" v:"required#请输入标签ID" dc:"标签ID"`
+}
+
+// TagDeleteRes 删除附件标签返回参数
+type TagDeleteRes struct{}
+
+// SetTagsReq 设置附件标签请求参数，整体替换附件的标签，不存在的标签会自动创建
+type SetTagsReq struct {
+	g.Meta `path:"/attachment/{id}/tags" method:"put" tags:"附件管理" summary:"设置附件标签"`
+	Id     uint64   `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
+	Tags   []string `json:"tags" dc:"标签名称列表，最多20个，为空时清空标签"`
+}
+
+// SetTagsRes 设置附件标签返回参数
+type SetTagsRes struct{}
+
+// TagInfo 附件标签信息
+type TagInfo struct {
+	Id    uint64 `json:"id" dc:"标签ID"`
+	Name  string `json:"name" dc:"标签名称"`
+	Count int    `json:"count" dc:"使用该标签的附件数"`
+}
@@ -0,0 +1,24 @@
+package v1
+
+import "github.com/gogf/gf/v2/frame/g"
+
+// ImageVariant 图片规格
+type ImageVariant struct {
+	Name   string `json:"name" dc:"规格名称"`
+	Url    string `json:"url" dc:"访问URL"`
+	Width  int    `json:"width" dc:"宽度"`
+	Height int    `json:"height" dc:"高度"`
+	Size   int    `json:"size" dc:"文件大小"`
+}
+
+// RegenerateVariantsReq 重新生成图片规格请求参数
+type RegenerateVariantsReq struct {
+	g.Meta `path:"/attachment/{id}/variants" method:"post" tags:"附件管理" summary:"重新生成缩略图"`
+	Id     uint64 `json:"id" v:"required#请输入附件ID" dc:"附件ID"`
+}
+
+// RegenerateVariantsRes 重新生成图片规格返回参数
+type RegenerateVariantsRes struct {
+	ThumbnailUrl string         `json:"thumbnailUrl" dc:"缩略图URL"`
+	Variants     []ImageVariant `json:"variants" dc:"图片规格列表"`
+}
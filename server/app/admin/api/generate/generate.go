@@ -17,8 +17,20 @@ type IGenerateV1 interface {
 	GetCodeGenRecordDetail(ctx context.Context, req *v1.GetCodeGenRecordDetailReq) (res *v1.GetCodeGenRecordDetailRes, err error)
 	DeleteCodeGenRecord(ctx context.Context, req *v1.DeleteCodeGenRecordReq) (res *v1.DeleteCodeGenRecordRes, err error)
 	UpdateCodeGenRecord(ctx context.Context, req *v1.UpdateCodeGenRecordReq) (res *v1.UpdateCodeGenRecordRes, err error)
+	GetTemplateSets(ctx context.Context, req *v1.GetTemplateSetsReq) (res *v1.GetTemplateSetsRes, err error)
+	GetTemplate(ctx context.Context, req *v1.GetTemplateReq) (res *v1.GetTemplateRes, err error)
+	SaveTemplate(ctx context.Context, req *v1.SaveTemplateReq) (res *v1.SaveTemplateRes, err error)
+	UploadTemplate(ctx context.Context, req *v1.UploadTemplateReq) (res *v1.UploadTemplateRes, err error)
+	ValidateTemplate(ctx context.Context, req *v1.ValidateTemplateReq) (res *v1.ValidateTemplateRes, err error)
+	DeleteTemplate(ctx context.Context, req *v1.DeleteTemplateReq) (res *v1.DeleteTemplateRes, err error)
 	GenerateSql(ctx context.Context, req *v1.GenerateSqlReq) (res *v1.GenerateSqlRes, err error)
 	ExecuteSql(ctx context.Context, req *v1.ExecuteSqlReq) (res *v1.ExecuteSqlRes, err error)
+	PreviewCreateTable(ctx context.Context, req *v1.PreviewCreateTableReq) (res *v1.PreviewCreateTableRes, err error)
+	CreateTable(ctx context.Context, req *v1.CreateTableReq) (res *v1.CreateTableRes, err error)
+	PreviewAlterTable(ctx context.Context, req *v1.PreviewAlterTableReq) (res *v1.PreviewAlterTableRes, err error)
+	AlterTable(ctx context.Context, req *v1.AlterTableReq) (res *v1.AlterTableRes, err error)
+	GetTableSchema(ctx context.Context, req *v1.GetTableSchemaReq) (res *v1.GetTableSchemaRes, err error)
+	GetTableMigrations(ctx context.Context, req *v1.GetTableMigrationsReq) (res *v1.GetTableMigrationsRes, err error)
 	GetTables(ctx context.Context, req *v1.GetTablesReq) (res *v1.GetTablesRes, err error)
 	ImportTables(ctx context.Context, req *v1.ImportTablesReq) (res *v1.ImportTablesRes, err error)
 	GetTablesWithColumns(ctx context.Context, req *v1.GetTablesWithColumnsReq) (res *v1.GetTablesWithColumnsRes, err error)
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"server/app/admin/api/common/page"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SchemaColumn 表结构设计中的字段定义
+type SchemaColumn struct {
+	Name          string   `json:"name" v:"required#请输入字段名" dc:"字段名"`
+	Type          string   `json:"type" v:"required#请选择数据类型" dc:"数据类型，如 int、varchar、decimal"`
+	Length        int      `json:"length" dc:"长度/精度，varchar、char、decimal 必填"`
+	Scale         int      `json:"scale" dc:"小数位数，仅 decimal"`
+	Unsigned      bool     `json:"unsigned" dc:"是否无符号"`
+	Nullable      bool     `json:"nullable" dc:"是否允许为空"`
+	Default       *string  `json:"default" dc:"默认值，为 null 时不设置；时间字段可使用 CURRENT_TIMESTAMP"`
+	AutoIncrement bool     `json:"autoIncrement" dc:"是否自增，仅整数主键"`
+	PrimaryKey    bool     `json:"primaryKey" dc:"是否主键"`
+	OnUpdate      bool     `json:"onUpdate" dc:"更新时自动设置为当前时间"`
+	Comment       string   `json:"comment" dc:"字段注释"`
+	Values        []string `json:"values" dc:"枚举可选值，仅 enum"`
+}
+
+// SchemaIndex 表结构设计中的索引定义
+type SchemaIndex struct {
+	Name    string   `json:"name" dc:"索引名，为空时自动生成"`
+	Columns []string `json:"columns" v:"required#请选择索引字段" dc:"索引字段"`
+	Unique  bool     `json:"unique" dc:"是否唯一索引"`
+}
+
+// SchemaDiff 表结构变更差异
+type SchemaDiff struct {
+	Action string `json:"action" dc:"变更动作：add新增字段 modify修改字段 drop删除字段 index新增索引"`
+	Name   string `json:"name" dc:"字段名或索引名"`
+	Before string `json:"before" dc:"变更前定义"`
+	After  string `json:"after" dc:"变更后定义"`
+}
+
+// SchemaTable 建表定义
+type SchemaTable struct {
+	TableName string         `json:"tableName" v:"required#请输入表名" dc:"表名"`
+	Comment   string         `json:"comment" dc:"表注释"`
+	Columns   []SchemaColumn `json:"columns" v:"required#请添加字段" dc:"字段"`
+	Indexes   []SchemaIndex  `json:"indexes" dc:"索引（不含主键）"`
+}
+
+// SchemaAlter 修改表结构定义
+type SchemaAlter struct {
+	TableName     string         `json:"tableName" v:"required#请输入表名" dc:"表名"`
+	AddColumns    []SchemaColumn `json:"addColumns" dc:"新增字段"`
+	ModifyColumns []SchemaColumn `json:"modifyColumns" dc:"修改字段"`
+	DropColumns   []string       `json:"dropColumns" dc:"删除字段"`
+	AddIndexes    []SchemaIndex  `json:"addIndexes" dc:"新增索引"`
+}
+
+// PreviewCreateTableReq 预览建表语句请求
+type PreviewCreateTableReq struct {
+	g.Meta `path:"/generate/schema/create/preview" method:"post" tags:"代码生成" summary:"预览建表语句"`
+	SchemaTable
+}
+
+// PreviewCreateTableRes 预览建表语句响应
+type PreviewCreateTableRes struct {
+	Sql string `json:"sql" dc:"建表语句"`
+}
+
+// CreateTableReq 创建数据表请求
+type CreateTableReq struct {
+	g.Meta `path:"/generate/schema/create" method:"post" tags:"代码生成" summary:"根据表结构定义创建数据表"`
+	SchemaTable
+}
+
+// CreateTableRes 创建数据表响应
+type CreateTableRes struct {
+	Sql string `json:"sql" dc:"执行的建表语句"`
+}
+
+// PreviewAlterTableReq 预览修改表结构请求
+type PreviewAlterTableReq struct {
+	g.Meta `path:"/generate/schema/alter/preview" method:"post" tags:"代码生成" summary:"预览修改表结构语句及差异"`
+	SchemaAlter
+}
+
+// PreviewAlterTableRes 预览修改表结构响应
+type PreviewAlterTableRes struct {
+	Sql   string       `json:"sql" dc:"修改表结构语句"`
+	Diffs []SchemaDiff `json:"diffs" dc:"与当前表结构的差异"`
+}
+
+// AlterTableReq 修改表结构请求
+type AlterTableReq struct {
+	g.Meta `path:"/generate/schema/alter" method:"post" tags:"代码生成" summary:"修改表结构"`
+	SchemaAlter
+}
+
+// AlterTableRes 修改表结构响应
+type AlterTableRes struct {
+	Sql   string       `json:"sql" dc:"执行的修改表结构语句"`
+	Diffs []SchemaDiff `json:"diffs" dc:"变更差异"`
+}
+
+// GetTableSchemaReq 获取表结构定义请求
+type GetTableSchemaReq struct {
+	g.Meta    `path:"/generate/schema/table" method:"get" tags:"代码生成" summary:"获取表结构定义"`
+	TableName string `json:"tableName" v:"required#请输入表名" dc:"表名"`
+}
+
+// GetTableSchemaRes 获取表结构定义响应
+type GetTableSchemaRes struct {
+	SchemaTable
+}
+
+// TableMigration 表结构变更记录
+type TableMigration struct {
+	Id         uint64      `json:"id" dc:"ID"`
+	TableName  string      `json:"tableName" dc:"表名"`
+	Action     string      `json:"action" dc:"变更类型：create建表 alter修改表结构 sql执行SQL"`
+	Statement  string      `json:"statement" dc:"执行的SQL语句"`
+	Definition string      `json:"definition" dc:"表结构定义（JSON）"`
+	OperatorId uint64      `json:"operatorId" dc:"操作人ID"`
+	CreatedAt  *gtime.Time `json:"createdAt" dc:"执行时间"`
+}
+
+// GetTableMigrationsReq 获取表结构变更记录请求
+type GetTableMigrationsReq struct {
+	g.Meta    `path:"/generate/schema/migrations" method:"get" tags:"代码生成" summary:"获取表结构变更记录"`
+	TableName string `json:"tableName" dc:"表名"`
+	page.ReqPage
+}
+
+// GetTableMigrationsRes 获取表结构变更记录响应
+type GetTableMigrationsRes struct {
+	List []TableMigration `json:"list" dc:"变更记录"`
+	page.ResPage
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// TemplateFile 模板集中的模板文件
+type TemplateFile struct {
+	Name   string `json:"name" dc:"模板文件名（相对路径）"`
+	Custom bool   `json:"custom" dc:"是否由模板集自定义，否则沿用默认模板"`
+}
+
+// TemplateSet 代码生成模板集
+type TemplateSet struct {
+	Name    string         `json:"name" dc:"模板集名称"`
+	Builtin bool           `json:"builtin" dc:"是否为内置默认模板集（只读）"`
+	Files   []TemplateFile `json:"files" dc:"模板文件"`
+}
+
+// GetTemplateSetsReq 获取模板集列表请求
+type GetTemplateSetsReq struct {
+	g.Meta `path:"/generate/template/sets" method:"get" tags:"代码生成" summary:"获取模板集列表"`
+}
+
+// GetTemplateSetsRes 获取模板集列表响应
+type GetTemplateSetsRes struct {
+	List []TemplateSet `json:"list" dc:"模板集列表"`
+}
+
+// GetTemplateReq 获取模板内容请求
+type GetTemplateReq struct {
+	g.Meta `path:"/generate/template" method:"get" tags:"代码生成" summary:"获取模板内容"`
+	Set    string `json:"set" d:"default" dc:"模板集名称"`
+	Name   string `json:"name" v:"required#请选择模板文件" dc:"模板文件名"`
+}
+
+// GetTemplateRes 获取模板内容响应
+type GetTemplateRes struct {
+	Content string `json:"content" dc:"模板内容"`
+	Custom  bool   `json:"custom" dc:"是否由模板集自定义"`
+}
+
+// SaveTemplateReq 保存模板请求
+type SaveTemplateReq struct {
+	g.Meta  `path:"/generate/template" method:"put" tags:"代码生成" summary:"保存模板（模板集不存在时自动创建）"`
+	Set     string `json:"set" v:"required#请输入模板集名称" dc:"模板集名称"`
+	Name    string `json:"name" v:"required#请选择模板文件" dc:"模板文件名"`
+	Content string `json:"content" v:"required#请输入模板内容" dc:"模板内容"`
+}
+
+// SaveTemplateRes 保存模板响应
+type SaveTemplateRes struct{}
+
+// UploadTemplateReq 上传模板请求
+type UploadTemplateReq struct {
+	g.Meta `path:"/generate/template/upload" method:"post" mime:"multipart/form-data" tags:"代码生成" summary:"上传模板文件"`
+	Set    string            `json:"set" v:"required#请输入模板集名称" dc:"模板集名称"`
+	Name   string            `json:"name" v:"required#请选择模板文件" dc:"模板文件名"`
+	File   *ghttp.UploadFile `json:"file" type:"file" v:"required#请选择上传文件" dc:"模板文件"`
+}
+
+// UploadTemplateRes 上传模板响应
+type UploadTemplateRes struct{}
+
+// ValidateTemplateReq 校验模板请求
+type ValidateTemplateReq struct {
+	g.Meta  `path:"/generate/template/validate" method:"post" tags:"代码生成" summary:"使用示例数据校验模板"`
+	Name    string `json:"name" v:"required#请选择模板文件" dc:"模板文件名"`
+	Content string `json:"content" v:"required#请输入模板内容" dc:"模板内容"`
+}
+
+// ValidateTemplateRes 校验模板响应
+type ValidateTemplateRes struct {
+	Output string `json:"output" dc:"示例数据的渲染结果"`
+}
+
+// DeleteTemplateReq 删除模板请求
+type DeleteTemplateReq struct {
+	g.Meta `path:"/generate/template" method:"delete" tags:"代码生成" summary:"删除自定义模板（恢复默认），未指定模板文件时删除整个模板集"`
+	Set    string `json:"set" v:"required#请输入模板集名称" dc:"模板集名称"`
+	Name   string `json:"name" dc:"模板文件名"`
+}
+
+// DeleteTemplateRes 删除模板响应
+type DeleteTemplateRes struct{}
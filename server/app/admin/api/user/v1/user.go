@@ -152,7 +152,7 @@ type AssignRolesRes struct{}
 type UploadAvatarReq struct {
 	g.Meta `path:"/user/{id}/avatar" method:"post" tags:"用户管理" summary:"上传用户头像"`
 	Id     uint64 `json:"id" v:"required#请输入用户ID" dc:"用户ID"`
-	Avatar string `json:"avatar" v:"required#请上传头像文件" dc:"头像文件base64或URL"`
+	Avatar string `json:"avatar" v:"required#请上传头像文件" dc:"头像文件base64，或已上传的公开图片附件的URL（记录为附件引用）"`
 }
 
 // UploadAvatarRes 上传用户头像返回参数
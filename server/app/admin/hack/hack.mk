@@ -25,6 +25,11 @@ dao: cli.install
 enums: cli.install
 	@gf gen enums
 
+# Rebuild internal/router/registry_gen.go from internal/controller/*/*_new.go.
+.PHONY: registry
+registry:
+	@go run ./hack/registry
+
 # Generate Go files for Service.
 .PHONY: service
 service: cli.install
@@ -0,0 +1,19 @@
+// 重新扫描 internal/controller 并生成 internal/router/registry_gen.go。
+// 该工具不依赖 router 包，即使删除模块后注册表已失效也可以运行：
+//
+//	go run ./hack/registry
+package main
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+
+	"server/app/admin/internal/library/generate/curd"
+)
+
+func main() {
+	ctx := gctx.GetInitCtx()
+	if err := curd.NewRegistryGenerator().Generate(ctx); err != nil {
+		g.Log().Fatal(ctx, err)
+	}
+}
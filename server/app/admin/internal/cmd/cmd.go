@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"context"
+	"strings"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/os/gtimer"
 
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/library/migrate"
+	"server/app/admin/internal/library/storage"
+	"server/app/admin/internal/logic/attachment"
 	"server/app/admin/internal/middleware"
 	"server/app/admin/internal/router"
 )
@@ -17,22 +23,44 @@ var (
 		Usage: "main",
 		Brief: "start http server",
 		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			// 配置 migrate.auto 为 true 时，启动前自动执行未执行的迁移
+			if g.Cfg().MustGet(ctx, "migrate.auto").Bool() {
+				if err = runMigrate(ctx, parser, 0, (*migrate.Migrator).Up, "执行"); err != nil {
+					return err
+				}
+			}
+
+			// 定期清理过期未完成的分片上传
+			gtimer.AddSingleton(ctx, consts.ChunkCleanupPeriod, func(ctx context.Context) {
+				if _, err := attachment.New().CleanupUploadSessions(ctx); err != nil {
+					g.Log().Warning(ctx, "清理过期的分片上传失败", g.Map{"error": err})
+				}
+			})
+
 			s := g.Server()
 
-			//设置静态资源访问目录
-			s.AddStaticPath("/uploads", "./uploads")
+			// 使用本地存储时，由服务提供上传文件的访问
+			storageConfig, err := storage.LoadConfig(ctx)
+			if err != nil {
+				return err
+			}
+			if local := storageConfig.Local.WithDefaults(); storageConfig.Driver == storage.DriverLocal && strings.HasPrefix(local.BaseUrl, "/") {
+				s.AddStaticPath(local.BaseUrl, local.Root)
+				s.BindHookHandler(strings.TrimRight(local.BaseUrl, "/")+"/*", ghttp.HookBeforeServe, middleware.UploadHeaders)
+				s.BindHookHandler(strings.TrimRight(local.BaseUrl, "/")+"/*", ghttp.HookBeforeServe, middleware.PrivateUploads(local.BaseUrl))
+			}
 
 			s.Group("/admin", func(group *ghttp.RouterGroup) {
-				group.Middleware(ghttp.MiddlewareHandlerResponse)
-				group.Middleware(middleware.Auth) // 添加认证中间件
+				group.Middleware(middleware.HandlerResponse) // 统一响应中间件
+				group.Middleware(middleware.Auth)            // 添加认证中间件
+				group.Middleware(middleware.Permission)      // 按钮权限校验中间件
 
-				// 自动绑定所有注册的控制器
-				controllers := router.GetAllControllers()
+				// 自动绑定所有启用的控制器（router.disabled 配置可禁用模块）
+				controllers, controllerNames := router.GetEnabledControllers(ctx)
 				g.Log().Infof(ctx, "正在绑定 %d 个控制器", len(controllers))
 				group.Bind(controllers...)
 
 				// 打印已注册的控制器列表
-				controllerNames := router.GetControllerNames()
 				g.Log().Infof(ctx, "已注册的控制器: %v", controllerNames)
 			})
 			s.Run()
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+
+	"server/app/admin/internal/library/migrate"
+)
+
+// migrateStepArgument 迁移数量参数
+var migrateStepArgument = gcmd.Argument{Name: "step", Short: "n", Brief: "number of migrations to apply or revert, 0 means all"}
+
+var (
+	// Migrate 数据库迁移命令，迁移文件内嵌于 internal/library/migrate/sql
+	Migrate = gcmd.Command{
+		Name:  "migrate",
+		Usage: "migrate status|up|down|redo [-n step]",
+		Brief: "manage versioned database migrations",
+	}
+
+	// MigrateStatus 查看迁移状态
+	MigrateStatus = gcmd.Command{
+		Name:  "status",
+		Usage: "migrate status",
+		Brief: "show applied and pending migrations",
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			migrator, err := migrate.New(g.DB())
+			if err != nil {
+				return err
+			}
+			states, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+			for _, state := range states {
+				appliedAt := "-"
+				if state.AppliedAt != nil {
+					appliedAt = state.AppliedAt.String()
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", state.Version, state.Name, state.Status, appliedAt)
+			}
+			return w.Flush()
+		},
+	}
+
+	// MigrateUp 执行未执行的迁移
+	MigrateUp = gcmd.Command{
+		Name:      "up",
+		Usage:     "migrate up [-n step]",
+		Brief:     "apply pending migrations, all of them by default",
+		Arguments: []gcmd.Argument{migrateStepArgument},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			return runMigrate(ctx, parser, 0, (*migrate.Migrator).Up, "执行")
+		},
+	}
+
+	// MigrateDown 回滚已执行的迁移
+	MigrateDown = gcmd.Command{
+		Name:      "down",
+		Usage:     "migrate down [-n step]",
+		Brief:     "revert applied migrations, the latest one by default",
+		Arguments: []gcmd.Argument{migrateStepArgument},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			return runMigrate(ctx, parser, 1, (*migrate.Migrator).Down, "回滚")
+		},
+	}
+
+	// MigrateRedo 回滚后重新执行最近的迁移
+	MigrateRedo = gcmd.Command{
+		Name:      "redo",
+		Usage:     "migrate redo [-n step]",
+		Brief:     "revert and re-apply the latest migrations, the latest one by default",
+		Arguments: []gcmd.Argument{migrateStepArgument},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			return runMigrate(ctx, parser, 1, (*migrate.Migrator).Redo, "重新执行")
+		},
+	}
+)
+
+func init() {
+	if err := Migrate.AddCommand(&MigrateStatus, &MigrateUp, &MigrateDown, &MigrateRedo); err != nil {
+		panic(err)
+	}
+	if err := Main.AddCommand(&Migrate); err != nil {
+		panic(err)
+	}
+}
+
+// runMigrate 读取迁移数量参数并执行迁移操作，defaultStep 为未指定 -n 时的数量（0 表示全部）
+func runMigrate(
+	ctx context.Context,
+	parser *gcmd.Parser,
+	defaultStep int,
+	action func(*migrate.Migrator, context.Context, int) ([]migrate.Migration, error),
+	verb string,
+) error {
+	step := parser.GetOpt("step", defaultStep).Int()
+	if step < 0 {
+		return fmt.Errorf("迁移数量不能小于 0")
+	}
+
+	migrator, err := migrate.New(g.DB())
+	if err != nil {
+		return err
+	}
+	done, err := action(migrator, ctx, step)
+	for _, migration := range done {
+		g.Log().Infof(ctx, "已%s迁移 %d_%s", verb, migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		g.Log().Infof(ctx, "没有需要%s的迁移", verb)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/logic/attachment"
+	"server/app/admin/internal/model"
+)
+
+var (
+	// Reconcile 存储对账命令，默认只报告，指定 -apply 时才隔离或删除孤儿文件
+	Reconcile = gcmd.Command{
+		Name:  "reconcile",
+		Usage: "reconcile [-action quarantine|delete] [-age 1h] [-apply]",
+		Brief: "find orphan files in storage and records whose files are missing, dry run unless -apply is given",
+		Arguments: []gcmd.Argument{
+			{Name: "action", Short: "a", Brief: "what to do with orphan files: quarantine (default) moves them to " + consts.QuarantineStoragePrefix + ", delete removes them"},
+			{Name: "age", Brief: "only files last modified longer ago than this are treated as orphans, e.g. 30m, 24h, default 1h"},
+			{Name: "apply", Orphan: true, Brief: "quarantine or delete the orphan files, otherwise only report them"},
+		},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			in := model.ReconcileInput{
+				Action: parser.GetOpt("action").String(),
+				DryRun: parser.GetOpt("apply") == nil,
+			}
+			if age := parser.GetOpt("age").String(); age != "" {
+				if in.MinAge, err = time.ParseDuration(age); err != nil || in.MinAge <= 0 {
+					return fmt.Errorf("age 不合法: %s", age)
+				}
+			}
+
+			out, err := attachment.New().Reconcile(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ORPHAN\tSIZE\tMODIFIED AT\tRESULT")
+			for _, orphan := range out.Orphans {
+				result := "-"
+				switch {
+				case orphan.Handled && out.Quarantine != "":
+					result = "quarantined"
+				case orphan.Handled:
+					result = "deleted"
+				case orphan.Error != "":
+					result = "failed: " + orphan.Error
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", orphan.Key, orphan.Size, orphan.ModTime.Format(time.DateTime), result)
+			}
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "MISSING\tSOURCE\tID")
+			for _, missing := range out.Missing {
+				fmt.Fprintf(w, "%s\t%s\t%d\n", missing.Key, missing.Source, missing.Id)
+			}
+			if err = w.Flush(); err != nil {
+				return err
+			}
+
+			g.Log().Infof(ctx, "已扫描 %d 个文件，孤儿文件 %d 个，缺失文件的记录 %d 条", out.Scanned, len(out.Orphans), len(out.Missing))
+			switch {
+			case in.DryRun && len(out.Orphans) > 0:
+				g.Log().Info(ctx, "试运行未处理孤儿文件，确认后使用 -apply 隔离或删除")
+			case out.Quarantine != "":
+				g.Log().Infof(ctx, "已将 %d 个孤儿文件移入隔离目录 %s", out.Handled, out.Quarantine)
+			case out.Handled > 0:
+				g.Log().Infof(ctx, "已删除 %d 个孤儿文件", out.Handled)
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	if err := Main.AddCommand(&Reconcile); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,68 @@
+package consts
+
+import "time"
+
+// 上传场景，场景允许上传的文件分类可通过 attachment.scenes 配置
+const (
+	UploadSceneDefault = "default" // 默认场景，允许图片、文档与压缩包
+	UploadSceneImage   = "image"   // 图片场景，只允许图片
+)
+
+// 分片上传会话状态（对应 upload_session.status）
+const (
+	UploadSessionUploading = 0 // 上传中
+	UploadSessionMerging   = 1 // 合并中
+)
+
+// 分片上传限制
+const (
+	ChunkSizeMin        = 256 * 1024             // 分片最小大小（最后一个分片除外）
+	ChunkSizeMax        = 5 * 1024 * 1024        // 分片最大大小，需小于服务的 clientMaxBodySize（默认 8MB）
+	ChunkSizeDefault    = 5 * 1024 * 1024        // 默认分片大小
+	ChunkFileSizeMax    = 2 * 1024 * 1024 * 1024 // 分片上传的文件最大大小
+	ChunkSessionTTL     = 24 * time.Hour         // 上传会话自最后一次上传分片起的有效期，过期未完成的会话会被清理
+	ChunkCleanupPeriod  = time.Hour              // 清理过期上传会话的间隔
+	ChunkStoragePrefix  = "chunks"               // 分片在存储中的目录
+	SingleUploadSizeMax = 50 * 1024 * 1024       // 单次上传的文件最大大小，更大的文件使用分片上传
+)
+
+// 图片处理
+const (
+	ImageProcessSizeMax   = 20 * 1024 * 1024 // 去除元数据与生成缩略图的图片最大大小，更大的图片原样保存且不生成缩略图
+	ImageVariantPrefix    = "variants"       // 缩略图等图片规格在存储中的目录
+	ImageThumbnailDefault = "thumb"          // 默认作为缩略图的图片规格
+)
+
+// 私有附件
+const (
+	PrivateStoragePrefix   = "private"                   // 私有文件在存储中的目录，本地存储不提供该目录的静态访问
+	SignedUrlPath          = "/admin/attachment/signed/" // 签名下载地址的路径前缀，不需要登录认证
+	SignedUrlExpireDefault = 10 * time.Minute            // 签名下载地址的默认有效期
+	SignedUrlExpireMax     = 7 * 24 * time.Hour          // 签名下载地址的最长有效期
+)
+
+// 附件整理
+const (
+	AttachmentTagMax     = 20 // 每个附件最多的标签数
+	AttachmentTagNameMax = 32 // 标签名称最大长度
+)
+
+// 存储配额对象类型（对应 attachment_quota.target_type）
+const (
+	QuotaTargetUser       = "user"       // 用户，统计用户上传的附件
+	QuotaTargetDepartment = "department" // 部门，统计部门下用户上传的附件（不含子部门）
+)
+
+// 附件统计
+const (
+	AttachmentStatsDaysDefault = 30 // 每日上传量默认统计的天数
+	AttachmentStatsTopDefault  = 10 // 扩展名、上传者排行默认返回的数量
+)
+
+// 存储对账：比对存储中的文件与附件、用户头像等数据库记录
+const (
+	ReconcileActionQuarantine = "quarantine"                         // 将孤儿文件移入隔离目录，确认无用后可手动删除
+	ReconcileActionDelete     = "delete"                             // 直接删除孤儿文件
+	ReconcileMinAgeDefault    = time.Hour                            // 默认只处理修改时间在 1 小时前的孤儿文件，避免误处理已保存但尚未写入数据库的文件
+	QuarantineStoragePrefix   = PrivateStoragePrefix + "/quarantine" // 隔离的孤儿文件在存储中的目录，按对账时间分目录保存原路径
+)
@@ -8,6 +8,17 @@ const (
 	OptionDelete      = "delete"      // 生成删除接口
 	OptionBatchDelete = "batchDelete" // 生成批量删除接口
 	OptionList        = "list"        // 生成列表接口
+	OptionExport      = "export"      // 生成导出接口
+	OptionImport      = "import"      // 生成导入接口
+
+	// 关联关系
+	RelationManyToOne = "manyToOne" // 多对一：列表中显示关联表字段
+	RelationOneToMany = "oneToMany" // 一对多：表单中内联编辑子表
+
+	// 表结构变更类型
+	MigrationCreate = "create" // 表结构设计：建表
+	MigrationAlter  = "alter"  // 表结构设计：修改表结构
+	MigrationSql    = "sql"    // 执行SQL建表
 
 	// 默认选项（全部生成）
 	DefaultOptions = "create,update,delete,batchDelete,list"
@@ -20,10 +31,33 @@ type GenerateOptions struct {
 	Delete      bool `json:"delete"`      // 是否生成删除接口
 	BatchDelete bool `json:"batchDelete"` // 是否生成批量删除接口
 	List        bool `json:"list"`        // 是否生成列表接口
+	Export      bool `json:"export"`      // 是否生成导出接口
+	Import      bool `json:"import"`      // 是否生成导入接口
 	//menuIcon
 	MenuIcon string `json:"menuIcon"` // 菜单图标
 	//menuName
 	MenuName string `json:"menuName"` // 菜单名称
 	//parentMenuId
 	ParentMenuId int `json:"parentMenuId"` // 父菜单ID
+	//roleIds
+	RoleIds []uint64 `json:"roleIds"` // 授予按钮权限的角色ID
+	//joinTables
+	JoinTables []JoinTableConfig `json:"joinTables"` // 关联表配置
+	//templateSet
+	TemplateSet string `json:"templateSet"` // 模板集名称，为空时使用默认模板
+	//treeParent
+	TreeParent string `json:"treeParent"` // 树表父级字段，为空时生成普通表
+	//treeLabel
+	TreeLabel string `json:"treeLabel"` // 树表上级选择器中显示的字段，为空时自动选择
+}
+
+// JoinTableConfig 关联表配置
+type JoinTableConfig struct {
+	TableName  string `json:"tableName"`  // 关联表名称
+	Alias      string `json:"alias"`      // 别名
+	JoinType   string `json:"joinType"`   // 关联方式: LEFT, RIGHT, INNER
+	JoinField  string `json:"joinField"`  // 关联表字段
+	MainField  string `json:"mainField"`  // 主表关联字段
+	Relation   string `json:"relation"`   // 关联关系: manyToOne, oneToMany
+	LabelField string `json:"labelField"` // 多对一时在列表中显示的关联表字段
 }
@@ -0,0 +1,9 @@
+package consts
+
+// 菜单类型（对应 menu.menu_type）
+const (
+	MenuTypeMenu   = 0 // 菜单
+	MenuTypeIframe = 1 // iframe
+	MenuTypeLink   = 2 // 外链
+	MenuTypeButton = 3 // 按钮
+)
@@ -13,6 +13,9 @@ func (c *ControllerV1) GetList(ctx context.Context, req *v1.GetListReq) (res *v1
 func (c *ControllerV1) Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error) {
 	return attachment.New().Upload(ctx, req)
 }
+func (c *ControllerV1) InstantUpload(ctx context.Context, req *v1.InstantUploadReq) (res *v1.InstantUploadRes, err error) {
+	return attachment.New().InstantUpload(ctx, req)
+}
 func (c *ControllerV1) Update(ctx context.Context, req *v1.UpdateReq) (res *v1.UpdateRes, err error) {
 	return attachment.New().Update(ctx, req)
 }
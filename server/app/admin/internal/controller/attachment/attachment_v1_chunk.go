@@ -0,0 +1,24 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) ChunkInit(ctx context.Context, req *v1.ChunkInitReq) (res *v1.ChunkInitRes, err error) {
+	return attachment.New().ChunkInit(ctx, req)
+}
+func (c *ControllerV1) ChunkStatus(ctx context.Context, req *v1.ChunkStatusReq) (res *v1.ChunkStatusRes, err error) {
+	return attachment.New().ChunkStatus(ctx, req)
+}
+func (c *ControllerV1) ChunkUpload(ctx context.Context, req *v1.ChunkUploadReq) (res *v1.ChunkUploadRes, err error) {
+	return attachment.New().ChunkUpload(ctx, req)
+}
+func (c *ControllerV1) ChunkComplete(ctx context.Context, req *v1.ChunkCompleteReq) (res *v1.ChunkCompleteRes, err error) {
+	return attachment.New().ChunkComplete(ctx, req)
+}
+func (c *ControllerV1) ChunkAbort(ctx context.Context, req *v1.ChunkAbortReq) (res *v1.ChunkAbortRes, err error) {
+	return attachment.New().ChunkAbort(ctx, req)
+}
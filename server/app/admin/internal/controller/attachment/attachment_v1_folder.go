@@ -0,0 +1,28 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) FolderList(ctx context.Context, req *v1.FolderListReq) (res *v1.FolderListRes, err error) {
+	return attachment.New().FolderList(ctx, req)
+}
+
+func (c *ControllerV1) FolderCreate(ctx context.Context, req *v1.FolderCreateReq) (res *v1.FolderCreateRes, err error) {
+	return attachment.New().FolderCreate(ctx, req)
+}
+
+func (c *ControllerV1) FolderUpdate(ctx context.Context, req *v1.FolderUpdateReq) (res *v1.FolderUpdateRes, err error) {
+	return attachment.New().FolderUpdate(ctx, req)
+}
+
+func (c *ControllerV1) FolderDelete(ctx context.Context, req *v1.FolderDeleteReq) (res *v1.FolderDeleteRes, err error) {
+	return attachment.New().FolderDelete(ctx, req)
+}
+
+func (c *ControllerV1) Move(ctx context.Context, req *v1.MoveReq) (res *v1.MoveRes, err error) {
+	return attachment.New().Move(ctx, req)
+}
@@ -0,0 +1,20 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) SignedUrl(ctx context.Context, req *v1.SignedUrlReq) (res *v1.SignedUrlRes, err error) {
+	return attachment.New().SignedUrl(ctx, req)
+}
+
+func (c *ControllerV1) SignedDownload(ctx context.Context, req *v1.SignedDownloadReq) (res *v1.SignedDownloadRes, err error) {
+	return attachment.New().SignedDownload(ctx, req)
+}
+
+func (c *ControllerV1) SetVisibility(ctx context.Context, req *v1.SetVisibilityReq) (res *v1.SetVisibilityRes, err error) {
+	return attachment.New().SetVisibility(ctx, req)
+}
@@ -0,0 +1,24 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) QuotaList(ctx context.Context, req *v1.QuotaListReq) (res *v1.QuotaListRes, err error) {
+	return attachment.New().QuotaList(ctx, req)
+}
+
+func (c *ControllerV1) QuotaSet(ctx context.Context, req *v1.QuotaSetReq) (res *v1.QuotaSetRes, err error) {
+	return attachment.New().QuotaSet(ctx, req)
+}
+
+func (c *ControllerV1) QuotaDelete(ctx context.Context, req *v1.QuotaDeleteReq) (res *v1.QuotaDeleteRes, err error) {
+	return attachment.New().QuotaDelete(ctx, req)
+}
+
+func (c *ControllerV1) QuotaUsage(ctx context.Context, req *v1.QuotaUsageReq) (res *v1.QuotaUsageRes, err error) {
+	return attachment.New().QuotaUsage(ctx, req)
+}
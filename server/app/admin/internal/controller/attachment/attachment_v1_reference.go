@@ -0,0 +1,12 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) References(ctx context.Context, req *v1.ReferencesReq) (res *v1.ReferencesRes, err error) {
+	return attachment.New().References(ctx, req)
+}
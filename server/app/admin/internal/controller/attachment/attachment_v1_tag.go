@@ -0,0 +1,20 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) TagList(ctx context.Context, req *v1.TagListReq) (res *v1.TagListRes, err error) {
+	return attachment.New().TagList(ctx, req)
+}
+
+func (c *ControllerV1) TagDelete(ctx context.Context, req *v1.TagDeleteReq) (res *v1.TagDeleteRes, err error) {
+	return attachment.New().TagDelete(ctx, req)
+}
+
+func (c *ControllerV1) SetTags(ctx context.Context, req *v1.SetTagsReq) (res *v1.SetTagsRes, err error) {
+	return attachment.New().SetTags(ctx, req)
+}
@@ -0,0 +1,12 @@
+package attachment
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/logic/attachment"
+)
+
+func (c *ControllerV1) RegenerateVariants(ctx context.Context, req *v1.RegenerateVariantsReq) (res *v1.RegenerateVariantsRes, err error) {
+	return attachment.New().RegenerateVariants(ctx, req)
+}
@@ -0,0 +1,32 @@
+package generate
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/generate/v1"
+	"server/app/admin/internal/logic/generate"
+)
+
+func (c *ControllerV1) PreviewCreateTable(ctx context.Context, req *v1.PreviewCreateTableReq) (res *v1.PreviewCreateTableRes, err error) {
+	return generate.New().PreviewCreateTable(ctx, *req)
+}
+
+func (c *ControllerV1) CreateTable(ctx context.Context, req *v1.CreateTableReq) (res *v1.CreateTableRes, err error) {
+	return generate.New().CreateTable(ctx, *req)
+}
+
+func (c *ControllerV1) PreviewAlterTable(ctx context.Context, req *v1.PreviewAlterTableReq) (res *v1.PreviewAlterTableRes, err error) {
+	return generate.New().PreviewAlterTable(ctx, *req)
+}
+
+func (c *ControllerV1) AlterTable(ctx context.Context, req *v1.AlterTableReq) (res *v1.AlterTableRes, err error) {
+	return generate.New().AlterTable(ctx, *req)
+}
+
+func (c *ControllerV1) GetTableSchema(ctx context.Context, req *v1.GetTableSchemaReq) (res *v1.GetTableSchemaRes, err error) {
+	return generate.New().GetTableSchema(ctx, *req)
+}
+
+func (c *ControllerV1) GetTableMigrations(ctx context.Context, req *v1.GetTableMigrationsReq) (res *v1.GetTableMigrationsRes, err error) {
+	return generate.New().GetTableMigrations(ctx, *req)
+}
@@ -0,0 +1,32 @@
+package generate
+
+import (
+	"context"
+
+	v1 "server/app/admin/api/generate/v1"
+	"server/app/admin/internal/logic/generate"
+)
+
+func (c *ControllerV1) GetTemplateSets(ctx context.Context, req *v1.GetTemplateSetsReq) (res *v1.GetTemplateSetsRes, err error) {
+	return generate.New().GetTemplateSets(ctx, *req)
+}
+
+func (c *ControllerV1) GetTemplate(ctx context.Context, req *v1.GetTemplateReq) (res *v1.GetTemplateRes, err error) {
+	return generate.New().GetTemplate(ctx, *req)
+}
+
+func (c *ControllerV1) SaveTemplate(ctx context.Context, req *v1.SaveTemplateReq) (res *v1.SaveTemplateRes, err error) {
+	return generate.New().SaveTemplate(ctx, *req)
+}
+
+func (c *ControllerV1) UploadTemplate(ctx context.Context, req *v1.UploadTemplateReq) (res *v1.UploadTemplateRes, err error) {
+	return generate.New().UploadTemplate(ctx, *req)
+}
+
+func (c *ControllerV1) ValidateTemplate(ctx context.Context, req *v1.ValidateTemplateReq) (res *v1.ValidateTemplateRes, err error) {
+	return generate.New().ValidateTemplate(ctx, *req)
+}
+
+func (c *ControllerV1) DeleteTemplate(ctx context.Context, req *v1.DeleteTemplateReq) (res *v1.DeleteTemplateRes, err error) {
+	return generate.New().DeleteTemplate(ctx, *req)
+}
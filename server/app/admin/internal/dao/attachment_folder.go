@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// attachmentFolderDao is the data access object for the table attachment_folder.
+// You can define custom methods on it to extend its functionality as needed.
+type attachmentFolderDao struct {
+	*internal.AttachmentFolderDao
+}
+
+var (
+	// AttachmentFolder is a globally accessible object for table attachment_folder operations.
+	AttachmentFolder = attachmentFolderDao{internal.NewAttachmentFolderDao()}
+)
+
+// Add your custom methods and functionality below.
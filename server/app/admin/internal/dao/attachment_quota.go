@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// attachmentQuotaDao is the data access object for the table attachment_quota.
+// You can define custom methods on it to extend its functionality as needed.
+type attachmentQuotaDao struct {
+	*internal.AttachmentQuotaDao
+}
+
+var (
+	// AttachmentQuota is a globally accessible object for table attachment_quota operations.
+	AttachmentQuota = attachmentQuotaDao{internal.NewAttachmentQuotaDao()}
+)
+
+// Add your custom methods and functionality below.
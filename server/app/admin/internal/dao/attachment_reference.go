@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// attachmentReferenceDao is the data access object for the table attachment_reference.
+// You can define custom methods on it to extend its functionality as needed.
+type attachmentReferenceDao struct {
+	*internal.AttachmentReferenceDao
+}
+
+var (
+	// AttachmentReference is a globally accessible object for table attachment_reference operations.
+	AttachmentReference = attachmentReferenceDao{internal.NewAttachmentReferenceDao()}
+)
+
+// Add your custom methods and functionality below.
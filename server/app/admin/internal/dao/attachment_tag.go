@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// attachmentTagDao is the data access object for the table attachment_tag.
+// You can define custom methods on it to extend its functionality as needed.
+type attachmentTagDao struct {
+	*internal.AttachmentTagDao
+}
+
+var (
+	// AttachmentTag is a globally accessible object for table attachment_tag operations.
+	AttachmentTag = attachmentTagDao{internal.NewAttachmentTagDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// attachmentTagRelationDao is the data access object for the table attachment_tag_relation.
+// You can define custom methods on it to extend its functionality as needed.
+type attachmentTagRelationDao struct {
+	*internal.AttachmentTagRelationDao
+}
+
+var (
+	// AttachmentTagRelation is a globally accessible object for table attachment_tag_relation operations.
+	AttachmentTagRelation = attachmentTagRelationDao{internal.NewAttachmentTagRelationDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -29,8 +29,13 @@ type AttachmentColumns struct {
 	FileExt      string // 文件扩展名
 	FilePath     string // 文件路径
 	FileUrl      string // 文件URL
+	FileHash     string // 文件内容SHA-256
+	SourceHash   string // 上传文件的原始内容SHA-256
 	IsImage      string // 是否为图片
 	ThumbnailUrl string // 缩略图URL
+	IsPrivate    string // 是否私有（1私有，0公开）
+	AccessRoles  string // 允许访问的角色编码（逗号分隔，为空时不限制）
+	FolderId     string // 所属文件夹ID（0为根目录）
 	UploaderId   string // 上传者ID
 	UploaderName string // 上传者名称
 	Status       string // 状态（1正常，0禁用/删除）
@@ -49,8 +54,13 @@ var attachmentColumns = AttachmentColumns{
 	FileExt:      "file_ext",
 	FilePath:     "file_path",
 	FileUrl:      "file_url",
+	FileHash:     "file_hash",
+	SourceHash:   "source_hash",
 	IsImage:      "is_image",
 	ThumbnailUrl: "thumbnail_url",
+	IsPrivate:    "is_private",
+	AccessRoles:  "access_roles",
+	FolderId:     "folder_id",
 	UploaderId:   "uploader_id",
 	UploaderName: "uploader_name",
 	Status:       "status",
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// tableMigrationDao is the data access object for the table table_migration.
+// You can define custom methods on it to extend its functionality as needed.
+type tableMigrationDao struct {
+	*internal.TableMigrationDao
+}
+
+var (
+	// TableMigration is a globally accessible object for table table_migration operations.
+	TableMigration = tableMigrationDao{internal.NewTableMigrationDao()}
+)
+
+// Add your custom methods and functionality below.
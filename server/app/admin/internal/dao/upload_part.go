@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// uploadPartDao is the data access object for the table upload_part.
+// You can define custom methods on it to extend its functionality as needed.
+type uploadPartDao struct {
+	*internal.UploadPartDao
+}
+
+var (
+	// UploadPart is a globally accessible object for table upload_part operations.
+	UploadPart = uploadPartDao{internal.NewUploadPartDao()}
+)
+
+// Add your custom methods and functionality below.
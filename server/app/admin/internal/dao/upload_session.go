@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"server/app/admin/internal/dao/internal"
+)
+
+// uploadSessionDao is the data access object for the table upload_session.
+// You can define custom methods on it to extend its functionality as needed.
+type uploadSessionDao struct {
+	*internal.UploadSessionDao
+}
+
+var (
+	// UploadSession is a globally accessible object for table upload_session operations.
+	UploadSession = uploadSessionDao{internal.NewUploadSessionDao()}
+)
+
+// Add your custom methods and functionality below.
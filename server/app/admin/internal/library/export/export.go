@@ -0,0 +1,185 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/model/entity"
+	"strings"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// 导出格式
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	// BatchSize 分批查询导出数据的每批条数
+	BatchSize = 500
+
+	// sheetName XLSX工作表名称
+	sheetName = "Sheet1"
+)
+
+// Writer 导出文件写入器，CSV 逐批写入响应，XLSX 通过流式工作表写入后一次性输出
+type Writer struct {
+	r      *ghttp.Request
+	format string
+	csv    *csv.Writer
+	xlsx   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// NewWriter 创建导出写入器并写入表头
+// filename 为不含扩展名的下载文件名
+func NewWriter(r *ghttp.Request, format, filename string, headers []string) (*Writer, error) {
+	if r == nil {
+		return nil, fmt.Errorf("导出需要在HTTP请求上下文中调用")
+	}
+	if format == "" {
+		format = FormatCSV
+	}
+
+	w := &Writer{r: r, format: format}
+	switch format {
+	case FormatCSV:
+		r.Response.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		// 写入UTF-8 BOM，避免Excel打开中文乱码
+		r.Response.Write("\xEF\xBB\xBF")
+		w.csv = csv.NewWriter(r.Response.BufferWriter)
+	case FormatXLSX:
+		w.xlsx = excelize.NewFile()
+		stream, err := w.xlsx.NewStreamWriter(sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("创建XLSX工作表失败: %v", err)
+		}
+		w.stream = stream
+		r.Response.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	default:
+		return nil, fmt.Errorf("不支持的导出格式: %s", format)
+	}
+
+	name := url.PathEscape(filename + "." + format)
+	r.Response.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+name)
+
+	if err := w.Write(headers); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write 写入一行数据
+func (w *Writer) Write(values []string) error {
+	if w.csv != nil {
+		if err := w.csv.Write(values); err != nil {
+			return fmt.Errorf("写入CSV失败: %v", err)
+		}
+		return nil
+	}
+
+	w.row++
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	if err = w.stream.SetRow(cell, cells); err != nil {
+		return fmt.Errorf("写入XLSX失败: %v", err)
+	}
+	return nil
+}
+
+// Flush 将已写入的CSV数据推送给客户端
+func (w *Writer) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return fmt.Errorf("写入CSV失败: %v", err)
+	}
+	w.r.Response.Flush()
+	return nil
+}
+
+// Close 完成导出，XLSX 在此时写入响应
+func (w *Writer) Close() error {
+	if w.csv != nil {
+		return w.Flush()
+	}
+
+	defer w.xlsx.Close()
+	if err := w.stream.Flush(); err != nil {
+		return fmt.Errorf("生成XLSX失败: %v", err)
+	}
+	if err := w.xlsx.Write(w.r.Response.BufferWriter); err != nil {
+		return fmt.Errorf("输出XLSX失败: %v", err)
+	}
+	return nil
+}
+
+// DictLabels 加载字典值到字典标签的映射，返回 map[字典类型]map[字典值]字典标签
+func DictLabels(ctx context.Context, dictTypes ...string) (map[string]map[string]string, error) {
+	labels := make(map[string]map[string]string, len(dictTypes))
+	if len(dictTypes) == 0 {
+		return labels, nil
+	}
+
+	var list []*entity.Dict
+	err := dao.Dict.Ctx(ctx).
+		WhereIn(dao.Dict.Columns().DictType, dictTypes).
+		Scan(&list)
+	if err != nil {
+		return nil, fmt.Errorf("查询字典数据失败: %v", err)
+	}
+
+	for _, item := range list {
+		if labels[item.DictType] == nil {
+			labels[item.DictType] = make(map[string]string)
+		}
+		labels[item.DictType][item.DictValue] = item.DictLabel
+	}
+	return labels, nil
+}
+
+// Label 将字典值转换为字典标签，未匹配时返回原值
+// 多选字段以JSON数组保存，逐项转换后以逗号拼接
+func Label(labels map[string]map[string]string, dictType string, value interface{}) string {
+	str := gconv.String(value)
+	if label, ok := labels[dictType][str]; ok {
+		return label
+	}
+	if !strings.HasPrefix(str, "[") {
+		return str
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(str), &values); err != nil {
+		return str
+	}
+	for i, v := range values {
+		if label, ok := labels[dictType][v]; ok {
+			values[i] = label
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+// Value 将字典标签转换回字典值（导入时使用），未匹配时返回原文本
+func Value(labels map[string]map[string]string, dictType string, label string) string {
+	for value, text := range labels[dictType] {
+		if text == label {
+			return value
+		}
+	}
+	return label
+}
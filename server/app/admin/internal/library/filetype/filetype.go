@@ -0,0 +1,167 @@
+package filetype
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 文件分类
+const (
+	CategoryImage    = "image"    // 图片
+	CategoryDocument = "document" // 文档
+	CategoryArchive  = "archive"  // 压缩包
+)
+
+// SniffMaxSize 识别文件类型需要读取的文件开头长度
+const SniffMaxSize = 512
+
+// 根据文件内容识别出的类型中，net/http 不支持识别的类型
+const (
+	MIMEOLE   = "application/x-ole-storage"   // OLE 复合文档，如 .doc、.xls、.ppt
+	MIME7z    = "application/x-7z-compressed" // 7z 压缩包
+	MIMETar   = "application/x-tar"           // tar 归档
+	MIMESVG   = "image/svg+xml"               // SVG 图片，可包含脚本
+	MIMEOOXML = "application/x-ooxml"         // Office Open XML 文档，如 .docx、.xlsx、.pptx
+)
+
+// Type 允许上传的文件类型
+type Type struct {
+	Category  string   // 文件分类
+	Extension string   // 扩展名，小写且以 . 开头
+	MIME      string   // 保存与访问文件时使用的 MIME 类型
+	Detected  []string // 文件内容允许识别为的类型，内容与扩展名不一致时拒绝上传
+}
+
+// types 允许上传的文件类型，不在其中的扩展名（如 .html、.svg、.js）均不允许上传
+var types = map[string]Type{}
+
+func init() {
+	register := func(category, extension, mime string, detected ...string) {
+		if len(detected) == 0 {
+			detected = []string{mime}
+		}
+		types[extension] = Type{Category: category, Extension: extension, MIME: mime, Detected: detected}
+	}
+
+	register(CategoryImage, ".jpg", "image/jpeg")
+	register(CategoryImage, ".jpeg", "image/jpeg")
+	register(CategoryImage, ".png", "image/png")
+	register(CategoryImage, ".gif", "image/gif")
+	register(CategoryImage, ".webp", "image/webp")
+	register(CategoryImage, ".bmp", "image/bmp")
+
+	register(CategoryDocument, ".pdf", "application/pdf")
+	register(CategoryDocument, ".doc", "application/msword", MIMEOLE)
+	register(CategoryDocument, ".xls", "application/vnd.ms-excel", MIMEOLE)
+	register(CategoryDocument, ".ppt", "application/vnd.ms-powerpoint", MIMEOLE)
+	register(CategoryDocument, ".docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", MIMEOOXML, "application/zip")
+	register(CategoryDocument, ".xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", MIMEOOXML, "application/zip")
+	register(CategoryDocument, ".pptx", "application/vnd.openxmlformats-officedocument.presentationml.presentation", MIMEOOXML, "application/zip")
+	register(CategoryDocument, ".txt", "text/plain", "text/plain")
+	register(CategoryDocument, ".csv", "text/csv", "text/plain")
+	register(CategoryDocument, ".md", "text/markdown", "text/plain")
+
+	register(CategoryArchive, ".zip", "application/zip", "application/zip", MIMEOOXML)
+	register(CategoryArchive, ".rar", "application/vnd.rar", "application/x-rar-compressed")
+	register(CategoryArchive, ".7z", MIME7z)
+	register(CategoryArchive, ".gz", "application/gzip", "application/x-gzip")
+	register(CategoryArchive, ".tgz", "application/gzip", "application/x-gzip")
+	register(CategoryArchive, ".tar", MIMETar)
+}
+
+// Categories 所有文件分类
+func Categories() []string {
+	return []string{CategoryImage, CategoryDocument, CategoryArchive}
+}
+
+// Extensions 分类允许的扩展名，按字母排序
+func Extensions(categories ...string) []string {
+	var extensions []string
+	for extension, t := range types {
+		for _, category := range categories {
+			if t.Category == category {
+				extensions = append(extensions, extension)
+				break
+			}
+		}
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+// Lookup 根据文件名的扩展名查找允许上传的文件类型
+func Lookup(fileName string) (Type, bool) {
+	t, ok := types[strings.ToLower(filepath.Ext(fileName))]
+	return t, ok
+}
+
+// Detect 根据文件开头（至少 SniffMaxSize 字节，文件更短时为全部内容）识别文件类型，返回不含参数的 MIME 类型
+func Detect(head []byte) string {
+	if len(head) > SniffMaxSize {
+		head = head[:SniffMaxSize]
+	}
+	switch {
+	case bytes.HasPrefix(head, []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}):
+		return MIMEOLE
+	case bytes.HasPrefix(head, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}):
+		return MIME7z
+	case len(head) >= 262 && string(head[257:262]) == "ustar":
+		return MIMETar
+	}
+
+	mime, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	switch mime {
+	case "application/zip":
+		// Office Open XML 文档的第一个文件通常为 [Content_Types].xml
+		if bytes.Contains(head, []byte("[Content_Types].xml")) {
+			return MIMEOOXML
+		}
+	case "text/xml", "text/plain":
+		// XML 或文本中包含 svg 标签时按 SVG 处理，浏览器会执行其中的脚本
+		if bytes.Contains(bytes.ToLower(head), []byte("<svg")) {
+			return MIMESVG
+		}
+	}
+	return mime
+}
+
+// Validate 校验文件是否允许上传：扩展名需属于指定分类，文件内容识别出的类型需与扩展名一致
+func Validate(fileName string, head []byte, categories []string) (Type, error) {
+	t, err := ValidateName(fileName, categories)
+	if err != nil {
+		return t, err
+	}
+	if err = t.Match(head); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// ValidateName 校验文件扩展名是否属于指定分类，用于上传文件内容之前的检查
+func ValidateName(fileName string, categories []string) (Type, error) {
+	t, ok := Lookup(fileName)
+	if !ok {
+		return t, fmt.Errorf("不允许上传此类型的文件，允许的类型: %s", strings.Join(Extensions(categories...), " "))
+	}
+	for _, category := range categories {
+		if t.Category == category {
+			return t, nil
+		}
+	}
+	return t, fmt.Errorf("不允许上传此类型的文件，允许的类型: %s", strings.Join(Extensions(categories...), " "))
+}
+
+// Match 校验文件内容识别出的类型是否与扩展名一致
+func (t Type) Match(head []byte) error {
+	detected := Detect(head)
+	for _, allowed := range t.Detected {
+		if detected == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("文件内容（%s）与扩展名 %s 不一致", detected, t.Extension)
+}
@@ -0,0 +1,159 @@
+package filetype
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// 各类型文件的开头
+var (
+	headJPEG  = []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	headPNG   = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	headGIF   = []byte("GIF89a\x01\x00\x01\x00")
+	headWebP  = []byte("RIFF\x24\x00\x00\x00WEBPVP8L")
+	headBMP   = []byte("BM\x3a\x00\x00\x00\x00\x00")
+	headPDF   = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	headOLE   = []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1\x00\x00")
+	head7z    = []byte("7z\xbc\xaf\x27\x1c\x00\x04")
+	headZip   = []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00readme.txt")
+	headOOXML = []byte("PK\x03\x04\x14\x00\x06\x00\x08\x00[Content_Types].xml")
+	headGzip  = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00")
+	headRar   = []byte("Rar!\x1a\x07\x01\x00")
+	headText  = []byte("name,age\n张三,18\n")
+	headHTML  = []byte("<!DOCTYPE html><html><script>alert(1)</script>")
+	headSVG   = []byte(`<?xml version="1.0"?><SVG xmlns="http://www.w3.org/2000/svg" onload="alert(1)"/>`)
+	headTar   = func() []byte {
+		head := make([]byte, 512)
+		copy(head, "file.txt")
+		copy(head[257:], "ustar\x0000")
+		return head
+	}()
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{name: "JPEG", head: headJPEG, want: "image/jpeg"},
+		{name: "PNG", head: headPNG, want: "image/png"},
+		{name: "GIF", head: headGIF, want: "image/gif"},
+		{name: "WebP", head: headWebP, want: "image/webp"},
+		{name: "BMP", head: headBMP, want: "image/bmp"},
+		{name: "PDF", head: headPDF, want: "application/pdf"},
+		{name: "OLE 复合文档", head: headOLE, want: MIMEOLE},
+		{name: "7z", head: head7z, want: MIME7z},
+		{name: "tar", head: headTar, want: MIMETar},
+		{name: "zip", head: headZip, want: "application/zip"},
+		{name: "Office Open XML", head: headOOXML, want: MIMEOOXML},
+		{name: "gzip", head: headGzip, want: "application/x-gzip"},
+		{name: "rar", head: headRar, want: "application/x-rar-compressed"},
+		{name: "文本", head: headText, want: "text/plain"},
+		{name: "HTML", head: headHTML, want: "text/html"},
+		{name: "XML 中的 SVG", head: headSVG, want: MIMESVG},
+		{name: "文本中的 SVG", head: []byte("hello <svg onload=alert(1)>"), want: MIMESVG},
+		{name: "只识别开头", head: append(bytes.Repeat([]byte("a"), SniffMaxSize), "<svg>"...), want: "text/plain"},
+		{name: "空内容", head: nil, want: "text/plain"},
+		{name: "二进制", head: []byte{0x00, 0x01, 0x02, 0x03}, want: "application/octet-stream"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Detect(c.head); got != c.want {
+				t.Errorf("Detect = %s，期望 %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		fileName string
+		ok       bool
+		category string
+		mime     string
+	}{
+		{"photo.JPG", true, CategoryImage, "image/jpeg"},
+		{"dir/photo.jpeg", true, CategoryImage, "image/jpeg"},
+		{"报告.docx", true, CategoryDocument, "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"data.tar.gz", true, CategoryArchive, "application/gzip"},
+		{"index.html", false, "", ""},
+		{"logo.svg", false, "", ""},
+		{"photo.jpg.exe", false, "", ""},
+		{"README", false, "", ""},
+		{".jpg", true, CategoryImage, "image/jpeg"},
+	}
+	for _, c := range cases {
+		got, ok := Lookup(c.fileName)
+		if ok != c.ok || got.Category != c.category || got.MIME != c.mime {
+			t.Errorf("Lookup(%q) = %+v, %v，期望分类 %q、MIME %q、%v", c.fileName, got, ok, c.category, c.mime, c.ok)
+		}
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	cases := []struct {
+		categories []string
+		want       string
+	}{
+		{[]string{CategoryImage}, ".bmp .gif .jpeg .jpg .png .webp"},
+		{[]string{CategoryArchive}, ".7z .gz .rar .tar .tgz .zip"},
+		{[]string{CategoryImage, CategoryArchive}, ".7z .bmp .gif .gz .jpeg .jpg .png .rar .tar .tgz .webp .zip"},
+		{[]string{"video"}, ""},
+		{nil, ""},
+	}
+	for _, c := range cases {
+		if got := strings.Join(Extensions(c.categories...), " "); got != c.want {
+			t.Errorf("Extensions(%v) = %s，期望 %s", c.categories, got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	all := Categories()
+	images := []string{CategoryImage}
+	cases := []struct {
+		name       string
+		fileName   string
+		head       []byte
+		categories []string
+		mime       string // 校验通过时的 MIME 类型
+		err        string // 错误信息包含的内容
+	}{
+		{name: "JPEG 图片", fileName: "photo.JPG", head: headJPEG, categories: images, mime: "image/jpeg"},
+		{name: "WebP 图片", fileName: "photo.webp", head: headWebP, categories: images, mime: "image/webp"},
+		{name: "Word 文档", fileName: "报告.docx", head: headOOXML, categories: all, mime: "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{name: "开头不是 [Content_Types].xml 的 Excel", fileName: "a.xlsx", head: headZip, categories: all, mime: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{name: "旧版 Excel", fileName: "a.xls", head: headOLE, categories: all, mime: "application/vnd.ms-excel"},
+		{name: "压缩包中的 Office 文档", fileName: "a.zip", head: headOOXML, categories: all, mime: "application/zip"},
+		{name: "CSV", fileName: "users.csv", head: headText, categories: all, mime: "text/csv"},
+		{name: "tar.gz", fileName: "data.tar.gz", head: headGzip, categories: all, mime: "application/gzip"},
+		{name: "tar", fileName: "data.tar", head: headTar, categories: all, mime: MIMETar},
+		{name: "7z", fileName: "data.7z", head: head7z, categories: all, mime: MIME7z},
+		{name: "不允许的扩展名", fileName: "logo.svg", head: headSVG, categories: all, err: "不允许上传此类型的文件"},
+		{name: "分类不允许", fileName: "a.pdf", head: headPDF, categories: images, err: "允许的类型: .bmp .gif .jpeg .jpg .png .webp"},
+		{name: "伪装为图片的 HTML", fileName: "photo.jpg", head: headHTML, categories: images, err: "文件内容（text/html）与扩展名 .jpg 不一致"},
+		{name: "扩展名与图片格式不一致", fileName: "photo.png", head: headJPEG, categories: images, err: "不一致"},
+		{name: "伪装为文本的 SVG", fileName: "a.txt", head: headSVG, categories: all, err: "image/svg+xml"},
+		{name: "伪装为文档的可执行文件", fileName: "a.doc", head: []byte("MZ\x90\x00\x03\x00\x00\x00"), categories: all, err: "不一致"},
+		{name: "没有扩展名", fileName: "README", head: headText, categories: all, err: "不允许上传此类型的文件"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Validate(c.fileName, c.head, c.categories)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.MIME != c.mime {
+				t.Errorf("MIME = %s，期望 %s", got.MIME, c.mime)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package generate
 
+import (
+	"strconv"
+	"strings"
+)
+
 // 字段映射关系
 
 // go类型
@@ -14,6 +19,7 @@ const (
 	GoTypeIntSlice    = "[]int"
 	GoTypeInt64Slice  = "[]int64"
 	GoTypeUint64Slice = "[]uint64"
+	GoTypeStringSlice = "[]string"
 	GoTypeFloat32     = "float32"
 	GoTypeFloat64     = "float64"
 	GoTypeBytes       = "[]byte"
@@ -34,6 +40,7 @@ var GoTypeNameMap = map[string]string{
 	GoTypeIntSlice:    GoTypeIntSlice,
 	GoTypeInt64Slice:  GoTypeInt64Slice,
 	GoTypeUint64Slice: GoTypeUint64Slice,
+	GoTypeStringSlice: GoTypeStringSlice,
 	GoTypeFloat32:     GoTypeFloat32,
 	GoTypeFloat64:     GoTypeFloat64,
 	GoTypeBytes:       GoTypeBytes,
@@ -74,6 +81,7 @@ var ShiftMap = map[string]string{
 	GoTypeIntSlice:    TsTypeArray,
 	GoTypeInt64Slice:  TsTypeArray,
 	GoTypeUint64Slice: TsTypeArray,
+	GoTypeStringSlice: TsTypeArray,
 	GoTypeFloat32:     TsTypeNumber,
 	GoTypeFloat64:     TsTypeNumber,
 	GoTypeBytes:       TsTypeString,
@@ -141,6 +149,45 @@ var FormModeMap = map[string]string{
 	FormModeRate:           "评分",
 }
 
+// legacyFormModes 早期版本保存的表单组件取值
+var legacyFormModes = map[string]string{
+	"textarea": FormModeInputTextarea,
+	"number":   FormModeInputNumber,
+	"editor":   FormModeInputEditor,
+	"datetime": FormModeTime,
+	"image":    FormModeUploadImage,
+	"file":     FormModeUploadFile,
+}
+
+// NormalizeFormMode 规范化字段的表单组件，兼容早期保存的小写取值，无法识别时使用文本输入
+func NormalizeFormMode(htmlType string) string {
+	htmlType = strings.TrimSpace(htmlType)
+	if mode, ok := legacyFormModes[strings.ToLower(htmlType)]; ok {
+		return mode
+	}
+	for _, mode := range FormModes {
+		if strings.EqualFold(mode, htmlType) {
+			return mode
+		}
+	}
+	return FormModeInput
+}
+
+// FormModeGoType 返回表单组件决定的请求参数Go类型，返回空字符串时沿用字段类型
+// 多值组件以JSON数组保存，日期组件使用 *gtime.Time，动态键值对使用 *gjson.Json
+func FormModeGoType(formMode string) string {
+	switch formMode {
+	case FormModeDate, FormModeTime:
+		return GoTypeGTime
+	case FormModeDateRange, FormModeTimeRange, FormModeCheckbox, FormModeSelectMultiple,
+		FormModeCascader, FormModeUploadImages, FormModeUploadFiles:
+		return GoTypeStringSlice
+	case FormModeInputDynamic:
+		return GoTypeJson
+	}
+	return ""
+}
+
 // 表单验证
 const (
 	FormValidationNone   = "none"
@@ -220,6 +267,162 @@ func GetWhereModeOptions() []WhereModeOption {
 	return options
 }
 
+// NormalizeWhereMode 规范化字段的查询条件
+// 未配置或无法识别时沿用默认规则：字符串模糊查询，其余等值查询；LIKE 仅适用于字符串
+func NormalizeWhereMode(mode, goType string) string {
+	mode = strings.ToUpper(strings.TrimSpace(mode))
+	if mode == "EQ" {
+		mode = WhereModeEq
+	}
+	if _, ok := WhereModeMap[mode]; !ok {
+		mode = ""
+	}
+	if mode == "" {
+		if goType == GoTypeString {
+			return WhereModeLike
+		}
+		return WhereModeEq
+	}
+	if mode == WhereModeLike && goType != GoTypeString {
+		return WhereModeEq
+	}
+	return mode
+}
+
+// ColumnGoType 根据字段元数据推断Go类型，与 gf gen dao 的类型映射保持一致，
+// 额外将 tinyint(1) 视为布尔值
+func ColumnGoType(meta ColumnMeta) string {
+	columnType := strings.ToLower(meta.ColumnType)
+	unsigned := strings.Contains(columnType, "unsigned")
+
+	switch strings.ToLower(meta.DataType) {
+	case "tinyint":
+		if strings.HasPrefix(columnType, "tinyint(1)") {
+			return GoTypeBool
+		}
+		fallthrough
+	case "int", "integer", "smallint", "mediumint":
+		if unsigned {
+			return GoTypeUint
+		}
+		return GoTypeInt
+	case "bigint":
+		if unsigned {
+			return GoTypeUint64
+		}
+		return GoTypeInt64
+	case "float", "double", "decimal", "numeric":
+		return GoTypeFloat64
+	case "real":
+		return GoTypeFloat32
+	case "bit":
+		if columnType == "bit(1)" {
+			return GoTypeBool
+		}
+		return GoTypeInt64
+	case "bool", "boolean":
+		return GoTypeBool
+	case "date", "datetime", "timestamp":
+		return GoTypeGTime
+	case "json":
+		return GoTypeJson
+	case "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob":
+		return GoTypeBytes
+	}
+	return GoTypeString
+}
+
+// ColumnTsType 返回Go类型对应的TS类型
+func ColumnTsType(goType string) string {
+	if tsType, ok := ShiftMap[goType]; ok {
+		return tsType
+	}
+	return TsTypeAny
+}
+
+// intBits 整数类型的位数
+var intBits = map[string]uint{
+	"tinyint":   8,
+	"smallint":  16,
+	"mediumint": 24,
+	"int":       32,
+	"integer":   32,
+}
+
+// ColumnMaxLength 返回字符串字段的最大字符数，仅 char/varchar 有效，其余返回0
+func ColumnMaxLength(meta ColumnMeta) int {
+	switch strings.ToLower(meta.DataType) {
+	case "char", "varchar":
+		return meta.CharacterMaximumLength
+	}
+	return 0
+}
+
+// ColumnRange 返回数字字段的取值范围，无法确定的一端返回空字符串
+// 整数按类型位数计算，定点数按精度与小数位数计算，无符号字段最小值为0
+func ColumnRange(meta ColumnMeta) (min, max string) {
+	unsigned := strings.Contains(strings.ToLower(meta.ColumnType), "unsigned")
+	dataType := strings.ToLower(meta.DataType)
+
+	if bits, ok := intBits[dataType]; ok {
+		if ColumnGoType(meta) == GoTypeBool {
+			return "", ""
+		}
+		if unsigned {
+			return "0", strconv.FormatUint(1<<bits-1, 10)
+		}
+		return strconv.FormatInt(-(1 << (bits - 1)), 10), strconv.FormatInt(1<<(bits-1)-1, 10)
+	}
+
+	// 超过 float64 有效位数的精度无法准确比较，仅限制符号
+	if (dataType == "decimal" || dataType == "numeric") && meta.NumericPrecision > 0 && meta.NumericPrecision <= 15 {
+		max = strings.Repeat("9", meta.NumericPrecision-meta.NumericScale)
+		if max == "" {
+			max = "0"
+		}
+		if meta.NumericScale > 0 {
+			max += "." + strings.Repeat("9", meta.NumericScale)
+		}
+		if unsigned {
+			return "0", max
+		}
+		return "-" + max, max
+	}
+
+	if unsigned && IsNumberType(ColumnGoType(meta)) {
+		return "0", ""
+	}
+	return "", ""
+}
+
+// ColumnEnumValues 返回 enum 字段的可选值
+func ColumnEnumValues(meta ColumnMeta) []string {
+	if strings.ToLower(meta.DataType) != "enum" {
+		return nil
+	}
+	columnType := strings.TrimSpace(meta.ColumnType)
+	columnType = strings.TrimSuffix(columnType[strings.Index(columnType, "(")+1:], ")")
+
+	var values []string
+	for _, value := range strings.Split(columnType, "','") {
+		value = strings.Trim(value, "'")
+		values = append(values, strings.ReplaceAll(value, "''", "'"))
+	}
+	return values
+}
+
+// NormalizeFormValidation 规范化字段的表单验证，无规则或无法识别时返回空字符串
+func NormalizeFormValidation(validation string) string {
+	validation = strings.ToLower(strings.TrimSpace(validation))
+	if validation == FormValidationNone {
+		return ""
+	}
+	if _, ok := FormValidationMap[validation]; !ok {
+		return ""
+	}
+	return validation
+}
+
 // IsNumberType 是否是数字类型
 func IsNumberType(goType string) bool {
 	switch goType {
@@ -0,0 +1,36 @@
+package curd
+
+import (
+	"strings"
+
+	"server/app/admin/internal/library/generate"
+)
+
+// IsNumber 字段是否为数字类型
+func (c Column) IsNumber() bool {
+	return generate.IsNumberType(c.GoType)
+}
+
+// LabelGoField 返回字典字段在列表响应中对应标签的Go字段名
+func (c Column) LabelGoField() string {
+	return c.GoField + "Label"
+}
+
+// LabelJsonField 返回字典字段在列表响应中对应标签的JSON字段名，与字段JSON命名风格保持一致
+func (c Column) LabelJsonField() string {
+	if strings.Contains(c.JsonField, "_") {
+		return c.JsonField + "_label"
+	}
+	return c.JsonField + "Label"
+}
+
+// dictColumns 返回绑定了字典的可编辑字段，用于校验提交值是否为有效字典值
+func dictColumns(columns []Column) []Column {
+	var result []Column
+	for _, column := range columns {
+		if column.DictType != "" && isEditableColumn(column) {
+			result = append(result, column)
+		}
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package curd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"server/app/admin/internal/library/generate"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// goTypeImports 请求参数类型所需的导入包
+var goTypeImports = map[string]string{
+	generate.GoTypeGTime: "github.com/gogf/gf/v2/os/gtime",
+	generate.GoTypeJson:  "github.com/gogf/gf/v2/encoding/gjson",
+}
+
+// FormMode 返回规范化后的表单组件，绑定字典但未选择选项类组件时使用下拉框，
+// 未选择组件时布尔字段使用开关、时间字段使用时间选择、JSON字段使用动态键值对
+func (c Column) FormMode() string {
+	mode := generate.NormalizeFormMode(c.HtmlType)
+	if c.DictType != "" && !hasOptions(mode) {
+		return generate.FormModeSelect
+	}
+	if mode == generate.FormModeInput {
+		switch c.GoType {
+		case generate.GoTypeBool:
+			return generate.FormModeSwitch
+		case generate.GoTypeGTime:
+			return generate.FormModeTime
+		case generate.GoTypeJson:
+			return generate.FormModeInputDynamic
+		}
+	}
+	return mode
+}
+
+// isRefType 字段类型本身是否为指针或切片，无需再包装为指针
+func (c Column) isRefType() bool {
+	return strings.HasPrefix(c.GoType, "*") || strings.HasPrefix(c.GoType, "[]")
+}
+
+// FieldGoType 返回新增/修改请求中字段的Go类型，未提交的字段为 nil
+func (c Column) FieldGoType() string {
+	if goType := generate.FormModeGoType(c.FormMode()); goType != "" {
+		return goType
+	}
+	if c.isRefType() {
+		return c.GoType
+	}
+	return "*" + c.GoType
+}
+
+// Deref 返回读取请求字段值时的解引用前缀，仅标量指针字段需要解引用
+func (c Column) Deref() string {
+	if c.isRefType() || c.FieldGoType() != "*"+c.GoType {
+		return ""
+	}
+	return "*"
+}
+
+// EmptyCheck 返回判断请求字段为空的表达式，v 为请求变量名
+func (c Column) EmptyCheck(v string) string {
+	field := v + "." + c.GoField
+	switch c.FieldGoType() {
+	case generate.GoTypeStringSlice, generate.GoTypeBytes:
+		return fmt.Sprintf("len(%s) == 0", field)
+	case generate.GoTypeGTime:
+		return fmt.Sprintf("%s == nil || %s.IsZero()", field, field)
+	case generate.GoTypeJson:
+		return fmt.Sprintf("%s == nil || %s.IsNil()", field, field)
+	}
+	switch c.GoType {
+	case generate.GoTypeString:
+		return fmt.Sprintf("%s == nil || *%s == \"\"", field, field)
+	case generate.GoTypeBool:
+		return fmt.Sprintf("%s == nil", field)
+	}
+	return fmt.Sprintf("%s == nil || *%s == 0", field, field)
+}
+
+// PresentCheck 返回判断请求字段已填写的表达式，与 EmptyCheck 相反
+func (c Column) PresentCheck(v string) string {
+	field := v + "." + c.GoField
+	switch c.FieldGoType() {
+	case generate.GoTypeStringSlice, generate.GoTypeBytes:
+		return fmt.Sprintf("len(%s) > 0", field)
+	case generate.GoTypeGTime:
+		return fmt.Sprintf("%s != nil && !%s.IsZero()", field, field)
+	case generate.GoTypeJson:
+		return fmt.Sprintf("%s != nil && !%s.IsNil()", field, field)
+	}
+	switch c.GoType {
+	case generate.GoTypeString:
+		return fmt.Sprintf("%s != nil && *%s != \"\"", field, field)
+	case generate.GoTypeBool:
+		return fmt.Sprintf("%s != nil", field)
+	}
+	return fmt.Sprintf("%s != nil && *%s != 0", field, field)
+}
+
+// IsMultiValue 是否为多值字段（以JSON数组保存）
+func (c Column) IsMultiValue() bool {
+	return c.FieldGoType() == generate.GoTypeStringSlice
+}
+
+// IsUpload 表单组件是否为上传组件，字段保存附件地址，新增、修改、删除时同步附件引用
+func (c Column) IsUpload() bool {
+	switch c.FormMode() {
+	case generate.FormModeUploadImage, generate.FormModeUploadImages, generate.FormModeUploadFile, generate.FormModeUploadFiles:
+		return true
+	}
+	return false
+}
+
+// uploadColumns 返回使用上传组件的可编辑字段
+func uploadColumns(columns []Column) []Column {
+	var result []Column
+	for _, column := range columns {
+		if column.IsUpload() && isEditableColumn(column) {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// HasOptions 表单组件是否需要选项数据
+func (c Column) HasOptions() bool {
+	return hasOptions(c.FormMode())
+}
+
+// hasOptions 表单组件是否需要选项数据
+func hasOptions(formMode string) bool {
+	switch formMode {
+	case generate.FormModeRadio, generate.FormModeCheckbox, generate.FormModeSelect,
+		generate.FormModeSelectMultiple, generate.FormModeTreeSelect, generate.FormModeCascader:
+		return true
+	}
+	return false
+}
+
+// TsType 返回字段类型对应的TS类型
+func (c Column) TsType() string {
+	return generate.ColumnTsType(c.GoType)
+}
+
+// FieldTsType 返回表单数据中字段的TS类型
+func (c Column) FieldTsType() string {
+	switch c.FieldGoType() {
+	case generate.GoTypeStringSlice:
+		return "string[]"
+	case generate.GoTypeGTime:
+		return "string"
+	case generate.GoTypeJson:
+		return "Record<string, any>"
+	}
+	return c.TsType()
+}
+
+// FormDefault 返回表单中字段的默认值（TS字面量），优先使用字段的数据库默认值
+func (c Column) FormDefault() string {
+	switch c.FieldGoType() {
+	case generate.GoTypeStringSlice:
+		return "[]"
+	case generate.GoTypeJson:
+		return "{}"
+	case generate.GoTypeGTime:
+		return `""`
+	}
+	switch {
+	case c.GoType == generate.GoTypeBool:
+		if c.DefaultValue != "" {
+			return strconv.FormatBool(gconv.Bool(strings.Trim(c.DefaultValue, "b'")))
+		}
+		return "false"
+	case c.IsNumber():
+		if _, err := strconv.ParseFloat(c.DefaultValue, 64); err == nil {
+			return c.DefaultValue
+		}
+		return "0"
+	case c.FormMode() == generate.FormModeSwitch || c.FormMode() == generate.FormModeRate:
+		return "0"
+	case c.DefaultValue != "":
+		return strconv.Quote(c.DefaultValue)
+	}
+	return `""`
+}
+
+// DefaultDesc 返回接口文档中字段默认值的说明，默认值含引号等无法写入结构体标签时省略
+func (c Column) DefaultDesc() string {
+	if c.DefaultValue == "" || strings.ContainsAny(c.DefaultValue, "\"`\\") {
+		return ""
+	}
+	return "（默认：" + c.DefaultValue + "）"
+}
+
+// fieldImports 返回可编辑字段、查询参数及一对多子表字段的类型所需的导入包
+func fieldImports(columns []Column, oneToMany []JoinTable) []string {
+	var goTypes []string
+	for _, column := range columns {
+		if isEditableColumn(column) {
+			goTypes = append(goTypes, column.FieldGoType())
+		}
+		if column.IsQuery {
+			goTypes = append(goTypes, column.QueryGoType())
+		}
+	}
+	for _, join := range oneToMany {
+		for _, column := range join.Columns {
+			goTypes = append(goTypes, column.FieldGoType())
+		}
+	}
+
+	var (
+		imports []string
+		seen    = make(map[string]bool)
+	)
+	for _, goType := range goTypes {
+		pkg, ok := goTypeImports[goType]
+		if !ok || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		imports = append(imports, pkg)
+	}
+	return imports
+}
@@ -44,6 +44,11 @@ func (fg *FrontendGenerator) GenerateAll(ctx context.Context, config FrontendCon
 		return fmt.Errorf("生成表单组件失败: %v", err)
 	}
 
+	// 生成子表组件
+	if err := fg.GenerateSubTables(ctx, config); err != nil {
+		return fmt.Errorf("生成子表组件失败: %v", err)
+	}
+
 	// 生成工具文件
 	if err := fg.GenerateUtils(ctx, config); err != nil {
 		return fmt.Errorf("生成工具文件失败: %v", err)
@@ -55,7 +60,7 @@ func (fg *FrontendGenerator) GenerateAll(ctx context.Context, config FrontendCon
 
 // GenerateAPI 生成TypeScript API文件
 func (fg *FrontendGenerator) GenerateAPI(ctx context.Context, config FrontendConfig) error {
-	templatePath := fg.getTemplatePath("api.ts.template")
+	templatePath := fg.getTemplatePath(ctx, config, "api.ts.template")
 	outputPath := fg.getAPIOutputPath(config)
 	templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
 
@@ -64,7 +69,7 @@ func (fg *FrontendGenerator) GenerateAPI(ctx context.Context, config FrontendCon
 
 // GenerateIndexPage 生成主页面Vue文件
 func (fg *FrontendGenerator) GenerateIndexPage(ctx context.Context, config FrontendConfig) error {
-	templatePath := fg.getTemplatePath("index.vue.template")
+	templatePath := fg.getTemplatePath(ctx, config, "index.vue.template")
 	outputPath := fg.getIndexPageOutputPath(config)
 	templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
 
@@ -73,13 +78,28 @@ func (fg *FrontendGenerator) GenerateIndexPage(ctx context.Context, config Front
 
 // GenerateFormComponent 生成表单组件
 func (fg *FrontendGenerator) GenerateFormComponent(ctx context.Context, config FrontendConfig) error {
-	templatePath := fg.getTemplatePath("form/index.vue.template")
+	templatePath := fg.getTemplatePath(ctx, config, "form/index.vue.template")
 	outputPath := fg.getFormComponentOutputPath(config)
 	templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
 
 	return fg.generator.generateFromTemplate(ctx, templatePath, outputPath, templateData)
 }
 
+// GenerateSubTables 为每个一对多关联表生成表单内联编辑的子表组件
+func (fg *FrontendGenerator) GenerateSubTables(ctx context.Context, config FrontendConfig) error {
+	templatePath := fg.getTemplatePath(ctx, config, "form/subTable.vue.template")
+	_, oneToMany := splitJoins(config.Joins)
+	for _, join := range oneToMany {
+		templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
+		templateData["Join"] = join
+		outputPath := fg.getSubTableOutputPath(config, join)
+		if err := fg.generator.generateFromTemplate(ctx, templatePath, outputPath, templateData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GenerateUtils 生成工具文件
 func (fg *FrontendGenerator) GenerateUtils(ctx context.Context, config FrontendConfig) error {
 	// 生成types.ts
@@ -102,7 +122,7 @@ func (fg *FrontendGenerator) GenerateUtils(ctx context.Context, config FrontendC
 
 // generateTypesFile 生成types.ts文件
 func (fg *FrontendGenerator) generateTypesFile(ctx context.Context, config FrontendConfig) error {
-	templatePath := fg.getTemplatePath("utils/types.ts.template")
+	templatePath := fg.getTemplatePath(ctx, config, "utils/types.ts.template")
 	outputPath := fg.getTypesOutputPath(config)
 	templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
 
@@ -111,7 +131,7 @@ func (fg *FrontendGenerator) generateTypesFile(ctx context.Context, config Front
 
 // generateHookFile 生成hook.tsx文件
 func (fg *FrontendGenerator) generateHookFile(ctx context.Context, config FrontendConfig) error {
-	templatePath := fg.getTemplatePath("utils/hook.tsx.template")
+	templatePath := fg.getTemplatePath(ctx, config, "utils/hook.tsx.template")
 	outputPath := fg.getHookOutputPath(config)
 	templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
 
@@ -120,7 +140,7 @@ func (fg *FrontendGenerator) generateHookFile(ctx context.Context, config Fronte
 
 // generateRuleFile 生成rule.ts文件
 func (fg *FrontendGenerator) generateRuleFile(ctx context.Context, config FrontendConfig) error {
-	templatePath := fg.getTemplatePath("utils/rule.ts.template")
+	templatePath := fg.getTemplatePath(ctx, config, "utils/rule.ts.template")
 	outputPath := fg.getRuleOutputPath(config)
 	templateData := fg.generator.prepareTemplateData(config.GenerateConfig)
 
@@ -128,8 +148,8 @@ func (fg *FrontendGenerator) generateRuleFile(ctx context.Context, config Fronte
 }
 
 // getTemplatePath 获取模板文件路径
-func (fg *FrontendGenerator) getTemplatePath(templateName string) string {
-	return fg.generator.getTemplatePath(templateName)
+func (fg *FrontendGenerator) getTemplatePath(ctx context.Context, config FrontendConfig, templateName string) string {
+	return fg.generator.getTemplatePath(ctx, config.templateSet(), templateName)
 }
 
 // getWebRoot 获取前端项目根目录
@@ -160,6 +180,12 @@ func (fg *FrontendGenerator) getFormComponentOutputPath(config FrontendConfig) s
 	return filepath.Join(webRoot, "src", "views", config.PackageName, "form", "index.vue")
 }
 
+// getSubTableOutputPath 获取子表组件输出路径
+func (fg *FrontendGenerator) getSubTableOutputPath(config FrontendConfig, join JoinTable) string {
+	webRoot := fg.getWebRoot(config)
+	return filepath.Join(webRoot, "src", "views", config.PackageName, "form", join.GoAlias+"Table.vue")
+}
+
 // getTypesOutputPath 获取types文件输出路径
 func (fg *FrontendGenerator) getTypesOutputPath(config FrontendConfig) string {
 	webRoot := fg.getWebRoot(config)
@@ -187,6 +213,8 @@ func (fg *FrontendGenerator) GenerateSingleFile(ctx context.Context, config Fron
 		return fg.GenerateIndexPage(ctx, config)
 	case "form":
 		return fg.GenerateFormComponent(ctx, config)
+	case "subtable":
+		return fg.GenerateSubTables(ctx, config)
 	case "types":
 		return fg.generateTypesFile(ctx, config)
 	case "hook":
@@ -217,7 +245,7 @@ func (fg *FrontendGenerator) ValidateConfig(config FrontendConfig) error {
 
 // GetGeneratedFiles 获取将要生成的文件列表
 func (fg *FrontendGenerator) GetGeneratedFiles(config FrontendConfig) []string {
-	return []string{
+	files := []string{
 		fg.getAPIOutputPath(config),
 		fg.getIndexPageOutputPath(config),
 		fg.getFormComponentOutputPath(config),
@@ -225,4 +253,9 @@ func (fg *FrontendGenerator) GetGeneratedFiles(config FrontendConfig) []string {
 		fg.getHookOutputPath(config),
 		fg.getRuleOutputPath(config),
 	}
+	_, oneToMany := splitJoins(config.Joins)
+	for _, join := range oneToMany {
+		files = append(files, fg.getSubTableOutputPath(config, join))
+	}
+	return files
 }
@@ -0,0 +1,113 @@
+package curd
+
+import (
+	"fmt"
+	"server/app/admin/internal/consts"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// JoinTable 关联表模板数据
+type JoinTable struct {
+	TableName      string   `json:"tableName"`      // 关联表名称
+	TableComment   string   `json:"tableComment"`   // 关联表注释
+	EntityName     string   `json:"entityName"`     // 关联表实体名称（dao/entity）
+	Alias          string   `json:"alias"`          // SQL别名
+	GoAlias        string   `json:"goAlias"`        // Go字段名前缀
+	JsonAlias      string   `json:"jsonAlias"`      // JSON字段名
+	Relation       string   `json:"relation"`       // 关联关系: manyToOne, oneToMany
+	JoinMethod     string   `json:"joinMethod"`     // gdb关联方法: LeftJoin, RightJoin, InnerJoin
+	JoinField      string   `json:"joinField"`      // 关联表字段
+	JoinGoField    string   `json:"joinGoField"`    // 关联表字段Go名称
+	MainField      string   `json:"mainField"`      // 主表关联字段
+	MainGoField    string   `json:"mainGoField"`    // 主表关联字段Go名称
+	LabelField     string   `json:"labelField"`     // 多对一时列表显示的关联表字段
+	LabelGoField   string   `json:"labelGoField"`   // 列表显示字段Go名称
+	LabelJsonField string   `json:"labelJsonField"` // 列表显示字段JSON名称
+	LabelAs        string   `json:"labelAs"`        // 列表显示字段查询别名
+	Columns        []Column `json:"columns"`        // 一对多时表单中可编辑的子表字段
+}
+
+// NewJoinTable 根据关联表配置构建模板数据，columns 为一对多子表的可编辑字段
+func NewJoinTable(join consts.JoinTableConfig, tableComment string, columns []Column) (JoinTable, error) {
+	if join.Alias == "" {
+		join.Alias = join.TableName
+	}
+	if join.Relation == "" {
+		join.Relation = consts.RelationManyToOne
+	}
+	if join.JoinField == "" {
+		return JoinTable{}, fmt.Errorf("关联表 %s 未配置关联字段", join.TableName)
+	}
+	if tableComment == "" {
+		tableComment = join.TableName
+	}
+
+	jt := JoinTable{
+		TableName:    join.TableName,
+		TableComment: tableComment,
+		EntityName:   gstr.CaseCamel(join.TableName),
+		Alias:        join.Alias,
+		GoAlias:      gstr.CaseCamel(join.Alias),
+		JsonAlias:    gstr.CaseCamelLower(join.Alias),
+		Relation:     join.Relation,
+		JoinField:    join.JoinField,
+		JoinGoField:  gstr.CaseCamel(join.JoinField),
+		MainField:    join.MainField,
+		LabelField:   join.LabelField,
+	}
+
+	switch gstr.ToUpper(join.JoinType) {
+	case "RIGHT":
+		jt.JoinMethod = "RightJoin"
+	case "INNER":
+		jt.JoinMethod = "InnerJoin"
+	default:
+		jt.JoinMethod = "LeftJoin"
+	}
+
+	switch join.Relation {
+	case consts.RelationManyToOne:
+		if jt.MainField == "" {
+			return JoinTable{}, fmt.Errorf("关联表 %s 未配置主表关联字段", join.TableName)
+		}
+		if jt.LabelField == "" {
+			jt.LabelField = jt.JoinField
+		}
+		jt.LabelGoField = jt.GoAlias + gstr.CaseCamel(jt.LabelField)
+		jt.LabelJsonField = gstr.CaseCamelLower(jt.LabelGoField)
+		jt.LabelAs = gstr.CaseSnake(jt.Alias) + "_" + jt.LabelField
+	case consts.RelationOneToMany:
+		// 子表通过外键指向主表主键，新增主表记录后才能写入子表
+		if jt.MainField == "" {
+			jt.MainField = "id"
+		}
+		if jt.MainField != "id" {
+			return JoinTable{}, fmt.Errorf("一对多关联表 %s 的主表关联字段必须为主键id", join.TableName)
+		}
+		for _, column := range columns {
+			switch column.ColumnName {
+			case "id", "created_at", "updated_at", "deleted_at", jt.JoinField:
+				continue
+			}
+			jt.Columns = append(jt.Columns, column)
+		}
+	default:
+		return JoinTable{}, fmt.Errorf("不支持的关联关系: %s", join.Relation)
+	}
+	jt.MainGoField = gstr.CaseCamel(jt.MainField)
+
+	return jt, nil
+}
+
+// splitJoins 按关联关系拆分关联表
+func splitJoins(joins []JoinTable) (manyToOne, oneToMany []JoinTable) {
+	for _, join := range joins {
+		if join.Relation == consts.RelationOneToMany {
+			oneToMany = append(oneToMany, join)
+		} else {
+			manyToOne = append(manyToOne, join)
+		}
+	}
+	return
+}
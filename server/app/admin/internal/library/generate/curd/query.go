@@ -0,0 +1,130 @@
+package curd
+
+import (
+	"fmt"
+	"strings"
+
+	"server/app/admin/internal/library/generate"
+)
+
+// whereMethods 查询条件对应的 gdb 查询方法后缀（Where/WherePrefix + 后缀）
+var whereMethods = map[string]string{
+	generate.WhereModeEq:         "",
+	generate.WhereModeNeq:        "Not",
+	generate.WhereModeGt:         "GT",
+	generate.WhereModeGte:        "GTE",
+	generate.WhereModeLt:         "LT",
+	generate.WhereModeLte:        "LTE",
+	generate.WhereModeIn:         "In",
+	generate.WhereModeNotIn:      "NotIn",
+	generate.WhereModeBetween:    "Between",
+	generate.WhereModeNotBetween: "NotBetween",
+	generate.WhereModeLike:       "Like",
+}
+
+// whereMode 返回规范化后的查询条件，字典字段按字典值精确匹配，不做模糊查询
+func (c Column) whereMode() string {
+	mode := generate.NormalizeWhereMode(c.QueryType, c.GoType)
+	if c.DictType != "" && mode == generate.WhereModeLike {
+		return generate.WhereModeEq
+	}
+	return mode
+}
+
+// WhereMethod 返回查询条件对应的 gdb 查询方法后缀
+func (c Column) WhereMethod() string {
+	return whereMethods[c.whereMode()]
+}
+
+// IsLikeQuery 是否为模糊查询
+func (c Column) IsLikeQuery() bool {
+	return c.whereMode() == generate.WhereModeLike
+}
+
+// IsRangeQuery 是否为区间查询（BETWEEN / NOT BETWEEN），查询参数为 [起, 止]
+func (c Column) IsRangeQuery() bool {
+	mode := c.whereMode()
+	return mode == generate.WhereModeBetween || mode == generate.WhereModeNotBetween
+}
+
+// IsSliceQuery 是否为切片查询参数（IN / NOT IN / 区间查询）
+func (c Column) IsSliceQuery() bool {
+	mode := c.whereMode()
+	return mode == generate.WhereModeIn || mode == generate.WhereModeNotIn || c.IsRangeQuery()
+}
+
+// queryPointer 查询值是否使用指针以区分未传参与零值（布尔、数字），如按 0 筛选
+func (c Column) queryPointer() bool {
+	return c.GoType == generate.GoTypeBool || generate.IsNumberType(c.GoType)
+}
+
+// queryBaseType 返回单个查询值的Go类型，布尔、数字、时间使用指针以区分未传参，JSON、二进制按字符串查询
+func (c Column) queryBaseType() string {
+	if c.queryPointer() {
+		return "*" + c.GoType
+	}
+	switch c.GoType {
+	case generate.GoTypeJson, generate.GoTypeBytes:
+		return generate.GoTypeString
+	}
+	return c.GoType
+}
+
+// QueryGoType 返回查询参数的Go类型
+func (c Column) QueryGoType() string {
+	if !c.IsSliceQuery() {
+		return c.queryBaseType()
+	}
+	switch goType := c.queryBaseType(); goType {
+	case generate.GoTypeGTime:
+		return generate.GoTypeStringSlice
+	default:
+		return "[]" + strings.TrimPrefix(goType, "*")
+	}
+}
+
+// QueryTsType 返回查询参数的TS类型
+func (c Column) QueryTsType() string {
+	goType := c.queryBaseType()
+	if c.queryPointer() {
+		goType = c.GoType
+	}
+	tsType := generate.ColumnTsType(goType)
+	if c.IsSliceQuery() {
+		return tsType + "[]"
+	}
+	return tsType
+}
+
+// QueryPresentCheck 返回判断非切片查询参数已传入的表达式，v 为请求变量名
+func (c Column) QueryPresentCheck(v string) string {
+	field := v + "." + c.GoField
+	switch goType := c.queryBaseType(); {
+	case goType == generate.GoTypeString:
+		return fmt.Sprintf("%s != \"\"", field)
+	case goType == generate.GoTypeGTime:
+		return fmt.Sprintf("%s != nil && !%s.IsZero()", field, field)
+	case strings.HasPrefix(goType, "*"):
+		return fmt.Sprintf("%s != nil", field)
+	}
+	return fmt.Sprintf("%s != 0", field)
+}
+
+// QueryValue 返回非切片查询参数的取值表达式，v 为请求变量名
+func (c Column) QueryValue(v string) string {
+	field := v + "." + c.GoField
+	if c.queryPointer() {
+		return "*" + field
+	}
+	return field
+}
+
+// hasSliceQuery 是否存在切片类型的查询参数
+func hasSliceQuery(columns []Column) bool {
+	for _, column := range columns {
+		if column.IsQuery && column.IsSliceQuery() {
+			return true
+		}
+	}
+	return false
+}
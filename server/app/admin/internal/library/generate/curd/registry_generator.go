@@ -0,0 +1,225 @@
+package curd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	// registryImportPrefix 控制器包导入路径前缀
+	registryImportPrefix = "server/app/admin/internal/controller/"
+	// registryFactoryName 控制器构造函数名称
+	registryFactoryName = "NewV1"
+	// registryVarName 生成的注册表变量名
+	registryVarName = "generatedControllers"
+	// registryHeader 生成文件头
+	registryHeader = `// ==========================================================================
+// Code generated by CurdGenerator. DO NOT EDIT.
+// 由 internal/controller/*/*_new.go 扫描生成，请勿手动修改
+// ==========================================================================
+
+`
+)
+
+// ControllerPackage 扫描到的控制器包信息
+type ControllerPackage struct {
+	Name        string `json:"name"`        // 注册名称（目录名）
+	PackageName string `json:"packageName"` // Go包名
+	ImportPath  string `json:"importPath"`  // 导入路径
+}
+
+// RegistryGenerator 控制器注册表生成器
+type RegistryGenerator struct {
+	controllerDir string // 控制器根目录
+	outputPath    string // registry_gen.go 输出路径
+}
+
+// NewRegistryGenerator 创建新的控制器注册表生成器
+func NewRegistryGenerator() *RegistryGenerator {
+	adminRoot := filepath.Join(NewCurdGenerator().getProjectRoot(), "app", "admin")
+	return &RegistryGenerator{
+		controllerDir: filepath.Join(adminRoot, "internal", "controller"),
+		outputPath:    filepath.Join(adminRoot, "internal", "router", "registry_gen.go"),
+	}
+}
+
+// Generate 扫描控制器目录并重建 registry_gen.go
+func (rg *RegistryGenerator) Generate(ctx context.Context) error {
+	packages, err := rg.Scan()
+	if err != nil {
+		return err
+	}
+
+	content, err := rg.Render(packages)
+	if err != nil {
+		return err
+	}
+
+	if err := gfile.PutBytes(rg.outputPath, content); err != nil {
+		return fmt.Errorf("写入控制器注册表失败: %v", err)
+	}
+
+	g.Log().Infof(ctx, "控制器注册表生成成功: %s, 共 %d 个控制器", rg.outputPath, len(packages))
+	return nil
+}
+
+// Scan 扫描 internal/controller/*/*_new.go，找出导出 NewV1 构造函数的控制器包
+func (rg *RegistryGenerator) Scan() ([]ControllerPackage, error) {
+	files, err := filepath.Glob(filepath.Join(rg.controllerDir, "*", "*_new.go"))
+	if err != nil {
+		return nil, fmt.Errorf("扫描控制器目录失败: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	packages := make([]ControllerPackage, 0, len(files))
+	fset := token.NewFileSet()
+	for _, file := range files {
+		dirName := filepath.Base(filepath.Dir(file))
+		if seen[dirName] {
+			continue
+		}
+
+		astFile, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("解析控制器文件 %s 失败: %v", file, err)
+		}
+		if !hasFactoryFunc(astFile) {
+			continue
+		}
+
+		seen[dirName] = true
+		packages = append(packages, ControllerPackage{
+			Name:        dirName,
+			PackageName: astFile.Name.Name,
+			ImportPath:  registryImportPrefix + dirName,
+		})
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// Render 使用 go/ast 构建注册表文件并通过 go/format 格式化
+func (rg *RegistryGenerator) Render(packages []ControllerPackage) ([]byte, error) {
+	aliases := resolveImportAliases(packages)
+
+	// 为节点分配逐行递增的位置，使 go/format 按一项一行输出
+	fset := token.NewFileSet()
+	lines := 4*len(packages) + 16
+	tf := fset.AddFile("registry_gen.go", -1, lines)
+	tf.SetLinesForContent(bytes.Repeat([]byte("\n"), lines))
+	line := 0
+	nextLine := func() token.Pos {
+		line++
+		return tf.Pos(line)
+	}
+
+	importDecl := &ast.GenDecl{TokPos: nextLine(), Tok: token.IMPORT, Lparen: nextLine()}
+	for _, pkg := range packages {
+		spec := &ast.ImportSpec{
+			Path: &ast.BasicLit{ValuePos: nextLine(), Kind: token.STRING, Value: strconv.Quote(pkg.ImportPath)},
+		}
+		if aliases[pkg.Name] != pkg.PackageName {
+			spec.Name = &ast.Ident{NamePos: spec.Path.Pos(), Name: aliases[pkg.Name]}
+		}
+		importDecl.Specs = append(importDecl.Specs, spec)
+	}
+	importDecl.Rparen = nextLine()
+
+	nextLine() // 导入与变量声明之间空一行
+	varPos := nextLine()
+	mapLit := &ast.CompositeLit{
+		Type:   &ast.MapType{Map: varPos, Key: ast.NewIdent("string"), Value: ast.NewIdent("ControllerFactory")},
+		Lbrace: varPos,
+	}
+	for _, pkg := range packages {
+		// "name": func() interface{} { return pkg.NewV1() },
+		pos := nextLine()
+		mapLit.Elts = append(mapLit.Elts, &ast.KeyValueExpr{
+			Key:   &ast.BasicLit{ValuePos: pos, Kind: token.STRING, Value: strconv.Quote(pkg.Name)},
+			Colon: pos,
+			Value: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Func:    pos,
+					Params:  &ast.FieldList{Opening: pos, Closing: pos},
+					Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.InterfaceType{Interface: pos, Methods: &ast.FieldList{Opening: pos, Closing: pos}}}}},
+				},
+				Body: &ast.BlockStmt{Lbrace: pos, Rbrace: pos, List: []ast.Stmt{
+					&ast.ReturnStmt{Return: pos, Results: []ast.Expr{
+						&ast.CallExpr{Lparen: pos, Rparen: pos, Fun: &ast.SelectorExpr{
+							X:   &ast.Ident{NamePos: pos, Name: aliases[pkg.Name]},
+							Sel: &ast.Ident{NamePos: pos, Name: registryFactoryName},
+						}},
+					}},
+				}},
+			},
+		})
+	}
+	mapLit.Rbrace = nextLine()
+
+	varDecl := &ast.GenDecl{
+		TokPos: varPos,
+		Tok:    token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names:  []*ast.Ident{{NamePos: varPos, Name: registryVarName}},
+			Values: []ast.Expr{mapLit},
+		}},
+	}
+
+	file := &ast.File{Name: ast.NewIdent("router")}
+	if len(importDecl.Specs) > 0 {
+		file.Decls = append(file.Decls, importDecl)
+	}
+	file.Decls = append(file.Decls, varDecl)
+
+	var buf bytes.Buffer
+	buf.WriteString(registryHeader)
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("生成控制器注册表失败: %v", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化控制器注册表失败: %v", err)
+	}
+	return src, nil
+}
+
+// hasFactoryFunc 判断文件是否声明了包级 NewV1 函数
+func hasFactoryFunc(file *ast.File) bool {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if ok && fn.Recv == nil && fn.Name.Name == registryFactoryName {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveImportAliases 为包名冲突的控制器包分配导入别名
+func resolveImportAliases(packages []ControllerPackage) map[string]string {
+	aliases := make(map[string]string, len(packages))
+	used := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		alias := pkg.PackageName
+		for i := 2; used[alias]; i++ {
+			alias = fmt.Sprintf("%s%d", pkg.PackageName, i)
+		}
+		used[alias] = true
+		aliases[pkg.Name] = alias
+	}
+	return aliases
+}
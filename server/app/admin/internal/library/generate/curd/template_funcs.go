@@ -0,0 +1,85 @@
+package curd
+
+import (
+	"fmt"
+	"strings"
+
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gview"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// DictOption 模板中字典查询返回的字典项
+type DictOption struct {
+	Label string `json:"label"` // 字典标签
+	Value string `json:"value"` // 字典值
+}
+
+// templateFuncs 模板中可用的扩展函数
+//
+//	camel/camelLower/snake/kebab  命名风格转换，如 {{camel "user_name"}} => UserName
+//	pluralize                     英文复数形式，如 {{pluralize "category"}} => categories
+//	dictOptions                   查询启用的字典项，如 {{range dictOptions "sys_status"}}{{.Label}}{{end}}
+//	dictLabel                     查询字典值对应的标签，如 {{dictLabel "sys_status" "1"}}
+func templateFuncs() gview.FuncMap {
+	return gview.FuncMap{
+		"camel":       gstr.CaseCamel,
+		"camelLower":  gstr.CaseCamelLower,
+		"snake":       gstr.CaseSnake,
+		"kebab":       gstr.CaseKebab,
+		"pluralize":   pluralize,
+		"dictOptions": dictOptions,
+		"dictLabel":   dictLabel,
+	}
+}
+
+// pluralize 返回英文单词的复数形式，仅处理常见规则
+func pluralize(word string) string {
+	lower := strings.ToLower(word)
+	switch {
+	case word == "":
+		return word
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return word + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
+
+// dictOptions 查询字典类型下启用的字典项，按排序值升序
+func dictOptions(dictType string) ([]DictOption, error) {
+	var list []*entity.Dict
+	err := dao.Dict.Ctx(gctx.New()).
+		Where(dao.Dict.Columns().DictType, dictType).
+		Where(dao.Dict.Columns().Status, 1).
+		OrderAsc(dao.Dict.Columns().Sort).
+		Scan(&list)
+	if err != nil {
+		return nil, fmt.Errorf("查询字典 %s 失败: %v", dictType, err)
+	}
+
+	options := make([]DictOption, 0, len(list))
+	for _, item := range list {
+		options = append(options, DictOption{Label: item.DictLabel, Value: item.DictValue})
+	}
+	return options, nil
+}
+
+// dictLabel 查询字典值对应的标签，未匹配时返回原值
+func dictLabel(dictType, value string) (string, error) {
+	options, err := dictOptions(dictType)
+	if err != nil {
+		return "", err
+	}
+	for _, option := range options {
+		if option.Value == value {
+			return option.Label, nil
+		}
+	}
+	return value, nil
+}
@@ -6,7 +6,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"server/app/admin/internal/consts"
-	"strings"
+	"server/app/admin/internal/library/generate"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gfile"
@@ -15,17 +15,26 @@ import (
 
 // Column 字段配置结构体
 type Column struct {
-	ColumnName     string `json:"columnName"`     // 数据库字段名
-	ColumnComment  string `json:"columnComment"`  // 字段注释
-	GoField        string `json:"goField"`        // Go字段名
-	GoType         string `json:"goType"`         // Go类型
-	JsonField      string `json:"jsonField"`      // JSON字段名
-	IsRequired     bool   `json:"isRequired"`     // 是否必填
-	IsQuery        bool   `json:"isQuery"`        // 是否用于查询
-	IsList         bool   `json:"isList"`         // 是否显示在列表
-	IsPointer      bool   `json:"isPointer"`      // 是否指针类型
-	IsUnique       bool   `json:"isUnique"`       // 是否唯一
-	ValidationRule string `json:"validationRule"` // 验证规则
+	ColumnName     string   `json:"columnName"`     // 数据库字段名
+	ColumnComment  string   `json:"columnComment"`  // 字段注释
+	GoField        string   `json:"goField"`        // Go字段名
+	GoType         string   `json:"goType"`         // Go类型
+	JsonField      string   `json:"jsonField"`      // JSON字段名
+	IsRequired     bool     `json:"isRequired"`     // 是否必填
+	IsQuery        bool     `json:"isQuery"`        // 是否用于查询
+	IsList         bool     `json:"isList"`         // 是否显示在列表
+	IsPointer      bool     `json:"isPointer"`      // 是否指针类型
+	IsUnique       bool     `json:"isUnique"`       // 是否唯一
+	ValidationRule string   `json:"validationRule"` // 验证规则
+	DictType       string   `json:"dictType"`       // 绑定的字典类型
+	QueryType      string   `json:"queryType"`      // 查询条件，见 generate.WhereModes
+	HtmlType       string   `json:"htmlType"`       // 表单组件，见 generate.FormModes
+	IsNullable     bool     `json:"isNullable"`     // 数据库字段是否可为空
+	DefaultValue   string   `json:"defaultValue"`   // 数据库字段默认值
+	MaxLength      int      `json:"maxLength"`      // 字符串最大长度，0 表示不限制
+	MinValue       string   `json:"minValue"`       // 数字最小值，空表示不限制
+	MaxValue       string   `json:"maxValue"`       // 数字最大值，空表示不限制
+	EnumValues     []string `json:"enumValues"`     // 可选值（enum 字段或绑定字典的字典值）
 }
 
 // CurdGenerator CURD代码生成器
@@ -35,13 +44,15 @@ type CurdGenerator struct {
 
 // NewCurdGenerator 创建新的CURD生成器
 func NewCurdGenerator() *CurdGenerator {
+	view := gview.New()
+	view.BindFuncMap(templateFuncs())
 	return &CurdGenerator{
-		view: gview.New(),
+		view: view,
 	}
 }
 
-// getProjectRoot 获取项目根目录
-func (cg *CurdGenerator) getProjectRoot() string {
+// projectRoot 获取项目根目录（包含go.mod的server目录）
+func projectRoot() string {
 	// 获取当前文件的绝对路径
 	_, currentFile, _, _ := runtime.Caller(0)
 	// 从当前文件路径向上查找到server目录
@@ -60,19 +71,18 @@ func (cg *CurdGenerator) getProjectRoot() string {
 		currentDir = parent
 	}
 
-	// 如果未找到go.mod，返回当前工作目录
-	if workDir := gfile.Pwd(); workDir != "" {
-		return workDir
-	}
+	// 如果未找到go.mod（如编译后部署），使用当前工作目录
+	return gfile.Pwd()
+}
 
-	// 最后的备选方案
-	return "/Users/ypp/开源/panda/server"
+// getProjectRoot 获取项目根目录
+func (cg *CurdGenerator) getProjectRoot() string {
+	return projectRoot()
 }
 
-// getTemplatePath 获取模板文件路径
-func (cg *CurdGenerator) getTemplatePath(templateName string) string {
-	projectRoot := cg.getProjectRoot()
-	return filepath.Join(projectRoot, "app", "admin", "resource", "generate", "curd", templateName)
+// getTemplatePath 获取模板文件路径，模板集未自定义该文件时使用默认模板
+func (cg *CurdGenerator) getTemplatePath(ctx context.Context, set, templateName string) string {
+	return TemplatePath(ctx, set, templateName)
 }
 
 // getOutputPath 获取输出文件路径
@@ -84,7 +94,7 @@ func (cg *CurdGenerator) getOutputPath(packageName, moduleName string) string {
 // GenerateAPI 生成API文件
 func (cg *CurdGenerator) GenerateAPI(ctx context.Context, config GenerateConfig) error {
 	// 动态获取模板文件路径
-	templatePath := cg.getTemplatePath("api.go.template")
+	templatePath := cg.getTemplatePath(ctx, config.templateSet(), "api.go.template")
 	fmt.Println(templatePath, "templatePath路径")
 	// 动态获取输出文件路径
 	outputPath := cg.getOutputPath(config.PackageName, config.ModuleName)
@@ -102,19 +112,97 @@ type GenerateConfig struct {
 	PackageName  string                  `json:"package_name"`  // 包名
 	ModuleName   string                  `json:"module_name"`   // 模块名
 	Columns      []Column                `json:"columns"`       // 字段配置
+	Joins        []JoinTable             `json:"joins"`         // 关联表配置
+	Tree         *TreeTable              `json:"tree"`          // 树表配置，非树表时为 nil
 	Options      *consts.GenerateOptions `json:"options"`       // 生成选项
 }
 
+// templateSet 返回生成使用的模板集名称
+func (c GenerateConfig) templateSet() string {
+	if c.Options == nil {
+		return ""
+	}
+	return c.Options.TemplateSet
+}
+
+// authCodes 返回接口与页面按钮使用的权限标识；未配置菜单名称时不会创建按钮权限并授予角色，
+// 此时返回空，生成的接口与按钮不做权限校验，避免非开发者用户无法访问
+func (c GenerateConfig) authCodes() map[string]string {
+	if c.Options == nil || c.Options.MenuName == "" {
+		return nil
+	}
+	return generate.GetAuthCodes(c.ModuleName)
+}
+
 // prepareTemplateData 准备模板数据
 func (cg *CurdGenerator) prepareTemplateData(config GenerateConfig) g.Map {
+	manyToOne, oneToMany := splitJoins(config.Joins)
 	return g.Map{
+		"TableName":    config.TableName,
+		"TemplateSet":  config.templateSet(),
 		"EntityName":   config.EntityName,
 		"TableComment": config.TableComment,
 		"ModuleName":   config.ModuleName,
 		"PackageName":  config.PackageName,
 		"Columns":      config.Columns,
 		"Options":      config.Options, // 添加选项到模板数据
+		"Auths":        config.authCodes(),
+		"ManyToOne":    manyToOne,   // 多对一关联表，列表中显示关联字段
+		"OneToMany":    oneToMany,   // 一对多子表，表单中内联编辑
+		"Tree":         config.Tree, // 树表配置，非树表时为 nil
+		"DictTypes":    collectDictTypes(config.Columns, func(c Column) bool { return c.IsList }),
+		// 导入时按可编辑字段的字典标签转换为字典值
+		"ImportDictTypes": collectDictTypes(config.Columns, isEditableColumn),
+		"UniqueColumns":   uniqueColumns(config.Columns),
+		// 绑定字典的可编辑字段，新增/修改/导入时校验字典值
+		"DictColumns": dictColumns(config.Columns),
+		// 存在 IN/BETWEEN 等切片查询参数时，前端需按 a[]=1&a[]=2 序列化
+		"SliceQuery": hasSliceQuery(config.Columns),
+		// 前端表单校验规则需要导入的校验函数
+		"RuleImports": ruleImports(config.Columns),
+		// 时间、JSON等类型的请求参数需要额外导入的包
+		"FieldImports": fieldImports(config.Columns, oneToMany),
+		// 树表父级字段名，非树表时为空，便于在字段循环中判断
+		"TreeParent": treeParent(config.Tree),
+		// 使用上传组件的字段，新增/修改/删除时记录业务数据对附件的引用
+		"UploadColumns": uploadColumns(config.Columns),
+	}
+}
+
+// isEditableColumn 判断字段是否可通过新增/修改接口写入
+func isEditableColumn(column Column) bool {
+	switch column.ColumnName {
+	case "id", "created_at", "updated_at":
+		return false
 	}
+	return true
+}
+
+// uniqueColumns 返回可编辑的唯一字段，用作导入时的更新依据
+func uniqueColumns(columns []Column) []Column {
+	var result []Column
+	for _, column := range columns {
+		if column.IsUnique && isEditableColumn(column) {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// collectDictTypes 返回满足条件的字段绑定的字典类型（去重，保持字段顺序）
+func collectDictTypes(columns []Column, include func(Column) bool) []string {
+	var (
+		types []string
+		seen  = make(map[string]bool)
+	)
+	for _, column := range columns {
+		if !include(column) || column.DictType == "" || seen[column.DictType] {
+			continue
+		}
+		seen[column.DictType] = true
+		types = append(types, column.DictType)
+	}
+	return types
 }
 
 // generateFromTemplate 从模板生成文件
@@ -152,7 +240,7 @@ func (cg *CurdGenerator) generateFromTemplate(ctx context.Context, templatePath,
 // GenerateLogic 生成Logic文件
 func (cg *CurdGenerator) GenerateLogic(ctx context.Context, config GenerateConfig) error {
 	// 动态获取模板文件路径
-	templatePath := cg.getTemplatePath("logic.go.template")
+	templatePath := cg.getTemplatePath(ctx, config.templateSet(), "logic.go.template")
 	fmt.Println(templatePath, "logic模板路径")
 
 	// 动态获取输出文件路径
@@ -169,7 +257,7 @@ func (cg *CurdGenerator) GenerateLogic(ctx context.Context, config GenerateConfi
 // GenerateController 生成Controller文件
 func (cg *CurdGenerator) GenerateController(ctx context.Context, config GenerateConfig) error {
 	// 动态获取模板文件路径
-	templatePath := cg.getTemplatePath("controller.go.template")
+	templatePath := cg.getTemplatePath(ctx, config.templateSet(), "controller.go.template")
 	fmt.Println(templatePath, "controller模板路径")
 
 	// 动态获取输出文件路径
@@ -185,72 +273,8 @@ func (cg *CurdGenerator) GenerateController(ctx context.Context, config Generate
 		return err
 	}
 
-	// 自动更新控制器注册表
-	return cg.updateControllerRegistry(config.PackageName)
-}
-
-// updateControllerRegistry 更新控制器注册表
-func (cg *CurdGenerator) updateControllerRegistry(packageName string) error {
-	registryPath := filepath.Join(cg.getProjectRoot(), "app", "admin", "internal", "router", "registry.go")
-
-	content := gfile.GetContents(registryPath)
-	if content == "" {
-		return fmt.Errorf("无法读取 registry.go 文件")
-	}
-
-	// 检查是否已经存在该控制器的导入
-	importLine := fmt.Sprintf(`"server/app/admin/internal/controller/%s"`, packageName)
-	if !strings.Contains(content, importLine) {
-		// 添加导入
-		content = cg.addRegistryImport(content, importLine)
-	}
-
-	// 检查是否已经存在该控制器的注册项
-	registryLine := fmt.Sprintf(`	"%s":`, packageName)
-	if !strings.Contains(content, registryLine) {
-		// 添加注册项
-		newRegistryLine := fmt.Sprintf(`	"%s": func() interface{} { return %s.NewV1() },`, packageName, packageName)
-		content = cg.addRegistryEntry(content, newRegistryLine)
-	}
-
-	return gfile.PutContents(registryPath, content)
-}
-
-// addRegistryImport 添加导入语句到注册表文件
-func (cg *CurdGenerator) addRegistryImport(content, importLine string) string {
-	// 找到最后一个controller导入的位置
-	lastImportPattern := `"server/app/admin/internal/controller/`
-	lastImportIndex := strings.LastIndex(content, lastImportPattern)
-
-	if lastImportIndex != -1 {
-		// 找到该行的结束位置
-		lineEnd := strings.Index(content[lastImportIndex:], "\n")
-		if lineEnd != -1 {
-			insertPos := lastImportIndex + lineEnd
-			// 在最后一个导入后添加新的导入
-			content = content[:insertPos] + "\n\t" + importLine + content[insertPos:]
-		}
-	}
-
-	return content
-}
-
-// addRegistryEntry 添加注册项到注册表
-func (cg *CurdGenerator) addRegistryEntry(content, registryLine string) string {
-	// 找到ControllerRegistry的结束位置（最后一个逗号后）
-	registryStart := "var ControllerRegistry = map[string]ControllerFactory{"
-	registryStartIndex := strings.Index(content, registryStart)
-
-	if registryStartIndex != -1 {
-		// 找到最后一个注册项的位置
-		lastCommaIndex := strings.LastIndex(content, "},")
-		if lastCommaIndex != -1 && lastCommaIndex > registryStartIndex {
-			// 在最后一个注册项后添加新的注册项
-			content = content[:lastCommaIndex+2] + "\n" + registryLine + content[lastCommaIndex+2:]
-		}
-	}
-
-	return content
+	// 重新扫描控制器目录生成注册表
+	return NewRegistryGenerator().Generate(ctx)
 }
 
 // getLogicOutputPath 获取Logic文件输出路径
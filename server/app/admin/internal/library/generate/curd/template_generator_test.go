@@ -0,0 +1,60 @@
+package curd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/app/admin/internal/consts"
+)
+
+// TestAuthCodesRequireMenu 仅在配置菜单名称、会创建按钮权限时，生成的接口与页面按钮才声明权限标识
+func TestAuthCodesRequireMenu(t *testing.T) {
+	cg := NewCurdGenerator()
+	templates := filepath.Join(cg.getProjectRoot(), "app", "admin", "resource", "generate", "curd")
+
+	cases := []struct {
+		name     string
+		menuName string
+		want     bool
+	}{
+		{name: "无菜单", menuName: "", want: false},
+		{name: "有菜单", menuName: "用户管理", want: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := GenerateConfig{
+				TableName: "user", TableComment: "用户", EntityName: "User",
+				PackageName: "user", ModuleName: "user",
+				Columns: renderCheckColumns(),
+				Options: &consts.GenerateOptions{
+					Create: true, Update: true, Delete: true, BatchDelete: true, List: true, Export: true, Import: true,
+					MenuName: c.menuName,
+				},
+			}
+			data := cg.prepareTemplateData(config)
+
+			for name, marker := range map[string]string{
+				"api.go.template":    `auth:"`,
+				"index.vue.template": `v-perms=`,
+			} {
+				content, err := os.ReadFile(filepath.Join(templates, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				result, err := cg.view.ParseContent(context.Background(), string(content), data)
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if got := strings.Contains(result, marker); got != c.want {
+					t.Errorf("%s 包含 %s = %v，期望 %v", name, marker, got, c.want)
+				}
+				if c.want && !strings.Contains(result, "user:btn:create") {
+					t.Errorf("%s 缺少权限标识 user:btn:create", name)
+				}
+			}
+		})
+	}
+}
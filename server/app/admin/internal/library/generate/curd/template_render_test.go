@@ -0,0 +1,187 @@
+package curd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/library/generate"
+)
+
+// renderCheckColumns 用户表字段，生成的代码使用已有的 dao.User、entity.User
+func renderCheckColumns() []Column {
+	return []Column{
+		{ColumnName: "id", ColumnComment: "ID", GoField: "Id", GoType: "uint64", JsonField: "id", IsList: true},
+		{ColumnName: "username", ColumnComment: "用户名", GoField: "Username", GoType: "string", JsonField: "username", IsRequired: true, IsQuery: true, IsList: true},
+		{ColumnName: "department_id", ColumnComment: "部门", GoField: "DepartmentId", GoType: "uint64", JsonField: "departmentId", IsList: true},
+		{ColumnName: "status", ColumnComment: "状态", GoField: "Status", GoType: "int", JsonField: "status", IsQuery: true, IsList: true},
+		{ColumnName: "created_at", ColumnComment: "创建时间", GoField: "CreatedAt", GoType: "*gtime.Time", JsonField: "createdAt", IsList: true},
+	}
+}
+
+// renderCheckTreeColumns 部门表字段，生成的代码使用已有的 dao.Department、entity.Department
+func renderCheckTreeColumns() []Column {
+	return []Column{
+		{ColumnName: "id", ColumnComment: "ID", GoField: "Id", GoType: "uint64", JsonField: "id", IsList: true},
+		{ColumnName: "parent_id", ColumnComment: "上级部门", GoField: "ParentId", GoType: "uint64", JsonField: "parentId", IsList: true},
+		{ColumnName: "name", ColumnComment: "部门名称", GoField: "Name", GoType: "string", JsonField: "name", IsRequired: true, IsQuery: true, IsList: true},
+		{ColumnName: "sort", ColumnComment: "排序号", GoField: "Sort", GoType: "int", JsonField: "sort", IsList: true},
+		{ColumnName: "status", ColumnComment: "状态", GoField: "Status", GoType: "int", JsonField: "status", IsQuery: true, IsList: true},
+	}
+}
+
+// copyModule 将模块复制到临时目录，生成的代码写入副本，避免在源码目录中残留文件
+func copyModule(t *testing.T, root string) string {
+	t.Helper()
+	dir := t.TempDir()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, 0644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestRenderedBackendVet 按多种生成配置渲染 api 与 logic 模板，写入模块副本后执行 go vet，确保生成的代码可以编译
+func TestRenderedBackendVet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("需要执行 go vet，short 模式跳过")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("未找到 go 命令")
+	}
+
+	crud := &consts.GenerateOptions{Create: true, Update: true, Delete: true, BatchDelete: true, List: true}
+	all := &consts.GenerateOptions{Create: true, Update: true, Delete: true, BatchDelete: true, List: true, Export: true, Import: true}
+	department := JoinTable{
+		TableName: "department", TableComment: "部门", EntityName: "Department",
+		Alias: "department", GoAlias: "Department", JsonAlias: "department",
+		Relation: consts.RelationManyToOne, JoinMethod: "LeftJoin",
+		JoinField: "id", JoinGoField: "Id", MainField: "department_id", MainGoField: "DepartmentId",
+		LabelField: "name", LabelGoField: "DepartmentName", LabelJsonField: "departmentName", LabelAs: "department_name",
+	}
+	userRole := JoinTable{
+		TableName: "user_role", TableComment: "用户角色", EntityName: "UserRole",
+		Alias: "user_role", GoAlias: "UserRole", JsonAlias: "userRole",
+		Relation: consts.RelationOneToMany, JoinMethod: "LeftJoin",
+		JoinField: "user_id", JoinGoField: "UserId", MainField: "id", MainGoField: "Id",
+		Columns: []Column{{ColumnName: "role_id", ColumnComment: "角色", GoField: "RoleId", GoType: "uint64", JsonField: "roleId", IsRequired: true}},
+	}
+	withDict := func(columns []Column) []Column {
+		columns[3].DictType = "sys_status"
+		return columns
+	}
+	withUpload := func(columns []Column) []Column {
+		return append(columns, Column{ColumnName: "avatar", ColumnComment: "头像", GoField: "Avatar", GoType: "string", JsonField: "avatar", IsList: true, HtmlType: generate.FormModeUploadImage})
+	}
+	withUnique := func(columns []Column) []Column {
+		columns[1].IsUnique = true
+		return columns
+	}
+	withQueryType := func(columns []Column, queryTypes ...string) []Column {
+		columns[2].IsQuery, columns[2].QueryType = true, queryTypes[0]
+		columns[3].QueryType = queryTypes[1]
+		columns[4].IsQuery, columns[4].QueryType = true, queryTypes[2]
+		return columns
+	}
+	tree := func(options consts.GenerateOptions) *consts.GenerateOptions {
+		options.TreeParent = generate.DefaultTreeParent
+		return &options
+	}
+
+	cases := []struct {
+		name    string
+		tree    bool // 使用部门表生成树表
+		columns []Column
+		joins   []JoinTable
+		options *consts.GenerateOptions
+	}{
+		{name: "plain", columns: renderCheckColumns(), options: crud},
+		{name: "join", columns: renderCheckColumns(), joins: []JoinTable{department}, options: crud},
+		{name: "dict", columns: withDict(renderCheckColumns()), options: crud},
+		{name: "listupload", columns: withUpload(renderCheckColumns()), options: &consts.GenerateOptions{List: true}},
+		{name: "deleteupload", columns: withUpload(renderCheckColumns()), options: &consts.GenerateOptions{List: true, Delete: true}},
+		{name: "full", columns: withUpload(withDict(renderCheckColumns())), joins: []JoinTable{department, userRole}, options: all},
+		{name: "uniqueimport", columns: withUnique(renderCheckColumns()), options: all},
+		{name: "uniquejoin", columns: withUnique(renderCheckColumns()), joins: []JoinTable{userRole}, options: crud},
+		{name: "between", columns: withQueryType(renderCheckColumns(), generate.WhereModeIn, generate.WhereModeBetween, generate.WhereModeBetween), options: crud},
+		{name: "notbetween", columns: withQueryType(renderCheckColumns(), generate.WhereModeNotIn, generate.WhereModeNotIn, generate.WhereModeNotBetween), options: all},
+		{name: "tree", tree: true, columns: renderCheckTreeColumns(), options: tree(*crud)},
+		{name: "treefull", tree: true, columns: withDict(renderCheckTreeColumns()), options: tree(*all)},
+	}
+
+	ctx := context.Background()
+	cg := NewCurdGenerator()
+	root := cg.getProjectRoot()
+	templates := filepath.Join(root, "app", "admin", "resource", "generate", "curd")
+	module := copyModule(t, root)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			packageName := "zzrender" + c.name
+			config := GenerateConfig{
+				TableName: "user", TableComment: "用户", EntityName: "User",
+				PackageName: packageName, ModuleName: packageName,
+				Columns: c.columns, Joins: c.joins, Options: c.options,
+			}
+			if c.tree {
+				config.TableName, config.TableComment, config.EntityName = "department", "部门", "Department"
+				tree, err := NewTreeTable(config.Columns, config.Options)
+				if err != nil || tree == nil {
+					t.Fatalf("构建树表配置失败: %v", err)
+				}
+				config.Tree = tree
+			}
+			data := cg.prepareTemplateData(config)
+
+			outputs := map[string]string{
+				"api.go.template":   filepath.Join(module, "app", "admin", "api", packageName, "v1", packageName+".go"),
+				"logic.go.template": filepath.Join(module, "app", "admin", "internal", "logic", packageName, packageName+".go"),
+			}
+			for name, output := range outputs {
+				content, err := os.ReadFile(filepath.Join(templates, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				result, err := cg.view.ParseContent(ctx, string(content), data)
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if err = os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err = os.WriteFile(output, []byte(result), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := exec.Command("go", "vet", "./app/admin/api/"+packageName+"/...", "./app/admin/internal/logic/"+packageName+"/...")
+			cmd.Dir = module
+			if output, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("生成的代码未通过 go vet: %v\n%s", err, output)
+			}
+		})
+	}
+}
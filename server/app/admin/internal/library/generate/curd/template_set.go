@@ -0,0 +1,294 @@
+package curd
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/library/generate"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// DefaultTemplateSet 内置默认模板集名称，对应 resource/generate/curd，只读
+const DefaultTemplateSet = "default"
+
+// TemplateFiles 模板集中可自定义的模板文件（相对路径）
+var TemplateFiles = []string{
+	"api.go.template",
+	"logic.go.template",
+	"controller.go.template",
+	"api.ts.template",
+	"index.vue.template",
+	"form/index.vue.template",
+	"form/subTable.vue.template",
+	"utils/types.ts.template",
+	"utils/hook.tsx.template",
+	"utils/rule.ts.template",
+}
+
+// templateSetNamePattern 模板集名称规则，同时作为目录名
+var templateSetNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// TemplateSet 模板集信息
+type TemplateSet struct {
+	Name    string         `json:"name"`    // 模板集名称
+	Builtin bool           `json:"builtin"` // 是否为内置默认模板集
+	Files   []TemplateFile `json:"files"`   // 模板文件
+}
+
+// TemplateFile 模板集中的模板文件
+type TemplateFile struct {
+	Name   string `json:"name"`   // 模板文件名（相对路径）
+	Custom bool   `json:"custom"` // 是否由模板集自定义，否则沿用默认模板
+}
+
+// builtinTemplateDir 返回内置默认模板目录
+func builtinTemplateDir() string {
+	return filepath.Join(projectRoot(), "app", "admin", "resource", "generate", "curd")
+}
+
+// templateSetRoot 返回自定义模板集的存放目录，可通过配置 generate.templateDir 指定
+func templateSetRoot(ctx context.Context) string {
+	dir := g.Cfg().MustGet(ctx, "generate.templateDir").String()
+	if dir == "" {
+		return filepath.Join(projectRoot(), "app", "admin", "resource", "generate", "sets")
+	}
+	return gfile.Abs(dir)
+}
+
+// isDefaultTemplateSet 是否为默认模板集
+func isDefaultTemplateSet(set string) bool {
+	return set == "" || set == DefaultTemplateSet
+}
+
+// checkTemplateName 校验模板集名称与模板文件名
+func checkTemplateName(set, name string) error {
+	if !templateSetNamePattern.MatchString(set) {
+		return fmt.Errorf("模板集名称只能包含字母、数字、下划线和中划线: %s", set)
+	}
+	for _, file := range TemplateFiles {
+		if file == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("不支持的模板文件: %s", name)
+}
+
+// TemplatePath 返回模板集中模板文件的路径，模板集未自定义该文件时使用默认模板
+func TemplatePath(ctx context.Context, set, name string) string {
+	if !isDefaultTemplateSet(set) {
+		path := filepath.Join(templateSetRoot(ctx), set, filepath.FromSlash(name))
+		if gfile.Exists(path) {
+			return path
+		}
+	}
+	return filepath.Join(builtinTemplateDir(), filepath.FromSlash(name))
+}
+
+// CheckTemplateSet 校验模板集是否存在
+func CheckTemplateSet(ctx context.Context, set string) error {
+	if isDefaultTemplateSet(set) {
+		return nil
+	}
+	if !templateSetNamePattern.MatchString(set) || !gfile.IsDir(filepath.Join(templateSetRoot(ctx), set)) {
+		return fmt.Errorf("模板集不存在: %s", set)
+	}
+	return nil
+}
+
+// ListTemplateSets 返回默认模板集及所有自定义模板集
+func ListTemplateSets(ctx context.Context) ([]TemplateSet, error) {
+	sets := []TemplateSet{{Name: DefaultTemplateSet, Builtin: true, Files: templateFiles(ctx, DefaultTemplateSet)}}
+
+	root := templateSetRoot(ctx)
+	if !gfile.IsDir(root) {
+		return sets, nil
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("读取模板集目录失败: %v", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && templateSetNamePattern.MatchString(entry.Name()) && entry.Name() != DefaultTemplateSet {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sets = append(sets, TemplateSet{Name: name, Files: templateFiles(ctx, name)})
+	}
+	return sets, nil
+}
+
+// templateFiles 返回模板集中各模板文件的自定义状态
+func templateFiles(ctx context.Context, set string) []TemplateFile {
+	files := make([]TemplateFile, 0, len(TemplateFiles))
+	for _, name := range TemplateFiles {
+		custom := !isDefaultTemplateSet(set) &&
+			gfile.Exists(filepath.Join(templateSetRoot(ctx), set, filepath.FromSlash(name)))
+		files = append(files, TemplateFile{Name: name, Custom: custom})
+	}
+	return files
+}
+
+// GetTemplate 读取模板集中的模板内容，custom 表示是否为模板集自定义的内容
+func GetTemplate(ctx context.Context, set, name string) (content string, custom bool, err error) {
+	if isDefaultTemplateSet(set) {
+		set = DefaultTemplateSet
+	}
+	if err = checkTemplateName(set, name); err != nil {
+		return "", false, err
+	}
+	if err = CheckTemplateSet(ctx, set); err != nil {
+		return "", false, err
+	}
+
+	path := TemplatePath(ctx, set, name)
+	if !gfile.Exists(path) {
+		return "", false, fmt.Errorf("模板文件不存在: %s", name)
+	}
+	custom = !strings.HasPrefix(path, builtinTemplateDir())
+	return gfile.GetContents(path), custom, nil
+}
+
+// SaveTemplate 校验并保存模板集中的模板，模板集不存在时自动创建，默认模板集不可修改
+func SaveTemplate(ctx context.Context, set, name, content string) error {
+	if isDefaultTemplateSet(set) {
+		return fmt.Errorf("默认模板集不可修改，请新建模板集")
+	}
+	if err := checkTemplateName(set, name); err != nil {
+		return err
+	}
+	if _, err := ValidateTemplate(ctx, name, content); err != nil {
+		return err
+	}
+
+	path := filepath.Join(templateSetRoot(ctx), set, filepath.FromSlash(name))
+	if err := gfile.PutContents(path, content); err != nil {
+		return fmt.Errorf("保存模板失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteTemplate 删除模板集中自定义的模板（恢复为默认模板），name 为空时删除整个模板集
+func DeleteTemplate(ctx context.Context, set, name string) error {
+	if isDefaultTemplateSet(set) {
+		return fmt.Errorf("默认模板集不可删除")
+	}
+	if err := CheckTemplateSet(ctx, set); err != nil {
+		return err
+	}
+
+	path := filepath.Join(templateSetRoot(ctx), set)
+	if name != "" {
+		if err := checkTemplateName(set, name); err != nil {
+			return err
+		}
+		path = filepath.Join(path, filepath.FromSlash(name))
+	}
+	if err := gfile.Remove(path); err != nil {
+		return fmt.Errorf("删除模板失败: %v", err)
+	}
+	return nil
+}
+
+// ValidateTemplate 使用示例数据渲染模板，检查模板语法、变量与函数，Go 模板还会检查生成代码的语法
+// 返回渲染结果供预览
+func ValidateTemplate(ctx context.Context, name, content string) (string, error) {
+	if err := checkTemplateName(DefaultTemplateSet, name); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", fmt.Errorf("模板内容不能为空")
+	}
+
+	// 分别使用普通表、树表示例数据渲染，返回普通表的渲染结果
+	cg := NewCurdGenerator()
+	var preview string
+	for i, config := range []GenerateConfig{sampleConfig(), sampleTreeConfig()} {
+		data := cg.prepareTemplateData(config)
+		if name == "form/subTable.vue.template" {
+			_, oneToMany := splitJoins(config.Joins)
+			data["Join"] = oneToMany[0]
+		}
+
+		result, err := cg.view.ParseContent(ctx, content, data)
+		if err != nil {
+			return preview, fmt.Errorf("模板解析失败: %v", err)
+		}
+		if i == 0 {
+			preview = result
+		}
+		// 模板数据为 map，引用不存在的变量不会报错而是输出 <no value>
+		if strings.Contains(result, "<no value>") {
+			return result, fmt.Errorf("模板引用了不存在的变量")
+		}
+		if strings.HasSuffix(name, ".go.template") {
+			if _, err = format.Source([]byte(result)); err != nil {
+				return result, fmt.Errorf("生成的Go代码存在语法错误: %v", err)
+			}
+		}
+	}
+	return preview, nil
+}
+
+// sampleConfig 返回校验模板使用的示例生成配置，覆盖关联表、字典、导入导出等分支
+func sampleConfig() GenerateConfig {
+	columns := []Column{
+		{ColumnName: "id", ColumnComment: "ID", GoField: "Id", GoType: "uint64", JsonField: "id", IsList: true},
+		{ColumnName: "name", ColumnComment: "名称", GoField: "Name", GoType: "string", JsonField: "name",
+			IsRequired: true, IsQuery: true, IsList: true, IsUnique: true, MaxLength: 64},
+		{ColumnName: "price", ColumnComment: "价格", GoField: "Price", GoType: "float64", JsonField: "price",
+			IsList: true, IsQuery: true, QueryType: "BETWEEN", ValidationRule: "amount", MinValue: "0"},
+		{ColumnName: "status", ColumnComment: "状态", GoField: "Status", GoType: "int", JsonField: "status",
+			IsList: true, IsQuery: true, DictType: "sys_status", EnumValues: []string{"0", "1"}},
+		{ColumnName: "category_id", ColumnComment: "分类", GoField: "CategoryId", GoType: "uint64", JsonField: "category_id"},
+		{ColumnName: "created_at", ColumnComment: "创建时间", GoField: "CreatedAt", GoType: "*gtime.Time",
+			JsonField: "created_at", IsList: true, IsQuery: true, QueryType: "BETWEEN"},
+	}
+	joins := []JoinTable{
+		{TableName: "category", TableComment: "分类", EntityName: "Category", Alias: "c", GoAlias: "C", JsonAlias: "c",
+			Relation: consts.RelationManyToOne, JoinMethod: "LeftJoin", JoinField: "id", JoinGoField: "Id",
+			MainField: "category_id", MainGoField: "CategoryId", LabelField: "name",
+			LabelGoField: "CName", LabelJsonField: "cName", LabelAs: "c_name"},
+		{TableName: "sku", TableComment: "规格", EntityName: "Sku", Alias: "sku", GoAlias: "Sku", JsonAlias: "sku",
+			Relation: consts.RelationOneToMany, JoinMethod: "LeftJoin", JoinField: "product_id", JoinGoField: "ProductId",
+			MainField: "id", MainGoField: "Id", Columns: []Column{
+				{ColumnName: "code", ColumnComment: "编码", GoField: "Code", GoType: "string", JsonField: "code", IsRequired: true},
+				{ColumnName: "stock", ColumnComment: "库存", GoField: "Stock", GoType: "int", JsonField: "stock"},
+			}},
+	}
+	return GenerateConfig{
+		TableName:    "product",
+		TableComment: "商品",
+		EntityName:   "Product",
+		PackageName:  "product",
+		ModuleName:   "product",
+		Columns:      columns,
+		Joins:        joins,
+		Options: &consts.GenerateOptions{
+			Create: true, Update: true, Delete: true, BatchDelete: true,
+			List: true, Export: true, Import: true,
+		},
+	}
+}
+
+// sampleTreeConfig 返回校验树表分支使用的示例生成配置，在示例配置基础上增加父级字段
+func sampleTreeConfig() GenerateConfig {
+	config := sampleConfig()
+	config.Columns = append(config.Columns, Column{ColumnName: "parent_id", ColumnComment: "上级商品",
+		GoField: "ParentId", GoType: "uint64", JsonField: "parent_id", IsList: true})
+	config.Options.TreeParent = generate.DefaultTreeParent
+	config.Tree, _ = NewTreeTable(config.Columns, config.Options)
+	return config
+}
@@ -0,0 +1,114 @@
+package curd
+
+import (
+	"fmt"
+
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/library/generate"
+)
+
+// treeLabelCandidates 未配置显示字段时优先使用的字段
+var treeLabelCandidates = []string{"name", "title", "label"}
+
+// treeSortCandidates 作为同级排序依据的字段
+var treeSortCandidates = []string{"sort", "rank", "order_num"}
+
+// TreeTable 树表模板数据
+type TreeTable struct {
+	Parent Column `json:"parent"` // 父级字段
+	Label  Column `json:"label"`  // 上级选择器中显示的字段
+	// SortGoField 同级排序字段的Go名称，表中不存在排序字段时为空
+	SortGoField string `json:"sortGoField"`
+}
+
+// NewTreeTable 根据生成选项构建树表模板数据，未配置父级字段时返回 nil；
+// 父级字段为自动识别的 parent_id 但表中不存在时同样返回 nil。
+// 父级字段的表单组件固定为树形选择（不绑定字典），且不作为必填字段（顶级节点的父级为 0）
+func NewTreeTable(columns []Column, options *consts.GenerateOptions) (*TreeTable, error) {
+	if options == nil || options.TreeParent == "" {
+		return nil, nil
+	}
+
+	parent, ok := findColumn(columns, options.TreeParent)
+	if !ok {
+		if options.TreeParent == generate.DefaultTreeParent {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("树表父级字段不存在: %s", options.TreeParent)
+	}
+	if !parent.IsNumber() {
+		if options.TreeParent == generate.DefaultTreeParent {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("树表父级字段必须为数字类型: %s", options.TreeParent)
+	}
+
+	label, err := treeLabelColumn(columns, parent, options.TreeLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range columns {
+		if columns[i].ColumnName == parent.ColumnName {
+			columns[i].HtmlType = generate.FormModeTreeSelect
+			columns[i].DictType = ""
+			columns[i].IsRequired = false
+			parent = columns[i]
+		}
+	}
+	return &TreeTable{Parent: parent, Label: label, SortGoField: treeSortGoField(columns)}, nil
+}
+
+// treeLabelColumn 返回上级选择器中显示的字段，未配置时依次尝试 name/title/label、首个字符串字段，最后使用 id
+func treeLabelColumn(columns []Column, parent Column, labelField string) (Column, error) {
+	if labelField != "" {
+		column, ok := findColumn(columns, labelField)
+		if !ok {
+			return Column{}, fmt.Errorf("树表显示字段不存在: %s", labelField)
+		}
+		return column, nil
+	}
+
+	for _, name := range treeLabelCandidates {
+		if column, ok := findColumn(columns, name); ok {
+			return column, nil
+		}
+	}
+	for _, column := range columns {
+		if column.GoType == generate.GoTypeString && column.ColumnName != parent.ColumnName {
+			return column, nil
+		}
+	}
+	if column, ok := findColumn(columns, "id"); ok {
+		return column, nil
+	}
+	return Column{}, fmt.Errorf("树表缺少显示字段")
+}
+
+// treeSortGoField 返回同级排序字段的Go名称
+func treeSortGoField(columns []Column) string {
+	for _, name := range treeSortCandidates {
+		if column, ok := findColumn(columns, name); ok && column.IsNumber() {
+			return column.GoField
+		}
+	}
+	return ""
+}
+
+// findColumn 按数据库字段名查找字段
+func findColumn(columns []Column, name string) (Column, bool) {
+	for _, column := range columns {
+		if column.ColumnName == name {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// treeParent 返回树表父级字段名，非树表时为空
+func treeParent(tree *TreeTable) string {
+	if tree == nil {
+		return ""
+	}
+	return tree.Parent.ColumnName
+}
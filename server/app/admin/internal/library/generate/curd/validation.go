@@ -0,0 +1,138 @@
+package curd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"server/app/admin/internal/library/generate"
+)
+
+// validationRuleImports 表单验证对应的前端校验函数（@pureadmin/utils）
+var validationRuleImports = map[string]string{
+	generate.FormValidationPhone: "isPhone",
+	generate.FormValidationEmail: "isEmail",
+}
+
+// validRule 字段的一条 gvalid 校验规则及其错误提示
+type validRule struct {
+	rule    string
+	message string
+}
+
+// FormValidation 返回规范化后的表单验证，见 generate.ValidationRules
+func (c Column) FormValidation() string {
+	return generate.NormalizeFormValidation(c.ValidationRule)
+}
+
+// HasLengthRule 是否需要校验字符串长度
+func (c Column) HasLengthRule() bool {
+	return c.MaxLength > 0 && c.FieldGoType() == "*"+generate.GoTypeString
+}
+
+// HasRangeRule 是否需要校验数字取值范围
+func (c Column) HasRangeRule() bool {
+	return c.IsNumber() && !c.IsMultiValue() && (c.MinValue != "" || c.MaxValue != "")
+}
+
+// HasEnumRule 是否需要校验可选值，多值字段由字典校验逐项检查
+func (c Column) HasEnumRule() bool {
+	return len(c.EnumValues) > 0 && !c.IsMultiValue()
+}
+
+// EnumTsValues 返回可选值的TS数组字面量
+func (c Column) EnumTsValues() string {
+	values, _ := json.Marshal(c.EnumValues)
+	return string(values)
+}
+
+// validRules 返回字段的 gvalid 校验规则
+func (c Column) validRules() []validRule {
+	var rules []validRule
+	if c.IsRequired {
+		rules = append(rules, validRule{"required", "请输入" + c.ColumnComment})
+	}
+
+	switch c.FormValidation() {
+	case generate.FormValidationPhone:
+		rules = append(rules, validRule{"phone", "请输入正确的" + c.ColumnComment})
+	case generate.FormValidationEmail:
+		rules = append(rules, validRule{"email", "请输入正确的" + c.ColumnComment})
+	case generate.FormValidationNum:
+		rules = append(rules,
+			validRule{"integer", c.ColumnComment + "必须为整数"},
+			validRule{"min:1", c.ColumnComment + "必须为非零正整数"},
+		)
+	case generate.FormValidationAmount:
+		rules = append(rules, validRule{`regex:^\d+(\.\d{1,2})?$`, c.ColumnComment + "必须为最多两位小数的金额"})
+	}
+
+	if c.HasLengthRule() {
+		rules = append(rules, validRule{
+			fmt.Sprintf("max-length:%d", c.MaxLength),
+			fmt.Sprintf("%s长度不能超过%d个字符", c.ColumnComment, c.MaxLength),
+		})
+	}
+
+	if c.HasRangeRule() {
+		switch {
+		case c.MinValue != "" && c.MaxValue != "":
+			rules = append(rules, validRule{
+				fmt.Sprintf("between:%s,%s", c.MinValue, c.MaxValue),
+				fmt.Sprintf("%s必须在%s到%s之间", c.ColumnComment, c.MinValue, c.MaxValue),
+			})
+		case c.MinValue != "":
+			rules = append(rules, validRule{"min:" + c.MinValue, fmt.Sprintf("%s不能小于%s", c.ColumnComment, c.MinValue)})
+		default:
+			rules = append(rules, validRule{"max:" + c.MaxValue, fmt.Sprintf("%s不能大于%s", c.ColumnComment, c.MaxValue)})
+		}
+	}
+
+	// 可选值含规则分隔符时无法写入 in 规则，交由字典校验
+	if c.HasEnumRule() && !strings.ContainsAny(strings.Join(c.EnumValues, ""), ",|#\"`\\") {
+		rules = append(rules, validRule{"in:" + strings.Join(c.EnumValues, ","), c.ColumnComment + "取值不正确"})
+	}
+	return rules
+}
+
+// ValidRules 返回请求参数 v 标签的内容，格式为 规则1|规则2#提示1|提示2，无规则时返回空字符串
+// 规则中的反斜杠按结构体标签转义
+func (c Column) ValidRules() string {
+	rules := c.validRules()
+	if len(rules) == 0 {
+		return ""
+	}
+	names := make([]string, len(rules))
+	messages := make([]string, len(rules))
+	for i, rule := range rules {
+		names[i] = rule.rule
+		messages[i] = rule.message
+	}
+	return strings.ReplaceAll(strings.Join(names, "|")+"#"+strings.Join(messages, "|"), `\`, `\\`)
+}
+
+// HasFormRules 表单中是否需要生成校验规则
+func (c Column) HasFormRules() bool {
+	return c.IsRequired || c.FormValidation() != "" || c.HasLengthRule() || c.HasRangeRule() ||
+		(c.HasEnumRule() && c.DictType == "")
+}
+
+// ruleImports 返回前端表单校验规则所需导入的校验函数
+func ruleImports(columns []Column) []string {
+	var (
+		imports []string
+		seen    = make(map[string]bool)
+	)
+	for _, column := range columns {
+		if !isEditableColumn(column) {
+			continue
+		}
+		name, ok := validationRuleImports[column.FormValidation()]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		imports = append(imports, name)
+	}
+	return imports
+}
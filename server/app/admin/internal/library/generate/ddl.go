@@ -0,0 +1,605 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// 以下为表结构设计使用的 DDL 定义与渲染，按 MySQL 方言生成语句
+
+// 表结构变更动作
+const (
+	DiffActionAdd    = "add"    // 新增字段
+	DiffActionModify = "modify" // 修改字段
+	DiffActionDrop   = "drop"   // 删除字段
+	DiffActionIndex  = "index"  // 新增索引
+)
+
+// identifierPattern 表名、字段名、索引名规则
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// maxIdentifierLength MySQL 标识符最大长度
+const maxIdentifierLength = 64
+
+// 表结构设计支持的数据类型分组
+var (
+	ddlIntegerTypes = map[string]bool{"tinyint": true, "smallint": true, "mediumint": true, "int": true, "bigint": true}
+	ddlDecimalTypes = map[string]bool{"decimal": true, "float": true, "double": true}
+	ddlStringTypes  = map[string]int{"char": 255, "varchar": 16383} // 值为最大长度
+	ddlTextTypes    = map[string]bool{
+		"tinytext": true, "text": true, "mediumtext": true, "longtext": true,
+		"tinyblob": true, "blob": true, "mediumblob": true, "longblob": true, "json": true,
+	}
+	ddlTimeTypes = map[string]bool{"date": true, "datetime": true, "timestamp": true, "time": true, "year": true}
+)
+
+// ddlCurrentTimestamp 时间字段可使用的默认值
+const ddlCurrentTimestamp = "CURRENT_TIMESTAMP"
+
+// ddlEscaper 转义 SQL 字符串字面量
+var ddlEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+// ColumnDefinition 字段定义
+type ColumnDefinition struct {
+	Name          string   `json:"name"`          // 字段名
+	Type          string   `json:"type"`          // 数据类型，如 int、varchar、decimal
+	Length        int      `json:"length"`        // 长度/精度，varchar、char、decimal 必填
+	Scale         int      `json:"scale"`         // 小数位数，仅 decimal
+	Unsigned      bool     `json:"unsigned"`      // 是否无符号，仅数字类型
+	Nullable      bool     `json:"nullable"`      // 是否允许为空
+	Default       *string  `json:"default"`       // 默认值，nil 表示不设置；时间字段可使用 CURRENT_TIMESTAMP
+	AutoIncrement bool     `json:"autoIncrement"` // 是否自增，仅整数主键
+	PrimaryKey    bool     `json:"primaryKey"`    // 是否主键
+	OnUpdate      bool     `json:"onUpdate"`      // 更新时自动设置为当前时间，仅 datetime、timestamp
+	Comment       string   `json:"comment"`       // 字段注释
+	Values        []string `json:"values"`        // 枚举可选值，仅 enum
+}
+
+// IndexDefinition 索引定义
+type IndexDefinition struct {
+	Name    string   `json:"name"`    // 索引名，为空时按字段自动生成
+	Columns []string `json:"columns"` // 索引字段
+	Unique  bool     `json:"unique"`  // 是否唯一索引
+}
+
+// TableDefinition 建表定义
+type TableDefinition struct {
+	TableName string             `json:"tableName"` // 表名
+	Comment   string             `json:"comment"`   // 表注释
+	Columns   []ColumnDefinition `json:"columns"`   // 字段
+	Indexes   []IndexDefinition  `json:"indexes"`   // 索引（不含主键）
+}
+
+// AlterDefinition 修改表结构定义
+type AlterDefinition struct {
+	TableName     string             `json:"tableName"`     // 表名
+	AddColumns    []ColumnDefinition `json:"addColumns"`    // 新增字段
+	ModifyColumns []ColumnDefinition `json:"modifyColumns"` // 修改字段
+	DropColumns   []string           `json:"dropColumns"`   // 删除字段
+	AddIndexes    []IndexDefinition  `json:"addIndexes"`    // 新增索引
+}
+
+// ColumnDiff 表结构变更差异
+type ColumnDiff struct {
+	Action string `json:"action"` // 变更动作：add、modify、drop、index
+	Name   string `json:"name"`   // 字段名或索引名
+	Before string `json:"before"` // 变更前定义，新增时为空
+	After  string `json:"after"`  // 变更后定义，删除时为空
+}
+
+// checkIdentifier 校验表名、字段名、索引名
+func checkIdentifier(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%s不能为空", kind)
+	}
+	if len(name) > maxIdentifierLength || !identifierPattern.MatchString(name) {
+		return fmt.Errorf("%s %s 不合法，只能包含字母、数字和下划线，且不能以数字开头，最长64个字符", kind, name)
+	}
+	return nil
+}
+
+// quoteIdentifier 使用反引号包裹标识符
+func quoteIdentifier(name string) string {
+	return "`" + name + "`"
+}
+
+// quoteString 返回转义后的字符串字面量
+func quoteString(value string) string {
+	return "'" + ddlEscaper.Replace(value) + "'"
+}
+
+// isIndexableType 是否可以直接建立索引（text、blob、json 需要前缀长度，不支持）
+func isIndexableType(dataType string) bool {
+	return !ddlTextTypes[strings.ToLower(dataType)]
+}
+
+// checkColumnDefinition 校验字段定义
+func checkColumnDefinition(column ColumnDefinition) error {
+	if err := checkIdentifier("字段名", column.Name); err != nil {
+		return err
+	}
+	dataType := strings.ToLower(column.Type)
+	name := column.Name
+
+	switch {
+	case ddlIntegerTypes[dataType]:
+		if column.Length < 0 || column.Length > 255 {
+			return fmt.Errorf("字段 %s 的显示宽度必须在 0-255 之间", name)
+		}
+	case dataType == "decimal":
+		if column.Length < 1 || column.Length > 65 {
+			return fmt.Errorf("字段 %s 的精度必须在 1-65 之间", name)
+		}
+		if column.Scale < 0 || column.Scale > 30 || column.Scale > column.Length {
+			return fmt.Errorf("字段 %s 的小数位数必须在 0-30 之间且不能大于精度", name)
+		}
+	case ddlDecimalTypes[dataType], ddlTimeTypes[dataType], ddlTextTypes[dataType]:
+	case ddlStringTypes[dataType] > 0:
+		if column.Length < 1 || column.Length > ddlStringTypes[dataType] {
+			return fmt.Errorf("字段 %s 的长度必须在 1-%d 之间", name, ddlStringTypes[dataType])
+		}
+	case dataType == "enum":
+		if len(column.Values) == 0 {
+			return fmt.Errorf("字段 %s 的枚举值不能为空", name)
+		}
+	default:
+		return fmt.Errorf("字段 %s 的数据类型 %s 不支持", name, column.Type)
+	}
+
+	if column.Unsigned && !ddlIntegerTypes[dataType] && !ddlDecimalTypes[dataType] {
+		return fmt.Errorf("字段 %s 的数据类型 %s 不支持无符号", name, column.Type)
+	}
+	if column.AutoIncrement && (!ddlIntegerTypes[dataType] || !column.PrimaryKey) {
+		return fmt.Errorf("字段 %s 只有整数主键才能设置自增", name)
+	}
+	if column.PrimaryKey && column.Nullable {
+		return fmt.Errorf("主键字段 %s 不能允许为空", name)
+	}
+	if column.PrimaryKey && !isIndexableType(dataType) {
+		return fmt.Errorf("字段 %s 的数据类型 %s 不能作为主键", name, column.Type)
+	}
+	if column.OnUpdate && dataType != "datetime" && dataType != "timestamp" {
+		return fmt.Errorf("字段 %s 只有 datetime、timestamp 类型才能设置更新时自动赋值", name)
+	}
+	if column.Default != nil {
+		if _, err := columnDefaultSQL(column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnTypeSQL 返回字段的类型定义，如 varchar(64)、decimal(10,2) unsigned
+func columnTypeSQL(column ColumnDefinition) string {
+	dataType := strings.ToLower(column.Type)
+	var sql string
+	switch {
+	case dataType == "decimal":
+		sql = fmt.Sprintf("decimal(%d,%d)", column.Length, column.Scale)
+	case dataType == "enum":
+		values := make([]string, 0, len(column.Values))
+		for _, value := range column.Values {
+			values = append(values, quoteString(value))
+		}
+		sql = "enum(" + strings.Join(values, ",") + ")"
+	case column.Length > 0 && (ddlIntegerTypes[dataType] || ddlStringTypes[dataType] > 0):
+		sql = fmt.Sprintf("%s(%d)", dataType, column.Length)
+	default:
+		sql = dataType
+	}
+	if column.Unsigned {
+		sql += " unsigned"
+	}
+	return sql
+}
+
+// columnDefaultSQL 返回字段默认值的 SQL 表示，校验默认值与类型是否匹配
+func columnDefaultSQL(column ColumnDefinition) (string, error) {
+	value := *column.Default
+	dataType := strings.ToLower(column.Type)
+	name := column.Name
+
+	if strings.EqualFold(value, "NULL") {
+		if !column.Nullable {
+			return "", fmt.Errorf("字段 %s 不允许为空，默认值不能为 NULL", name)
+		}
+		return "NULL", nil
+	}
+	switch {
+	case column.AutoIncrement:
+		return "", fmt.Errorf("自增字段 %s 不能设置默认值", name)
+	case ddlTextTypes[dataType]:
+		return "", fmt.Errorf("字段 %s 的数据类型 %s 不能设置默认值", name, column.Type)
+	case ddlIntegerTypes[dataType]:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "", fmt.Errorf("字段 %s 的默认值必须为整数", name)
+		}
+		return value, nil
+	case ddlDecimalTypes[dataType]:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("字段 %s 的默认值必须为数字", name)
+		}
+		return value, nil
+	case dataType == "enum":
+		for _, item := range column.Values {
+			if item == value {
+				return quoteString(value), nil
+			}
+		}
+		return "", fmt.Errorf("字段 %s 的默认值必须为枚举值之一", name)
+	case (dataType == "datetime" || dataType == "timestamp") && strings.EqualFold(value, ddlCurrentTimestamp):
+		return ddlCurrentTimestamp, nil
+	}
+	return quoteString(value), nil
+}
+
+// columnSQL 返回字段定义语句，调用前需先校验字段定义
+func columnSQL(column ColumnDefinition) string {
+	parts := []string{quoteIdentifier(column.Name), columnTypeSQL(column)}
+	if column.Nullable {
+		parts = append(parts, "NULL")
+	} else {
+		parts = append(parts, "NOT NULL")
+	}
+	if column.AutoIncrement {
+		parts = append(parts, "AUTO_INCREMENT")
+	}
+	if column.Default != nil {
+		if value, err := columnDefaultSQL(column); err == nil {
+			parts = append(parts, "DEFAULT "+value)
+		}
+	}
+	if column.OnUpdate {
+		parts = append(parts, "ON UPDATE "+ddlCurrentTimestamp)
+	}
+	if column.Comment != "" {
+		parts = append(parts, "COMMENT "+quoteString(column.Comment))
+	}
+	return strings.Join(parts, " ")
+}
+
+// indexName 返回索引名，未指定时按 idx_/uk_ 前缀加字段名生成
+func indexName(index IndexDefinition) string {
+	if index.Name != "" {
+		return index.Name
+	}
+	prefix := "idx_"
+	if index.Unique {
+		prefix = "uk_"
+	}
+	name := prefix + strings.Join(index.Columns, "_")
+	if len(name) > maxIdentifierLength {
+		name = name[:maxIdentifierLength]
+	}
+	return name
+}
+
+// indexColumnsSQL 返回索引字段列表，如 (`a`,`b`)
+func indexColumnsSQL(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quoted = append(quoted, quoteIdentifier(column))
+	}
+	return "(" + strings.Join(quoted, ",") + ")"
+}
+
+// checkIndexDefinition 校验索引定义，columnTypes 为表中字段名（小写）到数据类型的映射，names 记录已使用的索引名
+func checkIndexDefinition(index IndexDefinition, columnTypes map[string]string, names map[string]bool) error {
+	if len(index.Columns) == 0 {
+		return fmt.Errorf("索引字段不能为空")
+	}
+	name := indexName(index)
+	if err := checkIdentifier("索引名", name); err != nil {
+		return err
+	}
+	if strings.EqualFold(name, "PRIMARY") || names[strings.ToLower(name)] {
+		return fmt.Errorf("索引名 %s 重复", name)
+	}
+	names[strings.ToLower(name)] = true
+
+	seen := make(map[string]bool, len(index.Columns))
+	for _, column := range index.Columns {
+		dataType, ok := columnTypes[strings.ToLower(column)]
+		if !ok {
+			return fmt.Errorf("索引 %s 的字段 %s 不存在", name, column)
+		}
+		if seen[strings.ToLower(column)] {
+			return fmt.Errorf("索引 %s 的字段 %s 重复", name, column)
+		}
+		seen[strings.ToLower(column)] = true
+		if !isIndexableType(dataType) {
+			return fmt.Errorf("索引 %s 的字段 %s 类型为 %s，不能建立索引", name, column, dataType)
+		}
+	}
+	return nil
+}
+
+// CreateTableSQL 校验建表定义并生成 CREATE TABLE 语句
+func CreateTableSQL(definition TableDefinition) (string, error) {
+	if err := checkIdentifier("表名", definition.TableName); err != nil {
+		return "", err
+	}
+	if len(definition.Columns) == 0 {
+		return "", fmt.Errorf("表 %s 至少需要一个字段", definition.TableName)
+	}
+
+	var (
+		lines       = make([]string, 0, len(definition.Columns)+len(definition.Indexes)+1)
+		columnTypes = make(map[string]string, len(definition.Columns))
+		primaryKeys []string
+		autoCount   int
+	)
+	for _, column := range definition.Columns {
+		if err := checkColumnDefinition(column); err != nil {
+			return "", err
+		}
+		if _, ok := columnTypes[strings.ToLower(column.Name)]; ok {
+			return "", fmt.Errorf("字段 %s 重复", column.Name)
+		}
+		columnTypes[strings.ToLower(column.Name)] = strings.ToLower(column.Type)
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, column.Name)
+		}
+		if column.AutoIncrement {
+			autoCount++
+		}
+		lines = append(lines, "  "+columnSQL(column))
+	}
+	if len(primaryKeys) == 0 {
+		return "", fmt.Errorf("表 %s 至少需要一个主键字段", definition.TableName)
+	}
+	if autoCount > 1 {
+		return "", fmt.Errorf("表 %s 只能有一个自增字段", definition.TableName)
+	}
+	lines = append(lines, "  PRIMARY KEY "+indexColumnsSQL(primaryKeys))
+
+	names := make(map[string]bool, len(definition.Indexes))
+	for _, index := range definition.Indexes {
+		if err := checkIndexDefinition(index, columnTypes, names); err != nil {
+			return "", err
+		}
+		keyword := "KEY"
+		if index.Unique {
+			keyword = "UNIQUE KEY"
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s", keyword, quoteIdentifier(indexName(index)), indexColumnsSQL(index.Columns)))
+	}
+
+	sql := fmt.Sprintf("CREATE TABLE %s (\n%s\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		quoteIdentifier(definition.TableName), strings.Join(lines, ",\n"))
+	if definition.Comment != "" {
+		sql += " COMMENT=" + quoteString(definition.Comment)
+	}
+	return sql, nil
+}
+
+// AlterTableSQL 对比当前表结构校验变更定义，生成 ALTER TABLE 语句及变更差异
+// columns 为当前表字段（GetTableColumnsWithDetail），indexes 为当前表索引（GetTableIndexes）。
+// 修改后与当前定义一致的字段会被忽略；不支持变更主键
+func AlterTableSQL(alter AlterDefinition, columns []ColumnMeta, indexes []IndexDefinition) (string, []ColumnDiff, error) {
+	if err := checkIdentifier("表名", alter.TableName); err != nil {
+		return "", nil, err
+	}
+	if len(columns) == 0 {
+		return "", nil, fmt.Errorf("表 %s 不存在", alter.TableName)
+	}
+
+	var (
+		current     = make(map[string]ColumnMeta, len(columns))
+		columnTypes = make(map[string]string, len(columns)+len(alter.AddColumns))
+		touched     = make(map[string]bool)
+		clauses     []string
+		diffs       []ColumnDiff
+	)
+	for _, column := range columns {
+		current[strings.ToLower(column.ColumnName)] = column
+		columnTypes[strings.ToLower(column.ColumnName)] = strings.ToLower(column.DataType)
+	}
+	touch := func(name string) error {
+		if touched[strings.ToLower(name)] {
+			return fmt.Errorf("字段 %s 在本次变更中重复出现", name)
+		}
+		touched[strings.ToLower(name)] = true
+		return nil
+	}
+
+	for _, column := range alter.AddColumns {
+		if err := checkColumnDefinition(column); err != nil {
+			return "", nil, err
+		}
+		if column.PrimaryKey || column.AutoIncrement {
+			return "", nil, fmt.Errorf("不支持新增主键或自增字段 %s", column.Name)
+		}
+		if _, ok := current[strings.ToLower(column.Name)]; ok {
+			return "", nil, fmt.Errorf("字段 %s 已存在", column.Name)
+		}
+		if err := touch(column.Name); err != nil {
+			return "", nil, err
+		}
+		columnTypes[strings.ToLower(column.Name)] = strings.ToLower(column.Type)
+		definition := columnSQL(column)
+		clauses = append(clauses, "ADD COLUMN "+definition)
+		diffs = append(diffs, ColumnDiff{Action: DiffActionAdd, Name: column.Name, After: definition})
+	}
+
+	for _, column := range alter.ModifyColumns {
+		if err := checkColumnDefinition(column); err != nil {
+			return "", nil, err
+		}
+		meta, ok := current[strings.ToLower(column.Name)]
+		if !ok {
+			return "", nil, fmt.Errorf("字段 %s 不存在", column.Name)
+		}
+		if meta.ColumnKey == "PRI" || column.PrimaryKey || column.AutoIncrement {
+			return "", nil, fmt.Errorf("不支持修改主键字段 %s", column.Name)
+		}
+		if err := touch(column.Name); err != nil {
+			return "", nil, err
+		}
+		column.Name = meta.ColumnName
+		before, after := columnSQL(ColumnDefinitionFromMeta(meta)), columnSQL(column)
+		if before == after {
+			continue
+		}
+		columnTypes[strings.ToLower(column.Name)] = strings.ToLower(column.Type)
+		clauses = append(clauses, "MODIFY COLUMN "+after)
+		diffs = append(diffs, ColumnDiff{Action: DiffActionModify, Name: column.Name, Before: before, After: after})
+	}
+
+	for _, name := range alter.DropColumns {
+		meta, ok := current[strings.ToLower(name)]
+		if !ok {
+			return "", nil, fmt.Errorf("字段 %s 不存在", name)
+		}
+		if meta.ColumnKey == "PRI" {
+			return "", nil, fmt.Errorf("不支持删除主键字段 %s", name)
+		}
+		if err := touch(name); err != nil {
+			return "", nil, err
+		}
+		delete(columnTypes, strings.ToLower(name))
+		clauses = append(clauses, "DROP COLUMN "+quoteIdentifier(meta.ColumnName))
+		diffs = append(diffs, ColumnDiff{Action: DiffActionDrop, Name: meta.ColumnName,
+			Before: columnSQL(ColumnDefinitionFromMeta(meta))})
+	}
+
+	names := make(map[string]bool, len(indexes)+len(alter.AddIndexes))
+	for _, index := range indexes {
+		names[strings.ToLower(index.Name)] = true
+	}
+	for _, index := range alter.AddIndexes {
+		if err := checkIndexDefinition(index, columnTypes, names); err != nil {
+			return "", nil, err
+		}
+		keyword := "ADD INDEX"
+		if index.Unique {
+			keyword = "ADD UNIQUE INDEX"
+		}
+		definition := fmt.Sprintf("%s %s", quoteIdentifier(indexName(index)), indexColumnsSQL(index.Columns))
+		clauses = append(clauses, keyword+" "+definition)
+		diffs = append(diffs, ColumnDiff{Action: DiffActionIndex, Name: indexName(index), After: definition})
+	}
+
+	if len(clauses) == 0 {
+		return "", nil, fmt.Errorf("表 %s 没有需要变更的内容", alter.TableName)
+	}
+	return fmt.Sprintf("ALTER TABLE %s\n  %s", quoteIdentifier(alter.TableName), strings.Join(clauses, ",\n  ")), diffs, nil
+}
+
+// ColumnDefinitionFromMeta 将数据库字段元数据转换为字段定义，用于在表结构设计中编辑已有字段
+func ColumnDefinitionFromMeta(meta ColumnMeta) ColumnDefinition {
+	column := ColumnDefinition{
+		Name:          meta.ColumnName,
+		Type:          strings.ToLower(meta.DataType),
+		Unsigned:      strings.Contains(strings.ToLower(meta.ColumnType), "unsigned"),
+		Nullable:      meta.IsNullable == "YES",
+		AutoIncrement: strings.Contains(strings.ToLower(meta.Extra), "auto_increment"),
+		PrimaryKey:    meta.ColumnKey == "PRI",
+		OnUpdate:      strings.Contains(strings.ToLower(meta.Extra), "on update"),
+		Comment:       meta.ColumnComment,
+	}
+
+	if column.Type == "enum" {
+		column.Values = parseEnumValues(meta.ColumnType)
+	} else if start, end := strings.Index(meta.ColumnType, "("), strings.Index(meta.ColumnType, ")"); start > 0 && end > start {
+		size := strings.Split(meta.ColumnType[start+1:end], ",")
+		column.Length, _ = strconv.Atoi(strings.TrimSpace(size[0]))
+		if len(size) > 1 {
+			column.Scale, _ = strconv.Atoi(strings.TrimSpace(size[1]))
+		}
+	}
+
+	if meta.HasDefault {
+		value := meta.ColumnDefault
+		// MariaDB 返回 current_timestamp()，MySQL 返回 CURRENT_TIMESTAMP
+		if strings.EqualFold(strings.TrimSuffix(value, "()"), ddlCurrentTimestamp) {
+			value = ddlCurrentTimestamp
+		}
+		column.Default = &value
+	}
+	return column
+}
+
+// parseEnumValues 解析 enum('a','b') 中的枚举值
+func parseEnumValues(columnType string) []string {
+	start, end := strings.Index(columnType, "("), strings.LastIndex(columnType, ")")
+	if start < 0 || end <= start {
+		return nil
+	}
+	var (
+		values  []string
+		value   strings.Builder
+		quoted  bool
+		content = columnType[start+1 : end]
+	)
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\'' && quoted && i+1 < len(content) && content[i+1] == '\'':
+			value.WriteByte(c)
+			i++
+		case c == '\'':
+			if quoted {
+				values = append(values, value.String())
+				value.Reset()
+			}
+			quoted = !quoted
+		case quoted:
+			value.WriteByte(c)
+		}
+	}
+	return values
+}
+
+// GetTableIndexes 获取指定表的索引（不含主键）
+func GetTableIndexes(tableName string) ([]IndexDefinition, error) {
+	ctx := gctx.New()
+	db := g.DB()
+
+	// 获取当前数据库名称
+	currentDb, err := db.GetValue(ctx, "SELECT DATABASE()")
+	if err != nil {
+		return nil, fmt.Errorf("获取当前数据库名称出错: %v", err)
+	}
+
+	query := `
+        SELECT
+            INDEX_NAME as index_name,
+            NON_UNIQUE as non_unique,
+            COLUMN_NAME as column_name
+        FROM
+            information_schema.STATISTICS
+        WHERE
+            TABLE_SCHEMA = ? AND TABLE_NAME = ? AND INDEX_NAME <> 'PRIMARY'
+        ORDER BY
+            INDEX_NAME ASC, SEQ_IN_INDEX ASC
+    `
+	rows, err := db.GetAll(ctx, query, currentDb.String(), tableName)
+	if err != nil {
+		return nil, fmt.Errorf("查询表 %s 索引信息出错: %v", tableName, err)
+	}
+
+	indexMap := make(map[string]*IndexDefinition)
+	for _, row := range rows {
+		name := row["index_name"].String()
+		index, ok := indexMap[name]
+		if !ok {
+			index = &IndexDefinition{Name: name, Unique: row["non_unique"].Int() == 0}
+			indexMap[name] = index
+		}
+		index.Columns = append(index.Columns, row["column_name"].String())
+	}
+
+	indexes := make([]IndexDefinition, 0, len(indexMap))
+	for _, index := range indexMap {
+		indexes = append(indexes, *index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+	return indexes, nil
+}
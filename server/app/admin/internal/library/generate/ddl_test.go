@@ -0,0 +1,245 @@
+package generate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ptr 返回字符串指针，用于设置字段默认值
+func ptr(value string) *string {
+	return &value
+}
+
+// ddlIdColumn 自增主键字段
+var ddlIdColumn = ColumnDefinition{Name: "id", Type: "bigint", Unsigned: true, PrimaryKey: true, AutoIncrement: true, Comment: "ID"}
+
+func TestCreateTableSQL(t *testing.T) {
+	cases := []struct {
+		name       string
+		definition TableDefinition
+		want       string // 生成的语句
+		err        string // 错误信息包含的内容
+	}{
+		{
+			name: "完整表结构",
+			definition: TableDefinition{
+				TableName: "article",
+				Comment:   "文章'表",
+				Columns: []ColumnDefinition{
+					ddlIdColumn,
+					{Name: "title", Type: "varchar", Length: 128, Default: ptr(""), Comment: "标题"},
+					{Name: "price", Type: "decimal", Length: 10, Scale: 2, Unsigned: true, Default: ptr("0.00")},
+					{Name: "state", Type: "enum", Values: []string{"draft", "it's"}, Default: ptr("draft")},
+					{Name: "content", Type: "text", Nullable: true},
+					{Name: "updated_at", Type: "datetime", Nullable: true, Default: ptr("current_timestamp"), OnUpdate: true},
+				},
+				Indexes: []IndexDefinition{
+					{Columns: []string{"title"}, Unique: true},
+					{Name: "idx_state_price", Columns: []string{"state", "price"}},
+				},
+			},
+			want: "CREATE TABLE `article` (\n" +
+				"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT 'ID',\n" +
+				"  `title` varchar(128) NOT NULL DEFAULT '' COMMENT '标题',\n" +
+				"  `price` decimal(10,2) unsigned NOT NULL DEFAULT 0.00,\n" +
+				"  `state` enum('draft','it''s') NOT NULL DEFAULT 'draft',\n" +
+				"  `content` text NULL,\n" +
+				"  `updated_at` datetime NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  UNIQUE KEY `uk_title` (`title`),\n" +
+				"  KEY `idx_state_price` (`state`,`price`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='文章''表'",
+		},
+		{
+			name: "联合主键",
+			definition: TableDefinition{
+				TableName: "user_role",
+				Columns: []ColumnDefinition{
+					{Name: "user_id", Type: "bigint", PrimaryKey: true},
+					{Name: "role_id", Type: "bigint", PrimaryKey: true},
+				},
+			},
+			want: "CREATE TABLE `user_role` (\n" +
+				"  `user_id` bigint NOT NULL,\n" +
+				"  `role_id` bigint NOT NULL,\n" +
+				"  PRIMARY KEY (`user_id`,`role_id`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		},
+		{name: "表名不合法", definition: TableDefinition{TableName: "1article", Columns: []ColumnDefinition{ddlIdColumn}}, err: "表名 1article 不合法"},
+		{name: "表名注入", definition: TableDefinition{TableName: "a`; DROP TABLE b", Columns: []ColumnDefinition{ddlIdColumn}}, err: "不合法"},
+		{name: "没有字段", definition: TableDefinition{TableName: "a"}, err: "至少需要一个字段"},
+		{name: "没有主键", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{{Name: "name", Type: "varchar", Length: 10}}}, err: "至少需要一个主键"},
+		{name: "字段重复", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "ID", Type: "int"}}}, err: "字段 ID 重复"},
+		{name: "不支持的类型", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "geo", Type: "point"}}}, err: "数据类型 point 不支持"},
+		{name: "varchar 缺少长度", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "name", Type: "varchar"}}}, err: "长度必须在 1-16383 之间"},
+		{name: "decimal 小数位数超过精度", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "price", Type: "decimal", Length: 4, Scale: 5}}}, err: "不能大于精度"},
+		{name: "字符串无符号", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "name", Type: "char", Length: 2, Unsigned: true}}}, err: "不支持无符号"},
+		{name: "非主键自增", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "seq", Type: "int", AutoIncrement: true}}}, err: "只有整数主键才能设置自增"},
+		{name: "主键允许为空", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{{Name: "id", Type: "int", PrimaryKey: true, Nullable: true}}}, err: "不能允许为空"},
+		{name: "text 主键", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{{Name: "id", Type: "text", PrimaryKey: true}}}, err: "不能作为主键"},
+		{name: "date 更新时赋值", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "day", Type: "date", OnUpdate: true}}}, err: "只有 datetime、timestamp"},
+		{name: "整数默认值", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "sort", Type: "int", Default: ptr("abc")}}}, err: "默认值必须为整数"},
+		{name: "不允许为空的默认 NULL", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "sort", Type: "int", Default: ptr("NULL")}}}, err: "默认值不能为 NULL"},
+		{name: "text 默认值", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "body", Type: "text", Default: ptr("")}}}, err: "不能设置默认值"},
+		{name: "枚举默认值", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "state", Type: "enum", Values: []string{"a"}, Default: ptr("b")}}}, err: "必须为枚举值之一"},
+		{name: "多个自增字段", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "seq", Type: "int", PrimaryKey: true, AutoIncrement: true}}}, err: "只能有一个自增字段"},
+		{name: "索引字段不存在", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn}, Indexes: []IndexDefinition{{Columns: []string{"name"}}}}, err: "字段 name 不存在"},
+		{name: "索引名重复", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn}, Indexes: []IndexDefinition{{Columns: []string{"id"}}, {Name: "IDX_ID", Columns: []string{"id"}}}}, err: "索引名 IDX_ID 重复"},
+		{name: "索引名为 PRIMARY", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn}, Indexes: []IndexDefinition{{Name: "primary", Columns: []string{"id"}}}}, err: "重复"},
+		{name: "text 字段索引", definition: TableDefinition{TableName: "a", Columns: []ColumnDefinition{ddlIdColumn, {Name: "body", Type: "text"}}, Indexes: []IndexDefinition{{Columns: []string{"body"}}}}, err: "不能建立索引"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, err := CreateTableSQL(c.definition)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != c.want {
+				t.Errorf("生成的语句:\n%s\n期望:\n%s", sql, c.want)
+			}
+		})
+	}
+}
+
+// ddlCurrentColumns 修改表结构时的当前表字段
+func ddlCurrentColumns() []ColumnMeta {
+	return []ColumnMeta{
+		{ColumnName: "id", DataType: "bigint", ColumnType: "bigint unsigned", IsNullable: "NO", ColumnKey: "PRI", Extra: "auto_increment"},
+		{ColumnName: "title", DataType: "varchar", ColumnType: "varchar(128)", IsNullable: "NO", HasDefault: true, ColumnComment: "标题"},
+		{ColumnName: "content", DataType: "text", ColumnType: "text", IsNullable: "YES"},
+		{ColumnName: "updated_at", DataType: "datetime", ColumnType: "datetime", IsNullable: "YES", HasDefault: true, ColumnDefault: "current_timestamp()", Extra: "on update current_timestamp()"},
+	}
+}
+
+func TestAlterTableSQL(t *testing.T) {
+	indexes := []IndexDefinition{{Name: "idx_title", Columns: []string{"title"}}}
+	cases := []struct {
+		name    string
+		alter   AlterDefinition
+		missing bool // 表不存在，没有字段
+		want    string
+		diffs   []ColumnDiff
+		err     string
+	}{
+		{
+			name: "新增、修改、删除字段与新增索引",
+			alter: AlterDefinition{
+				TableName:     "article",
+				AddColumns:    []ColumnDefinition{{Name: "views", Type: "int", Unsigned: true, Default: ptr("0"), Comment: "浏览量"}},
+				ModifyColumns: []ColumnDefinition{{Name: "TITLE", Type: "varchar", Length: 255, Default: ptr(""), Comment: "标题"}},
+				DropColumns:   []string{"content"},
+				AddIndexes:    []IndexDefinition{{Columns: []string{"views", "title"}, Unique: true}},
+			},
+			want: "ALTER TABLE `article`\n" +
+				"  ADD COLUMN `views` int unsigned NOT NULL DEFAULT 0 COMMENT '浏览量',\n" +
+				"  MODIFY COLUMN `title` varchar(255) NOT NULL DEFAULT '' COMMENT '标题',\n" +
+				"  DROP COLUMN `content`,\n" +
+				"  ADD UNIQUE INDEX `uk_views_title` (`views`,`title`)",
+			diffs: []ColumnDiff{
+				{Action: DiffActionAdd, Name: "views", After: "`views` int unsigned NOT NULL DEFAULT 0 COMMENT '浏览量'"},
+				{Action: DiffActionModify, Name: "title", Before: "`title` varchar(128) NOT NULL DEFAULT '' COMMENT '标题'", After: "`title` varchar(255) NOT NULL DEFAULT '' COMMENT '标题'"},
+				{Action: DiffActionDrop, Name: "content", Before: "`content` text NULL"},
+				{Action: DiffActionIndex, Name: "uk_views_title", After: "`uk_views_title` (`views`,`title`)"},
+			},
+		},
+		{
+			name: "未变化的字段被忽略",
+			alter: AlterDefinition{
+				TableName: "article",
+				ModifyColumns: []ColumnDefinition{
+					{Name: "title", Type: "varchar", Length: 128, Default: ptr(""), Comment: "标题"},
+					{Name: "updated_at", Type: "datetime", Nullable: true, Default: ptr("CURRENT_TIMESTAMP"), OnUpdate: true},
+					{Name: "content", Type: "longtext", Nullable: true},
+				},
+			},
+			want: "ALTER TABLE `article`\n  MODIFY COLUMN `content` longtext NULL",
+			diffs: []ColumnDiff{
+				{Action: DiffActionModify, Name: "content", Before: "`content` text NULL", After: "`content` longtext NULL"},
+			},
+		},
+		{name: "表不存在", alter: AlterDefinition{TableName: "article"}, missing: true, err: "表 article 不存在"},
+		{name: "没有变更", alter: AlterDefinition{TableName: "article", ModifyColumns: []ColumnDefinition{{Name: "content", Type: "text", Nullable: true}}}, err: "没有需要变更的内容"},
+		{name: "新增已存在的字段", alter: AlterDefinition{TableName: "article", AddColumns: []ColumnDefinition{{Name: "Title", Type: "int"}}}, err: "字段 Title 已存在"},
+		{name: "新增主键", alter: AlterDefinition{TableName: "article", AddColumns: []ColumnDefinition{{Name: "uid", Type: "int", PrimaryKey: true}}}, err: "不支持新增主键"},
+		{name: "修改不存在的字段", alter: AlterDefinition{TableName: "article", ModifyColumns: []ColumnDefinition{{Name: "name", Type: "int"}}}, err: "字段 name 不存在"},
+		{name: "修改主键", alter: AlterDefinition{TableName: "article", ModifyColumns: []ColumnDefinition{{Name: "id", Type: "int"}}}, err: "不支持修改主键"},
+		{name: "删除主键", alter: AlterDefinition{TableName: "article", DropColumns: []string{"id"}}, err: "不支持删除主键"},
+		{name: "删除不存在的字段", alter: AlterDefinition{TableName: "article", DropColumns: []string{"name"}}, err: "字段 name 不存在"},
+		{name: "修改并删除同一字段", alter: AlterDefinition{TableName: "article", ModifyColumns: []ColumnDefinition{{Name: "content", Type: "longtext"}}, DropColumns: []string{"CONTENT"}}, err: "重复出现"},
+		{name: "索引名已存在", alter: AlterDefinition{TableName: "article", AddIndexes: []IndexDefinition{{Columns: []string{"title"}}}}, err: "索引名 idx_title 重复"},
+		{name: "索引已删除的字段", alter: AlterDefinition{TableName: "article", DropColumns: []string{"title"}, AddIndexes: []IndexDefinition{{Columns: []string{"title"}, Unique: true}}}, err: "字段 title 不存在"},
+		{name: "修改为 text 后建立索引", alter: AlterDefinition{TableName: "article", ModifyColumns: []ColumnDefinition{{Name: "title", Type: "text"}}, AddIndexes: []IndexDefinition{{Name: "idx_t", Columns: []string{"title"}}}}, err: "不能建立索引"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			columns := ddlCurrentColumns()
+			if c.missing {
+				columns = nil
+			}
+			sql, diffs, err := AlterTableSQL(c.alter, columns, indexes)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != c.want {
+				t.Errorf("生成的语句:\n%s\n期望:\n%s", sql, c.want)
+			}
+			if !reflect.DeepEqual(diffs, c.diffs) {
+				t.Errorf("变更差异 = %+v\n期望 %+v", diffs, c.diffs)
+			}
+		})
+	}
+}
+
+func TestColumnDefinitionFromMeta(t *testing.T) {
+	cases := []struct {
+		name string
+		meta ColumnMeta
+		want ColumnDefinition
+	}{
+		{
+			name: "无符号自增主键",
+			meta: ColumnMeta{ColumnName: "id", DataType: "BIGINT", ColumnType: "bigint(20) unsigned", IsNullable: "NO", ColumnKey: "PRI", Extra: "auto_increment"},
+			want: ColumnDefinition{Name: "id", Type: "bigint", Length: 20, Unsigned: true, PrimaryKey: true, AutoIncrement: true},
+		},
+		{
+			name: "decimal 精度与默认值",
+			meta: ColumnMeta{ColumnName: "price", DataType: "decimal", ColumnType: "decimal(10, 2)", IsNullable: "NO", HasDefault: true, ColumnDefault: "0.00", ColumnComment: "价格"},
+			want: ColumnDefinition{Name: "price", Type: "decimal", Length: 10, Scale: 2, Default: ptr("0.00"), Comment: "价格"},
+		},
+		{
+			name: "枚举值含引号与逗号",
+			meta: ColumnMeta{ColumnName: "state", DataType: "enum", ColumnType: "enum('a,b','it''s','')", IsNullable: "YES"},
+			want: ColumnDefinition{Name: "state", Type: "enum", Nullable: true, Values: []string{"a,b", "it's", ""}},
+		},
+		{
+			name: "MariaDB 当前时间默认值",
+			meta: ColumnMeta{ColumnName: "updated_at", DataType: "timestamp", ColumnType: "timestamp", IsNullable: "YES", HasDefault: true, ColumnDefault: "current_timestamp()", Extra: "on update current_timestamp()"},
+			want: ColumnDefinition{Name: "updated_at", Type: "timestamp", Nullable: true, Default: ptr("CURRENT_TIMESTAMP"), OnUpdate: true},
+		},
+		{
+			name: "默认值为空字符串",
+			meta: ColumnMeta{ColumnName: "title", DataType: "varchar", ColumnType: "varchar(64)", IsNullable: "NO", HasDefault: true},
+			want: ColumnDefinition{Name: "title", Type: "varchar", Length: 64, Default: ptr("")},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ColumnDefinitionFromMeta(c.meta); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("ColumnDefinitionFromMeta = %+v\n期望 %+v", got, c.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"server/app/admin/internal/consts"
 	"strings"
+
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
+// DefaultTreeParent 未配置树表父级字段时自动识别的字段名
+const DefaultTreeParent = "parent_id"
+
 // GenerateOptionsToString 将生成选项转换为字符串
 func GenerateOptionsToString(options *consts.GenerateOptions) string {
 	var optionList []string
@@ -26,6 +31,12 @@ func GenerateOptionsToString(options *consts.GenerateOptions) string {
 	if options.List {
 		optionList = append(optionList, consts.OptionList)
 	}
+	if options.Export {
+		optionList = append(optionList, consts.OptionExport)
+	}
+	if options.Import {
+		optionList = append(optionList, consts.OptionImport)
+	}
 
 	return strings.Join(optionList, ",")
 }
@@ -55,6 +66,14 @@ func ParseGenerateOptionsFromConfig(config map[string]interface{}) *consts.Gener
 		options.BatchDelete = hasBatchDelete
 	}
 
+	if hasExport, ok := config["hasExport"].(bool); ok {
+		options.Export = hasExport
+	}
+
+	if hasImport, ok := config["hasImport"].(bool); ok {
+		options.Import = hasImport
+	}
+
 	// 从配置中读取菜单相关选项
 	if menuIcon, ok := config["menuIcon"].(string); ok {
 		options.MenuIcon = menuIcon
@@ -64,8 +83,46 @@ func ParseGenerateOptionsFromConfig(config map[string]interface{}) *consts.Gener
 		options.MenuName = menuName
 	}
 
-	if parentMenuId, ok := config["parentMenuId"].(int); ok {
-		options.ParentMenuId = parentMenuId
+	// JSON 数字解码为 float64，使用 gconv 统一转换
+	if parentMenuId, ok := config["parentMenuId"]; ok && parentMenuId != nil {
+		options.ParentMenuId = gconv.Int(parentMenuId)
+	}
+
+	// 授予按钮权限的角色
+	if roleIds, ok := config["roleIds"]; ok && roleIds != nil {
+		options.RoleIds = gconv.Uint64s(roleIds)
+	}
+
+	// 代码生成使用的模板集
+	if templateSet, ok := config["templateSet"].(string); ok {
+		options.TemplateSet = templateSet
+	}
+
+	// 树表设置，未配置父级字段时按 parent_id 自动识别，配置为空字符串时生成普通表
+	options.TreeParent = DefaultTreeParent
+	if treeParent, ok := config["treeParent"].(string); ok {
+		options.TreeParent = treeParent
+	}
+
+	if treeLabel, ok := config["treeLabel"].(string); ok {
+		options.TreeLabel = treeLabel
+	}
+
+	// 关联表配置（忽略未选择表名的空配置）
+	if joinTables, ok := config["joinTables"].([]interface{}); ok {
+		for _, item := range joinTables {
+			var join consts.JoinTableConfig
+			if err := gconv.Struct(item, &join); err != nil || join.TableName == "" {
+				continue
+			}
+			if join.Relation == "" {
+				join.Relation = consts.RelationManyToOne
+			}
+			if join.JoinType == "" {
+				join.JoinType = "LEFT"
+			}
+			options.JoinTables = append(options.JoinTables, join)
+		}
 	}
 
 	return options
@@ -0,0 +1,66 @@
+package generate
+
+import (
+	"server/app/admin/internal/consts"
+)
+
+// ButtonPermission 按钮权限配置
+type ButtonPermission struct {
+	Option string `json:"option"` // 对应的生成选项
+	Title  string `json:"title"`  // 按钮名称
+	Auth   string `json:"auth"`   // 权限标识
+}
+
+// buttonTitles 生成选项对应的按钮名称（按菜单中的展示顺序排列）
+var buttonTitles = []struct {
+	Option string
+	Title  string
+}{
+	{consts.OptionList, "查询"},
+	{consts.OptionCreate, "新增"},
+	{consts.OptionUpdate, "修改"},
+	{consts.OptionDelete, "删除"},
+	{consts.OptionBatchDelete, "批量删除"},
+	{consts.OptionExport, "导出"},
+	{consts.OptionImport, "导入"},
+}
+
+// AuthCode 生成按钮权限标识，格式为 模块名:btn:操作
+func AuthCode(moduleName, option string) string {
+	return moduleName + ":btn:" + option
+}
+
+// GetAuthCodes 返回模块所有操作的权限标识（键为生成选项）
+func GetAuthCodes(moduleName string) map[string]string {
+	codes := make(map[string]string, len(buttonTitles))
+	for _, item := range buttonTitles {
+		codes[item.Option] = AuthCode(moduleName, item.Option)
+	}
+	return codes
+}
+
+// GetButtonPermissions 返回已启用操作对应的按钮权限
+func GetButtonPermissions(moduleName string, options *consts.GenerateOptions) []ButtonPermission {
+	enabled := map[string]bool{
+		consts.OptionList:        options.List,
+		consts.OptionCreate:      options.Create,
+		consts.OptionUpdate:      options.Update,
+		consts.OptionDelete:      options.Delete,
+		consts.OptionBatchDelete: options.BatchDelete,
+		consts.OptionExport:      options.Export,
+		consts.OptionImport:      options.Import,
+	}
+
+	permissions := make([]ButtonPermission, 0, len(buttonTitles))
+	for _, item := range buttonTitles {
+		if !enabled[item.Option] {
+			continue
+		}
+		permissions = append(permissions, ButtonPermission{
+			Option: item.Option,
+			Title:  item.Title,
+			Auth:   AuthCode(moduleName, item.Option),
+		})
+	}
+	return permissions
+}
@@ -28,10 +28,16 @@ type ColumnMeta struct {
 	ColumnType      string `json:"column_type"`      // 完整字段类型
 	IsNullable      string `json:"is_nullable"`      // 是否可为空
 	ColumnDefault   string `json:"column_default"`   // 默认值
+	HasDefault      bool   `json:"has_default"`      // 是否设置了默认值（区分未设置与默认空字符串）
 	ColumnComment   string `json:"column_comment"`   // 字段注释
 	ColumnKey       string `json:"column_key"`       // 键类型(PRI, UNI, MUL)
 	Extra           string `json:"extra"`            // 额外信息(auto_increment等)
 	OrdinalPosition int    `json:"ordinal_position"` // 字段位置
+
+	// 以下字段仅 GetTableColumnsWithDetail 返回
+	CharacterMaximumLength int `json:"character_maximum_length"` // 字符最大长度
+	NumericPrecision       int `json:"numeric_precision"`        // 数字精度
+	NumericScale           int `json:"numeric_scale"`            // 小数位数
 }
 
 // GetTablesMeta 获取表元数据信息(支持分页)
@@ -130,6 +136,7 @@ func GetTableColumns(tableName string) ([]ColumnMeta, error) {
             COLUMN_TYPE as column_type,
             IS_NULLABLE as is_nullable,
             IFNULL(COLUMN_DEFAULT, '') as column_default,
+            COLUMN_DEFAULT IS NOT NULL as has_default,
             IFNULL(COLUMN_COMMENT, '') as column_comment,
             IFNULL(COLUMN_KEY, '') as column_key,
             IFNULL(EXTRA, '') as extra,
@@ -155,6 +162,7 @@ func GetTableColumns(tableName string) ([]ColumnMeta, error) {
 			ColumnType:      row["column_type"].String(),
 			IsNullable:      row["is_nullable"].String(),
 			ColumnDefault:   row["column_default"].String(),
+			HasDefault:      row["has_default"].Bool(),
 			ColumnComment:   row["column_comment"].String(),
 			ColumnKey:       row["column_key"].String(),
 			Extra:           row["extra"].String(),
@@ -186,6 +194,7 @@ func GetTableColumnsWithDetail(tableName string) ([]ColumnMeta, error) {
             c.COLUMN_TYPE as column_type,
             c.IS_NULLABLE as is_nullable,
             IFNULL(c.COLUMN_DEFAULT, '') as column_default,
+            c.COLUMN_DEFAULT IS NOT NULL as has_default,
             IFNULL(c.COLUMN_COMMENT, '') as column_comment,
             IFNULL(c.COLUMN_KEY, '') as column_key,
             IFNULL(c.EXTRA, '') as extra,
@@ -209,15 +218,19 @@ func GetTableColumnsWithDetail(tableName string) ([]ColumnMeta, error) {
 	// 处理查询结果
 	for _, row := range rows {
 		columnMeta := ColumnMeta{
-			ColumnName:      row["column_name"].String(),
-			DataType:        row["data_type"].String(),
-			ColumnType:      row["column_type"].String(),
-			IsNullable:      row["is_nullable"].String(),
-			ColumnDefault:   row["column_default"].String(),
-			ColumnComment:   row["column_comment"].String(),
-			ColumnKey:       row["column_key"].String(),
-			Extra:           row["extra"].String(),
-			OrdinalPosition: row["ordinal_position"].Int(),
+			ColumnName:             row["column_name"].String(),
+			DataType:               row["data_type"].String(),
+			ColumnType:             row["column_type"].String(),
+			IsNullable:             row["is_nullable"].String(),
+			ColumnDefault:          row["column_default"].String(),
+			HasDefault:             row["has_default"].Bool(),
+			ColumnComment:          row["column_comment"].String(),
+			ColumnKey:              row["column_key"].String(),
+			Extra:                  row["extra"].String(),
+			OrdinalPosition:        row["ordinal_position"].Int(),
+			CharacterMaximumLength: row["character_maximum_length"].Int(),
+			NumericPrecision:       row["numeric_precision"].Int(),
+			NumericScale:           row["numeric_scale"].Int(),
 		}
 		columns = append(columns, columnMeta)
 	}
@@ -251,6 +264,31 @@ func ValidateTableExists(tableName string) (bool, error) {
 	return count.Int() > 0, nil
 }
 
+// GetTableComment 获取指定表的注释
+func GetTableComment(tableName string) (string, error) {
+	ctx := gctx.New()
+	db := g.DB()
+
+	// 获取当前数据库名称
+	currentDb, err := db.GetValue(ctx, "SELECT DATABASE()")
+	if err != nil {
+		return "", fmt.Errorf("获取当前数据库名称出错: %v", err)
+	}
+
+	query := `
+        SELECT IFNULL(TABLE_COMMENT, '') as table_comment
+        FROM information_schema.TABLES
+        WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+    `
+
+	comment, err := db.GetValue(ctx, query, currentDb.String(), tableName)
+	if err != nil {
+		return "", fmt.Errorf("查询表 %s 注释时出错: %v", tableName, err)
+	}
+
+	return comment.String(), nil
+}
+
 // GetAllTablesWithFilteredColumns 获取所有表和过滤后的字段信息
 func GetAllTablesWithFilteredColumns() ([]TableWithColumnsInfo, error) {
 	ctx := gctx.New()
@@ -310,6 +348,7 @@ func GetAllTablesWithFilteredColumns() ([]TableWithColumnsInfo, error) {
                 COLUMN_TYPE as column_type,
                 IS_NULLABLE as is_nullable,
                 IFNULL(COLUMN_DEFAULT, '') as column_default,
+                COLUMN_DEFAULT IS NOT NULL as has_default,
                 IFNULL(COLUMN_COMMENT, '') as column_comment,
                 IFNULL(COLUMN_KEY, '') as column_key,
                 IFNULL(EXTRA, '') as extra
@@ -0,0 +1,217 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Orientation 读取 JPEG 中 EXIF 记录的图片方向（1~8），没有记录时返回 1
+func Orientation(data []byte) int {
+	for _, segment := range jpegSegments(data) {
+		if segment.marker != 0xe1 || !bytes.HasPrefix(segment.payload, []byte("Exif\x00\x00")) {
+			continue
+		}
+		if orientation := exifOrientation(segment.payload[6:]); orientation >= 1 && orientation <= 8 {
+			return orientation
+		}
+	}
+	return 1
+}
+
+// exifOrientation 从 TIFF 结构的 IFD0 中读取方向标签（0x0112）
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// jpegSegment JPEG 文件中图像数据之前的段
+type jpegSegment struct {
+	marker  byte
+	start   int // 段在文件中的起始位置（含 0xff 标记）
+	end     int // 段在文件中的结束位置
+	payload []byte
+}
+
+// jpegSegments 解析 JPEG 文件中扫描数据（SOS）之前的段，不是 JPEG 或格式不正确时返回已解析的部分
+func jpegSegments(data []byte) []jpegSegment {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil
+	}
+	var segments []jpegSegment
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xff {
+			return segments
+		}
+		marker := data[pos+1]
+		if marker == 0xff { // 填充字节
+			pos++
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return segments
+		}
+		segments = append(segments, jpegSegment{
+			marker:  marker,
+			start:   pos,
+			end:     pos + 2 + length,
+			payload: data[pos+4 : pos+2+length],
+		})
+		if marker == 0xda { // SOS 之后是压缩的图像数据
+			return segments
+		}
+		pos += 2 + length
+	}
+	return segments
+}
+
+// StripMetadata 去除图片中的 EXIF、XMP、注释与文本等元数据，不重新编码图片
+// JPEG 保留 JFIF（APP0）、ICC 色彩配置（APP2）与 Adobe（APP14）段，EXIF 仅保留图片方向，避免去除后显示方向错误
+// PNG 去除 eXIf、tEXt、zTXt、iTXt、tIME 块，WebP 去除 EXIF、XMP 块，其他格式或无法解析时原样返回
+func StripMetadata(data []byte) []byte {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		return stripJPEG(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return stripPNG(data)
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return stripWebP(data)
+	default:
+		return data
+	}
+}
+
+// stripJPEG 去除 JPEG 中的元数据段
+func stripJPEG(data []byte) []byte {
+	segments := jpegSegments(data)
+	if len(segments) == 0 || segments[len(segments)-1].marker != 0xda {
+		return data
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)))
+	buf.Write(data[:2])
+	// 只包含方向的 EXIF 段放在 JFIF 段之后，没有 JFIF 段时紧跟 SOI
+	if segments[0].marker == 0xe0 {
+		buf.Write(data[segments[0].start:segments[0].end])
+		segments = segments[1:]
+	}
+	if orientation := Orientation(data); orientation > 1 {
+		buf.Write(orientationSegment(orientation))
+	}
+	for _, segment := range segments {
+		isMetadata := segment.marker == 0xfe || // COM
+			segment.marker >= 0xe1 && segment.marker <= 0xef && segment.marker != 0xe2 && segment.marker != 0xee
+		if !isMetadata {
+			buf.Write(data[segment.start:segment.end])
+		}
+	}
+	buf.Write(data[segments[len(segments)-1].end:])
+	return buf.Bytes()
+}
+
+// orientationSegment 只包含图片方向的 EXIF 段（APP1）
+func orientationSegment(orientation int) []byte {
+	return []byte{
+		0xff, 0xe1, 0x00, 0x22, // APP1，长度 34
+		'E', 'x', 'i', 'f', 0x00, 0x00,
+		'M', 'M', 0x00, 0x2a, 0x00, 0x00, 0x00, 0x08, // TIFF 头，IFD0 偏移 8
+		0x00, 0x01, // 1 个标签
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, byte(orientation), 0x00, 0x00, // 方向，SHORT
+		0x00, 0x00, 0x00, 0x00, // 没有下一个 IFD
+	}
+}
+
+// pngSignature PNG 文件签名
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// stripPNG 去除 PNG 中的元数据块
+func stripPNG(data []byte) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)))
+	buf.Write(pngSignature)
+	for pos := len(pngSignature); pos < len(data); {
+		if pos+12 > len(data) {
+			return data
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return data
+		}
+		switch string(data[pos+4 : pos+8]) {
+		case "eXIf", "tEXt", "zTXt", "iTXt", "tIME":
+		default:
+			buf.Write(data[pos:end])
+		}
+		pos = end
+	}
+	return buf.Bytes()
+}
+
+// WebP 扩展格式（VP8X 块）标志位中表示包含 EXIF、XMP 块的位
+const (
+	webpFlagExif = 0x08
+	webpFlagXmp  = 0x04
+)
+
+// stripWebP 去除 WebP 中的 EXIF、XMP 块，同时清除 VP8X 块中对应的标志位并更新 RIFF 文件大小
+func stripWebP(data []byte) []byte {
+	riffEnd := int(binary.LittleEndian.Uint32(data[4:])) + 8
+	if riffEnd < 12 || riffEnd > len(data) {
+		return data
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, riffEnd))
+	buf.Write(data[:12])
+	vp8x := -1
+	for pos := 12; pos < riffEnd; {
+		if pos+8 > riffEnd {
+			return data
+		}
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + size + size%2 // 块大小为奇数时有一个填充字节
+		if size < 0 || end > riffEnd {
+			return data
+		}
+		switch string(data[pos : pos+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			if size < 10 {
+				return data
+			}
+			vp8x = buf.Len()
+			buf.Write(data[pos:end])
+		default:
+			buf.Write(data[pos:end])
+		}
+		pos = end
+	}
+	out := buf.Bytes()
+	if vp8x >= 0 {
+		out[vp8x+8] &^= webpFlagExif | webpFlagXmp
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out
+}
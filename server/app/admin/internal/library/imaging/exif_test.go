@@ -0,0 +1,235 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testImage 左半部分为红色、右半部分为蓝色的图片
+func testImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.NRGBA{B: 255, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+// segment 构造 JPEG 段
+func segment(marker byte, payload []byte) []byte {
+	length := len(payload) + 2
+	return append([]byte{0xff, marker, byte(length >> 8), byte(length)}, payload...)
+}
+
+// byteOrder EXIF 使用的字节序
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+// exifPayload 构造包含方向标签与拍摄位置文本的 EXIF 段内容
+func exifPayload(order byteOrder, orientation int) []byte {
+	tiff := make([]byte, 8, 64)
+	if order == byteOrder(binary.LittleEndian) {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	// IFD0：软件名称与方向两个标签
+	tiff = order.AppendUint16(tiff, 2)
+	tiff = order.AppendUint16(tiff, 0x0131)
+	tiff = order.AppendUint16(tiff, 2)
+	tiff = order.AppendUint32(tiff, 4)
+	tiff = append(tiff, 'G', 'P', 'S', 0)
+	tiff = order.AppendUint16(tiff, 0x0112)
+	tiff = order.AppendUint16(tiff, 3)
+	tiff = order.AppendUint32(tiff, 1)
+	tiff = order.AppendUint16(tiff, uint16(orientation))
+	tiff = order.AppendUint16(tiff, 0)
+	tiff = order.AppendUint32(tiff, 0)
+	return append([]byte("Exif\x00\x00"), append(tiff, "GPS 31.2304N 121.4737E"...)...)
+}
+
+// testJPEG 编码测试图片，并在 SOI 之后插入指定的段
+func testJPEG(t *testing.T, segments ...[]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(16, 8), &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), bytes.Join(segments, nil)...), data[2:]...)
+}
+
+// pngChunk 构造 PNG 块
+func pngChunk(kind string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(append(chunk, kind...), data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// webpChunk 构造 WebP 块，大小为奇数时补一个填充字节
+func webpChunk(kind string, data []byte) []byte {
+	chunk := binary.LittleEndian.AppendUint32([]byte(kind), uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// riff 构造 WebP 文件
+func riff(chunks ...[]byte) []byte {
+	body := append([]byte("WEBP"), bytes.Join(chunks, nil)...)
+	return append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body))), body...)
+}
+
+// vp8x 构造扩展格式块，画布大小为 16x8
+func vp8x(flags byte) []byte {
+	return webpChunk("VP8X", []byte{flags, 0, 0, 0, 15, 0, 0, 7, 0, 0})
+}
+
+func TestOrientation(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "大端序", data: testJPEG(t, segment(0xe1, exifPayload(binary.BigEndian, 6))), want: 6},
+		{name: "小端序", data: testJPEG(t, segment(0xe1, exifPayload(binary.LittleEndian, 8))), want: 8},
+		{name: "JFIF 之后的 EXIF", data: testJPEG(t, segment(0xe0, []byte("JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00")), segment(0xe1, exifPayload(binary.BigEndian, 3))), want: 3},
+		{name: "只包含方向的 EXIF 段", data: testJPEG(t, orientationSegment(5)), want: 5},
+		{name: "没有 EXIF", data: testJPEG(t), want: 1},
+		{name: "方向值不合法", data: testJPEG(t, segment(0xe1, exifPayload(binary.BigEndian, 9))), want: 1},
+		{name: "XMP 段", data: testJPEG(t, segment(0xe1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>"))), want: 1},
+		{name: "EXIF 被截断", data: testJPEG(t, segment(0xe1, exifPayload(binary.BigEndian, 6)[:20])), want: 1},
+		{name: "不是 JPEG", data: []byte("GIF89a"), want: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Orientation(c.data); got != c.want {
+				t.Errorf("Orientation = %d，期望 %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestStripMetadata(t *testing.T) {
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, testImage(16, 8)); err != nil {
+		t.Fatal(err)
+	}
+	cleanPNG := pngBuf.Bytes()
+	ihdrEnd := len(pngSignature) + 12 + 13
+	withText := bytes.Join([][]byte{
+		cleanPNG[:ihdrEnd],
+		pngChunk("tEXt", []byte("Author\x00someone")),
+		pngChunk("eXIf", exifPayload(binary.BigEndian, 1)[6:]),
+		pngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>")),
+		pngChunk("zTXt", []byte("Comment\x00\x00x")),
+		pngChunk("tIME", []byte{0x07, 0xe8, 1, 1, 0, 0, 0}),
+		cleanPNG[ihdrEnd:],
+	}, nil)
+
+	var webpBuf bytes.Buffer
+	if err := EncodeWebP(&webpBuf, testImage(16, 8)); err != nil {
+		t.Fatal(err)
+	}
+	vp8l := webpBuf.Bytes()[12:]
+	iccp := webpChunk("ICCP", []byte("icc"))
+
+	app0 := segment(0xe0, []byte("JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00"))
+	icc := segment(0xe2, []byte("ICC_PROFILE\x00\x01\x01profile"))
+	adobe := segment(0xee, []byte("Adobe\x00\x64\x00\x00\x00\x00\x01"))
+	exif6 := segment(0xe1, exifPayload(binary.BigEndian, 6))
+	exif1 := segment(0xe1, exifPayload(binary.LittleEndian, 1))
+	xmp := segment(0xe1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>"))
+	comment := segment(0xfe, []byte("GPS comment"))
+	iptc := segment(0xed, []byte("Photoshop 3.0\x00GPS"))
+
+	cases := []struct {
+		name string
+		data []byte
+		want []byte
+	}{
+		{
+			name: "JPEG 保留方向、JFIF、ICC 与 Adobe 段",
+			data: testJPEG(t, app0, exif6, xmp, icc, comment, iptc, adobe),
+			want: testJPEG(t, app0, orientationSegment(6), icc, adobe),
+		},
+		{
+			name: "JPEG 没有 JFIF 时方向段紧跟 SOI",
+			data: testJPEG(t, xmp, exif6),
+			want: testJPEG(t, orientationSegment(6)),
+		},
+		{
+			name: "JPEG 默认方向时不保留 EXIF",
+			data: testJPEG(t, app0, exif1, comment),
+			want: testJPEG(t, app0),
+		},
+		{name: "JPEG 没有元数据", data: testJPEG(t, app0), want: testJPEG(t, app0)},
+		{name: "JPEG 缺少扫描数据时原样返回", data: testJPEG(t, exif6)[:40], want: testJPEG(t, exif6)[:40]},
+		{name: "PNG 去除文本与 EXIF 块", data: withText, want: cleanPNG},
+		{name: "PNG 块被截断时原样返回", data: withText[:len(withText)-5], want: withText[:len(withText)-5]},
+		{
+			name: "WebP 去除 EXIF、XMP 块并清除标志位",
+			data: riff(vp8x(webpFlagExif|webpFlagXmp|0x20), iccp, vp8l, webpChunk("EXIF", exifPayload(binary.BigEndian, 1)[6:]), webpChunk("XMP ", []byte("<x:xmpmeta/>"))),
+			want: riff(vp8x(0x20), iccp, vp8l),
+		},
+		{name: "WebP 简单格式", data: riff(vp8l), want: riff(vp8l)},
+		{name: "WebP 文件大小不合法时原样返回", data: append([]byte("RIFF\xff\xff\xff\x7fWEBP"), vp8l...), want: append([]byte("RIFF\xff\xff\xff\x7fWEBP"), vp8l...)},
+		{name: "其他格式原样返回", data: []byte("GIF89a\x01\x00"), want: []byte("GIF89a\x01\x00")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := StripMetadata(c.data)
+			if !bytes.Equal(got, c.want) {
+				t.Fatalf("StripMetadata 结果与期望不一致\n得到 %q\n期望 %q", got, c.want)
+			}
+			if bytes.Contains(got, []byte("GPS")) && !bytes.Equal(got, c.data) {
+				t.Error("去除元数据后仍包含拍摄位置")
+			}
+		})
+	}
+}
+
+// TestStripMetadataDecodable 去除元数据后的图片可以正常解码，JPEG 的方向不变
+func TestStripMetadataDecodable(t *testing.T) {
+	var webpBuf bytes.Buffer
+	if err := EncodeWebP(&webpBuf, testImage(16, 8)); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name   string
+		data   []byte
+		width  int
+		height int
+	}{
+		{name: "JPEG", data: testJPEG(t, segment(0xe1, exifPayload(binary.BigEndian, 6))), width: 8, height: 16},
+		{name: "WebP", data: riff(vp8x(webpFlagExif), webpBuf.Bytes()[12:], webpChunk("EXIF", []byte("Exif"))), width: 16, height: 8},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			img, _, err := Decode(StripMetadata(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bounds := img.Bounds(); bounds.Dx() != c.width || bounds.Dy() != c.height {
+				t.Errorf("解码后尺寸 %dx%d，期望 %dx%d", bounds.Dx(), bounds.Dy(), c.width, c.height)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ModeCrop = "crop" // 按比例缩放后居中裁剪为指定宽高
+	ModeFit  = "fit"  // 按比例缩放到指定宽高以内，宽或高为 0 时不限制
+
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+
+	MaxPixels      = 50_000_000 // 解码的最大像素数，避免解压炸弹占满内存
+	DefaultQuality = 85         // JPEG 默认质量
+)
+
+// Variant 图片规格
+type Variant struct {
+	Name    string `json:"name"`    // 规格名称，用于存储路径，如 thumb
+	Width   int    `json:"width"`   // 宽度
+	Height  int    `json:"height"`  // 高度
+	Mode    string `json:"mode"`    // 缩放方式：crop、fit，默认 fit
+	Format  string `json:"format"`  // 输出格式：jpeg、png、webp，为空时 JPEG 保持 JPEG，其他格式输出 PNG
+	Quality int    `json:"quality"` // JPEG 质量 1~100，默认 85
+}
+
+// DefaultVariants 默认图片规格：200x200 裁剪的缩略图、宽 800 的预览图与 WebP 格式的缩略图（WebP 为无损编码，体积较大，不适合大尺寸规格）
+var DefaultVariants = []Variant{
+	{Name: "thumb", Width: 200, Height: 200, Mode: ModeCrop},
+	{Name: "w800", Width: 800, Mode: ModeFit},
+	{Name: "thumb-webp", Width: 200, Height: 200, Mode: ModeCrop, Format: FormatWebP},
+}
+
+// Validate 校验图片规格
+func (v Variant) Validate() error {
+	if v.Name == "" || strings.ContainsAny(v.Name, "/\\.") {
+		return fmt.Errorf("图片规格名称不合法: %q", v.Name)
+	}
+	if v.Width < 0 || v.Height < 0 || v.Width == 0 && v.Height == 0 {
+		return fmt.Errorf("图片规格 %s 的宽高不合法", v.Name)
+	}
+	switch v.Mode {
+	case "", ModeFit:
+	case ModeCrop:
+		if v.Width == 0 || v.Height == 0 {
+			return fmt.Errorf("图片规格 %s 裁剪时需要同时指定宽高", v.Name)
+		}
+	default:
+		return fmt.Errorf("图片规格 %s 的缩放方式不支持: %s", v.Name, v.Mode)
+	}
+	switch v.Format {
+	case "", FormatJPEG, FormatPNG, FormatWebP:
+	default:
+		return fmt.Errorf("图片规格 %s 的输出格式不支持: %s", v.Name, v.Format)
+	}
+	return nil
+}
+
+// OutputFormat 图片规格的输出格式，source 为原图格式
+func (v Variant) OutputFormat(source string) string {
+	if v.Format != "" {
+		return v.Format
+	}
+	if source == FormatJPEG {
+		return FormatJPEG
+	}
+	return FormatPNG
+}
+
+// Decode 解码图片，并按 EXIF 中记录的方向旋转，返回图片与格式名称（jpeg、png、gif、webp）
+func Decode(data []byte) (image.Image, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("解析图片失败: %v", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, "", fmt.Errorf("图片尺寸不支持: %dx%d", config.Width, config.Height)
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("解码图片失败: %v", err)
+	}
+	if format == FormatJPEG {
+		img = orient(img, Orientation(data))
+	}
+	return img, format, nil
+}
+
+// Resize 按图片规格缩放图片，不会放大图片
+func Resize(img image.Image, variant Variant) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	src := bounds
+
+	var dstWidth, dstHeight int
+	if variant.Mode == ModeCrop {
+		// 从原图中心截取与目标宽高比一致的区域
+		dstWidth, dstHeight = variant.Width, variant.Height
+		if width*dstHeight > height*dstWidth {
+			cropWidth := height * dstWidth / dstHeight
+			src.Min.X += (width - cropWidth) / 2
+			src.Max.X = src.Min.X + cropWidth
+		} else {
+			cropHeight := width * dstHeight / dstWidth
+			src.Min.Y += (height - cropHeight) / 2
+			src.Max.Y = src.Min.Y + cropHeight
+		}
+		if dstWidth > src.Dx() || dstHeight > src.Dy() {
+			dstWidth, dstHeight = src.Dx(), src.Dy()
+		}
+	} else {
+		scale := 1.0
+		if variant.Width > 0 && float64(variant.Width)/float64(width) < scale {
+			scale = float64(variant.Width) / float64(width)
+		}
+		if variant.Height > 0 && float64(variant.Height)/float64(height) < scale {
+			scale = float64(variant.Height) / float64(height)
+		}
+		dstWidth, dstHeight = max(1, int(float64(width)*scale+0.5)), max(1, int(float64(height)*scale+0.5))
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	if dstWidth == src.Dx() && dstHeight == src.Dy() {
+		draw.Draw(dst, dst.Bounds(), img, src.Min, draw.Src)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Src, nil)
+	}
+	return dst
+}
+
+// Encode 按格式编码图片，返回内容与 Content-Type
+func Encode(img image.Image, format string, quality int) ([]byte, string, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	switch format {
+	case FormatJPEG:
+		if quality <= 0 || quality > 100 {
+			quality = DefaultQuality
+		}
+		err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+	case FormatWebP:
+		err = EncodeWebP(&buf, img)
+	default:
+		return nil, "", fmt.Errorf("不支持的图片格式: %s", format)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("编码图片失败: %v", err)
+	}
+	return buf.Bytes(), ContentType(format), nil
+}
+
+// ContentType 图片格式对应的 Content-Type
+func ContentType(format string) string {
+	return "image/" + format
+}
+
+// Extension 图片格式对应的扩展名
+func Extension(format string) string {
+	if format == FormatJPEG {
+		return ".jpg"
+	}
+	return "." + format
+}
+
+// flatten 将带透明度的图片合成到白色背景上，JPEG 不支持透明度
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+	return dst
+}
+
+// orient 按 EXIF 方向（1~8）旋转或翻转图片
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if orientation >= 5 { // 5~8 需要交换宽高
+		width, height = height, width
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			dx, dy := x, y
+			switch orientation {
+			case 2: // 水平翻转
+				dx = width - 1 - x
+			case 3: // 旋转 180 度
+				dx, dy = width-1-x, height-1-y
+			case 4: // 垂直翻转
+				dy = height - 1 - y
+			case 5: // 沿左上-右下对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转 90 度
+				dx, dy = width-1-y, x
+			case 7: // 沿右上-左下对角线翻转
+				dx, dy = width-1-y, height-1-x
+			case 8: // 逆时针旋转 90 度
+				dx, dy = y, height-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,250 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+)
+
+func TestVariantValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		variant Variant
+		err     string
+	}{
+		{name: "裁剪", variant: Variant{Name: "thumb", Width: 200, Height: 200, Mode: ModeCrop}},
+		{name: "只限制宽度", variant: Variant{Name: "w800", Width: 800}},
+		{name: "只限制高度", variant: Variant{Name: "h600", Height: 600, Mode: ModeFit, Format: FormatWebP}},
+		{name: "名称为空", variant: Variant{Width: 100}, err: "名称不合法"},
+		{name: "名称包含路径", variant: Variant{Name: "../thumb", Width: 100}, err: "名称不合法"},
+		{name: "宽高都为 0", variant: Variant{Name: "a"}, err: "宽高不合法"},
+		{name: "宽度为负数", variant: Variant{Name: "a", Width: -1, Height: 100}, err: "宽高不合法"},
+		{name: "裁剪缺少高度", variant: Variant{Name: "a", Width: 100, Mode: ModeCrop}, err: "需要同时指定宽高"},
+		{name: "缩放方式不支持", variant: Variant{Name: "a", Width: 100, Mode: "stretch"}, err: "缩放方式不支持"},
+		{name: "输出格式不支持", variant: Variant{Name: "a", Width: 100, Format: "avif"}, err: "输出格式不支持"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.variant.Validate()
+			if c.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("错误 = %v，期望包含 %q", err, c.err)
+			}
+		})
+	}
+	for _, variant := range DefaultVariants {
+		if err := variant.Validate(); err != nil {
+			t.Errorf("默认图片规格 %s: %v", variant.Name, err)
+		}
+	}
+}
+
+func TestVariantOutputFormat(t *testing.T) {
+	cases := []struct {
+		format, source, want string
+	}{
+		{"", FormatJPEG, FormatJPEG},
+		{"", FormatPNG, FormatPNG},
+		{"", "gif", FormatPNG},
+		{"", FormatWebP, FormatPNG},
+		{FormatWebP, FormatJPEG, FormatWebP},
+		{FormatJPEG, FormatPNG, FormatJPEG},
+	}
+	for _, c := range cases {
+		if got := (Variant{Format: c.format}).OutputFormat(c.source); got != c.want {
+			t.Errorf("Variant{Format: %q}.OutputFormat(%q) = %s，期望 %s", c.format, c.source, got, c.want)
+		}
+	}
+}
+
+// isColor 判断像素是否接近指定颜色，缩放插值与 JPEG 压缩会带来少量误差
+func isColor(c color.Color, want color.NRGBA) bool {
+	got := color.NRGBAModel.Convert(c).(color.NRGBA)
+	near := func(a, b uint8) bool { return int(a)-int(b) <= 40 && int(b)-int(a) <= 40 }
+	return near(got.R, want.R) && near(got.G, want.G) && near(got.B, want.B) && near(got.A, want.A)
+}
+
+var (
+	red   = color.NRGBA{R: 255, A: 255}
+	green = color.NRGBA{G: 255, A: 255}
+	blue  = color.NRGBA{B: 255, A: 255}
+)
+
+// stripes 由左到右红、绿、蓝三等分的图片
+func stripes(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i, c := range []color.NRGBA{red, green, blue} {
+		draw.Draw(img, image.Rect(width*i/3, 0, width*(i+1)/3, height), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+func TestResize(t *testing.T) {
+	cases := []struct {
+		name          string
+		width, height int
+		variant       Variant
+		wantW, wantH  int
+		center        color.NRGBA // 结果中心的颜色
+	}{
+		{name: "裁剪为正方形保留中间部分", width: 300, height: 100, variant: Variant{Width: 50, Height: 50, Mode: ModeCrop}, wantW: 50, wantH: 50, center: green},
+		{name: "竖图裁剪", width: 90, height: 300, variant: Variant{Width: 30, Height: 30, Mode: ModeCrop}, wantW: 30, wantH: 30, center: green},
+		{name: "裁剪不放大", width: 60, height: 90, variant: Variant{Width: 200, Height: 100, Mode: ModeCrop}, wantW: 60, wantH: 30, center: green},
+		{name: "按宽度等比缩放", width: 300, height: 100, variant: Variant{Width: 150}, wantW: 150, wantH: 50, center: green},
+		{name: "按高度等比缩放", width: 300, height: 100, variant: Variant{Height: 20, Mode: ModeFit}, wantW: 60, wantH: 20, center: green},
+		{name: "同时限制宽高取较小比例", width: 300, height: 100, variant: Variant{Width: 150, Height: 25}, wantW: 75, wantH: 25, center: green},
+		{name: "不放大", width: 300, height: 100, variant: Variant{Width: 800}, wantW: 300, wantH: 100, center: green},
+		{name: "缩放后至少 1 像素", width: 3000, height: 3, variant: Variant{Width: 30}, wantW: 30, wantH: 1, center: green},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Resize(stripes(c.width, c.height), c.variant)
+			bounds := got.Bounds()
+			if bounds.Dx() != c.wantW || bounds.Dy() != c.wantH {
+				t.Fatalf("尺寸 %dx%d，期望 %dx%d", bounds.Dx(), bounds.Dy(), c.wantW, c.wantH)
+			}
+			if center := got.At(bounds.Dx()/2, bounds.Dy()/2); !isColor(center, c.center) {
+				t.Errorf("中心颜色 %v，期望 %v", center, c.center)
+			}
+		})
+	}
+
+	// 裁剪为正方形时两侧的红色、蓝色被裁掉
+	got := Resize(stripes(300, 100), Variant{Width: 50, Height: 50, Mode: ModeCrop})
+	if !isColor(got.At(0, 25), green) || !isColor(got.At(49, 25), green) {
+		t.Errorf("裁剪结果两侧颜色 %v、%v，期望都为绿色", got.At(0, 25), got.At(49, 25))
+	}
+}
+
+// TestDecodeOrientation 解码 JPEG 时按 EXIF 方向旋转
+func TestDecodeOrientation(t *testing.T) {
+	cases := []struct {
+		orientation    int
+		width, height  int
+		topLeft, right color.NRGBA // 左上角与右下角的颜色
+	}{
+		{orientation: 1, width: 16, height: 8, topLeft: red, right: blue},
+		{orientation: 3, width: 16, height: 8, topLeft: blue, right: red},
+		{orientation: 6, width: 8, height: 16, topLeft: red, right: blue},
+		{orientation: 8, width: 8, height: 16, topLeft: blue, right: red},
+	}
+	for _, c := range cases {
+		data := testJPEG(t, orientationSegment(c.orientation))
+		img, format, err := Decode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bounds := img.Bounds()
+		if format != FormatJPEG || bounds.Dx() != c.width || bounds.Dy() != c.height {
+			t.Fatalf("方向 %d: 解码为 %s %dx%d，期望 jpeg %dx%d", c.orientation, format, bounds.Dx(), bounds.Dy(), c.width, c.height)
+		}
+		if !isColor(img.At(1, 1), c.topLeft) || !isColor(img.At(c.width-2, c.height-2), c.right) {
+			t.Errorf("方向 %d: 左上角 %v、右下角 %v，期望 %v、%v", c.orientation, img.At(1, 1), img.At(c.width-2, c.height-2), c.topLeft, c.right)
+		}
+	}
+
+	if _, _, err := Decode([]byte("not an image")); err == nil {
+		t.Error("解码非图片内容应返回错误")
+	}
+}
+
+// TestEncodeWebP 无损 WebP 编码后解码得到完全相同的像素
+func TestEncodeWebP(t *testing.T) {
+	gradient := image.NewNRGBA(image.Rect(0, 0, 37, 23))
+	for y := 0; y < 23; y++ {
+		for x := 0; x < 37; x++ {
+			gradient.Set(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(y * 11), B: uint8(x * y), A: 255})
+		}
+	}
+	alpha := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 20; x++ {
+			alpha.Set(x, y, color.NRGBA{R: uint8(x * 13), G: 200, B: uint8(y * 25), A: uint8(x*12 + y)})
+		}
+	}
+	uniform := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	draw.Draw(uniform, uniform.Bounds(), image.NewUniform(green), image.Point{}, draw.Src)
+	offset := stripes(30, 12).SubImage(image.Rect(5, 2, 25, 10)).(*image.NRGBA)
+
+	cases := []struct {
+		name string
+		img  image.Image
+	}{
+		{name: "渐变", img: gradient},
+		{name: "透明度", img: alpha},
+		{name: "单一颜色", img: uniform},
+		{name: "1x1", img: stripes(1, 1)},
+		{name: "起点不为原点", img: offset},
+		{name: "灰度图", img: image.NewGray(image.Rect(0, 0, 9, 9))},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			img := c.img
+			var buf bytes.Buffer
+			if err := EncodeWebP(&buf, img); err != nil {
+				t.Fatal(err)
+			}
+			decoded, format, err := image.Decode(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != FormatWebP {
+				t.Fatalf("格式为 %s", format)
+			}
+			bounds := img.Bounds()
+			if decoded.Bounds().Dx() != bounds.Dx() || decoded.Bounds().Dy() != bounds.Dy() {
+				t.Fatalf("尺寸 %v，期望 %v", decoded.Bounds(), bounds)
+			}
+			for y := 0; y < bounds.Dy(); y++ {
+				for x := 0; x < bounds.Dx(); x++ {
+					want := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y))
+					got := color.NRGBAModel.Convert(decoded.At(decoded.Bounds().Min.X+x, decoded.Bounds().Min.Y+y))
+					if want != got {
+						t.Fatalf("像素 (%d,%d) = %v，期望 %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+
+	for _, rect := range []image.Rectangle{image.Rect(0, 0, 0, 10), image.Rect(0, 0, 1<<14+1, 1)} {
+		if err := EncodeWebP(&bytes.Buffer{}, image.NewNRGBA(rect)); err == nil {
+			t.Errorf("尺寸 %v 应返回错误", rect)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	img := stripes(30, 12)
+	cases := []struct {
+		format      string
+		contentType string
+	}{
+		{FormatJPEG, "image/jpeg"},
+		{FormatPNG, "image/png"},
+		{FormatWebP, "image/webp"},
+	}
+	for _, c := range cases {
+		data, contentType, err := Encode(img, c.format, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contentType != c.contentType {
+			t.Errorf("%s 的 Content-Type = %s，期望 %s", c.format, contentType, c.contentType)
+		}
+		if _, format, err := image.Decode(bytes.NewReader(data)); err != nil || format != c.format {
+			t.Errorf("%s 编码结果解码为 %s: %v", c.format, format, err)
+		}
+	}
+	if _, _, err := Encode(img, "gif", 0); err == nil {
+		t.Error("不支持的格式应返回错误")
+	}
+}
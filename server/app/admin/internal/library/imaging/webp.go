@@ -0,0 +1,298 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"sort"
+)
+
+// EncodeWebP 以无损 WebP（VP8L）格式编码图片
+// 仅使用减绿变换与前缀编码，不使用预测变换与反向引用，压缩率低于 libwebp，但无需 cgo
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > 1<<14 || height > 1<<14 {
+		return fmt.Errorf("WebP 图片尺寸超出范围: %dx%d", width, height)
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+
+	// 减绿变换后的 ARGB 像素与各通道的符号频率
+	var (
+		pixels    = make([][4]byte, 0, width*height) // 绿、红、蓝、透明度
+		histogram [4][256]int
+		hasAlpha  bool
+	)
+	for i := 0; i < len(nrgba.Pix); i += 4 {
+		r, g, b, a := nrgba.Pix[i], nrgba.Pix[i+1], nrgba.Pix[i+2], nrgba.Pix[i+3]
+		pixel := [4]byte{g, r - g, b - g, a}
+		for channel, symbol := range pixel {
+			histogram[channel][symbol]++
+		}
+		if a != 0xff {
+			hasAlpha = true
+		}
+		pixels = append(pixels, pixel)
+	}
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8) // VP8L 签名
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // 版本号
+
+	bw.write(1, 1) // 存在变换
+	bw.write(2, 2) // 减绿变换
+	bw.write(0, 1) // 没有更多变换
+	bw.write(0, 1) // 不使用颜色缓存
+	bw.write(0, 1) // 不使用元前缀编码
+
+	// 五个前缀编码：绿（含长度前缀）、红、蓝、透明度、距离
+	codes := make([]prefixCode, 4)
+	for channel := range histogram {
+		alphabetSize := 256
+		if channel == 0 {
+			alphabetSize = 256 + 24 // 绿色字母表包含 24 个长度前缀
+		}
+		counts := make([]int, alphabetSize)
+		copy(counts, histogram[channel][:])
+		codes[channel] = newPrefixCode(counts, 15)
+		codes[channel].writeTo(bw)
+	}
+	writeSimpleCode(bw, 0) // 距离：没有反向引用
+
+	for _, pixel := range pixels {
+		for channel, symbol := range pixel {
+			codes[channel].writeSymbol(bw, int(symbol))
+		}
+	}
+	data := bw.bytes()
+
+	// RIFF 容器
+	var buf bytes.Buffer
+	chunkSize := len(data)
+	padding := chunkSize & 1
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4+8+chunkSize+padding))
+	buf.WriteString("WEBPVP8L")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(chunkSize))
+	buf.Write(data)
+	if padding == 1 {
+		buf.WriteByte(0)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// bitWriter 按低位在前的顺序写入比特
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+// write 写入 value 的低 n 位
+func (w *bitWriter) write(value uint32, n uint) {
+	w.acc |= uint64(value) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+// bytes 返回写入的内容，不足一个字节的部分补零
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf
+}
+
+// prefixCode 规范前缀编码（Huffman 编码）
+type prefixCode struct {
+	lengths []uint8  // 各符号的编码长度，0 表示未使用
+	codes   []uint32 // 各符号按位反转后的编码，可直接低位在前写入
+	symbols []int    // 使用的符号，少于 2 个时使用简单编码
+}
+
+// newPrefixCode 根据符号频率创建编码长度不超过 maxLength 的前缀编码
+func newPrefixCode(counts []int, maxLength int) prefixCode {
+	code := prefixCode{lengths: huffmanLengths(counts, maxLength)}
+	for symbol, count := range counts {
+		if count > 0 {
+			code.symbols = append(code.symbols, symbol)
+		}
+	}
+	code.codes = canonicalCodes(code.lengths)
+	return code
+}
+
+// writeTo 写入前缀编码的定义
+func (c prefixCode) writeTo(w *bitWriter) {
+	switch {
+	case len(c.symbols) == 0:
+		writeSimpleCode(w, 0)
+		return
+	case len(c.symbols) == 1 && c.symbols[0] < 256:
+		writeSimpleCode(w, c.symbols[0])
+		return
+	}
+
+	// 普通编码：先用编码长度编码（字母表 0~18，这里只使用 0~15）描述各符号的编码长度
+	var lengthCounts [19]int
+	for _, length := range c.lengths {
+		lengthCounts[length]++
+	}
+	lengthCode := newPrefixCode(lengthCounts[:], 7)
+	if len(lengthCode.symbols) == 1 {
+		// 只有一种编码长度时，补充一个长度为 1 的占位符号，保证编码完整
+		placeholder := 0
+		if lengthCode.symbols[0] == 0 {
+			placeholder = 1
+		}
+		lengthCode.lengths[lengthCode.symbols[0]], lengthCode.lengths[placeholder] = 1, 1
+		lengthCode.symbols = append(lengthCode.symbols, placeholder)
+		lengthCode.codes = canonicalCodes(lengthCode.lengths)
+	}
+
+	order := [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	count := len(order)
+	for count > 4 && lengthCode.lengths[order[count-1]] == 0 {
+		count--
+	}
+	w.write(0, 1) // 普通编码
+	w.write(uint32(count-4), 4)
+	for i := 0; i < count; i++ {
+		w.write(uint32(lengthCode.lengths[order[i]]), 3)
+	}
+	w.write(0, 1) // 写入全部符号的编码长度
+	for _, length := range c.lengths {
+		lengthCode.writeSymbol(w, int(length))
+	}
+}
+
+// writeSymbol 写入符号的编码，简单编码的单个符号不占用比特
+func (c prefixCode) writeSymbol(w *bitWriter, symbol int) {
+	if len(c.symbols) < 2 {
+		return
+	}
+	w.write(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+// writeSimpleCode 写入只有一个符号（小于 256）的简单编码
+func writeSimpleCode(w *bitWriter, symbol int) {
+	w.write(1, 1) // 简单编码
+	w.write(0, 1) // 一个符号
+	if symbol < 2 {
+		w.write(0, 1)
+		w.write(uint32(symbol), 1)
+	} else {
+		w.write(1, 1)
+		w.write(uint32(symbol), 8)
+	}
+}
+
+// huffmanLengths 计算编码长度不超过 maxLength 的 Huffman 编码长度，超过时减小频率差异后重新计算
+func huffmanLengths(counts []int, maxLength int) []uint8 {
+	lengths := make([]uint8, len(counts))
+	weights := append([]int(nil), counts...)
+	for {
+		type node struct {
+			weight      int
+			symbol      int // 叶子节点的符号，内部节点为 -1
+			left, right int
+		}
+		var nodes []node
+		for symbol, weight := range weights {
+			if weight > 0 {
+				nodes = append(nodes, node{weight: weight, symbol: symbol, left: -1, right: -1})
+			}
+		}
+		if len(nodes) < 2 {
+			for i := range nodes {
+				lengths[nodes[i].symbol] = 1
+			}
+			return lengths
+		}
+
+		// 按权重排序的待合并节点队列
+		queue := make([]int, len(nodes))
+		for i := range queue {
+			queue[i] = i
+		}
+		for len(queue) > 1 {
+			sort.SliceStable(queue, func(i, j int) bool { return nodes[queue[i]].weight < nodes[queue[j]].weight })
+			a, b := queue[0], queue[1]
+			nodes = append(nodes, node{weight: nodes[a].weight + nodes[b].weight, symbol: -1, left: a, right: b})
+			queue = append(queue[2:], len(nodes)-1)
+		}
+
+		tooLong := false
+		var walk func(index int, depth uint8)
+		walk = func(index int, depth uint8) {
+			if nodes[index].symbol >= 0 {
+				lengths[nodes[index].symbol] = depth
+				if int(depth) > maxLength {
+					tooLong = true
+				}
+				return
+			}
+			walk(nodes[index].left, depth+1)
+			walk(nodes[index].right, depth+1)
+		}
+		walk(queue[0], 0)
+		if !tooLong {
+			return lengths
+		}
+		for i, weight := range weights {
+			if weight > 0 {
+				weights[i] = weight/2 + 1
+			}
+		}
+	}
+}
+
+// canonicalCodes 根据编码长度生成规范编码，并按位反转以便低位在前写入
+func canonicalCodes(lengths []uint8) []uint32 {
+	var (
+		codes     = make([]uint32, len(lengths))
+		lengthNum [16]uint32
+		nextCode  [16]uint32
+	)
+	for _, length := range lengths {
+		if length > 0 {
+			lengthNum[length]++
+		}
+	}
+	var code uint32
+	for length := 1; length < 16; length++ {
+		code = (code + lengthNum[length-1]) << 1
+		nextCode[length] = code
+	}
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		value := nextCode[length]
+		nextCode[length]++
+		var reversed uint32
+		for i := uint8(0); i < length; i++ {
+			reversed = reversed<<1 | (value>>i)&1
+		}
+		codes[symbol] = reversed
+	}
+	return codes
+}
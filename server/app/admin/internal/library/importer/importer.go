@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxRows 单次导入允许的最大数据行数
+const MaxRows = 5000
+
+// RowError 导入行错误
+type RowError struct {
+	Row     int    `json:"row"     dc:"行号（含表头，从1开始）"`
+	Message string `json:"message" dc:"错误信息"`
+}
+
+// Record 导入文件中的一行数据
+type Record struct {
+	Row    int               // 行号（含表头，从1开始）
+	Values map[string]string // 表头 -> 单元格值
+}
+
+// Read 解析上传的 CSV/XLSX 文件，按表头返回每行数据（跳过空行）
+func Read(file *ghttp.UploadFile) ([]Record, error) {
+	if file == nil {
+		return nil, fmt.Errorf("请选择导入文件")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("打开导入文件失败: %v", err)
+	}
+	defer f.Close()
+
+	var rows [][]string
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".csv":
+		rows, err = readCSV(f)
+	case ".xlsx":
+		rows, err = readXLSX(f)
+	default:
+		return nil, fmt.Errorf("仅支持导入 csv 或 xlsx 文件")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("导入文件缺少表头")
+	}
+	if len(rows)-1 > MaxRows {
+		return nil, fmt.Errorf("单次最多导入 %d 行数据", MaxRows)
+	}
+
+	headers := make([]string, len(rows[0]))
+	for i, header := range rows[0] {
+		headers[i] = strings.TrimSpace(header)
+	}
+
+	records := make([]Record, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		values := make(map[string]string, len(headers))
+		empty := true
+		for j, header := range headers {
+			if header == "" || j >= len(row) {
+				continue
+			}
+			value := strings.TrimSpace(row[j])
+			if value != "" {
+				empty = false
+			}
+			values[header] = value
+		}
+		if empty {
+			continue
+		}
+		records = append(records, Record{Row: i + 2, Values: values})
+	}
+	return records, nil
+}
+
+// readCSV 读取CSV内容（兼容带BOM的UTF-8文件）
+func readCSV(r io.Reader) ([][]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取导入文件失败: %v", err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\xEF\xBB\xBF"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析CSV失败: %v", err)
+	}
+	return rows, nil
+}
+
+// readXLSX 读取XLSX第一个工作表
+func readXLSX(r io.Reader) ([][]string, error) {
+	xlsx, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("解析XLSX失败: %v", err)
+	}
+	defer xlsx.Close()
+
+	rows, err := xlsx.GetRows(xlsx.GetSheetName(0))
+	if err != nil {
+		return nil, fmt.Errorf("读取XLSX工作表失败: %v", err)
+	}
+	return rows, nil
+}
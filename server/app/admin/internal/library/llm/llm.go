@@ -0,0 +1,130 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/guonaihong/gout"
+)
+
+// 支持的模型服务类型
+const (
+	ProviderOpenAI = "openai" // OpenAI 兼容接口（/chat/completions），如 OpenAI、DeepSeek、通义千问等
+	ProviderOllama = "ollama" // Ollama 兼容接口（/api/chat）
+	ProviderMock   = "mock"   // 本地模拟实现，不请求外部服务，返回确定性结果，用于开发与测试
+)
+
+// Provider 大模型服务
+type Provider interface {
+	// Name 服务类型
+	Name() string
+	// Complete 根据系统提示词与用户提示词生成回复
+	Complete(ctx context.Context, system, prompt string) (string, error)
+}
+
+// Config 大模型服务配置
+type Config struct {
+	Provider string        // 服务类型：openai、ollama、mock，为空时使用 mock
+	Url      string        // 服务地址，openai 为 https://api.openai.com/v1 形式的 base url，ollama 为 http://localhost:11434
+	Model    string        // 模型名称
+	ApiKey   string        // 接口密钥，ollama 与 mock 不需要
+	Timeout  time.Duration // 单次请求超时时间，为 0 时仅受 ctx 控制
+	Retries  int           // 请求失败时的最大尝试次数，小于 1 时按 1 处理
+}
+
+// New 根据配置创建大模型服务
+func New(config Config) (Provider, error) {
+	switch strings.ToLower(config.Provider) {
+	case "", ProviderMock:
+		return NewMock(), nil
+	case ProviderOpenAI:
+		if config.Url == "" || config.Model == "" {
+			return nil, fmt.Errorf("openai 服务需要配置服务地址与模型名称")
+		}
+		return NewOpenAI(config), nil
+	case ProviderOllama:
+		if config.Url == "" || config.Model == "" {
+			return nil, fmt.Errorf("ollama 服务需要配置服务地址与模型名称")
+		}
+		return NewOllama(config), nil
+	default:
+		return nil, fmt.Errorf("不支持的大模型服务类型: %s", config.Provider)
+	}
+}
+
+// retry 按配置的次数重试请求，重试间隔递增，等待期间 ctx 取消或超时立即返回
+func retry(ctx context.Context, config Config, fn func(ctx context.Context) error) error {
+	attempts := config.Retries
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = attemptWithTimeout(ctx, config.Timeout, fn); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("请求已取消: %v", err)
+		}
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("请求已取消: %v", err)
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+	if attempts > 1 {
+		return fmt.Errorf("已重试 %d 次: %v", attempts, err)
+	}
+	return err
+}
+
+// attemptWithTimeout 以单次请求超时时间执行一次请求
+func attemptWithTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// postJSON 发送 JSON 请求并解析 JSON 响应，非 2xx 状态码返回错误
+func postJSON(ctx context.Context, url string, header gout.H, body, result interface{}) error {
+	var (
+		code int
+		raw  string
+	)
+	err := gout.POST(url).
+		WithContext(ctx).
+		SetHeader(header).
+		SetJSON(body).
+		BindBody(&raw).
+		Code(&code).
+		Do()
+	if err != nil {
+		return err
+	}
+	if code < 200 || code >= 300 {
+		return fmt.Errorf("服务返回状态码 %d: %s", code, truncate(raw, 512))
+	}
+	if err = gjson.DecodeTo(raw, result); err != nil {
+		return fmt.Errorf("解析响应失败: %v，原始响应: %s", err, truncate(raw, 512))
+	}
+	return nil
+}
+
+// truncate 截断过长的文本，用于错误信息
+func truncate(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	return text[:max] + "..."
+}
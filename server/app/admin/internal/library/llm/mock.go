@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"strings"
+)
+
+// mockTableNamePattern 从提示词中提取英文表名
+var mockTableNamePattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9_]*`)
+
+// mock 本地模拟实现，不请求外部服务，相同提示词总是返回相同的建表语句
+type mock struct{}
+
+// NewMock 创建本地模拟服务
+func NewMock() Provider {
+	return mock{}
+}
+
+// Name 服务类型
+func (mock) Name() string {
+	return ProviderMock
+}
+
+// Complete 根据用户提示词生成固定结构的建表语句：
+// 表名取提示词中第一个英文单词，没有时按提示词的校验和生成；表注释取提示词
+func (mock) Complete(ctx context.Context, system, prompt string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	tableName := strings.ToLower(mockTableNamePattern.FindString(prompt))
+	if tableName == "" || len(tableName) > 64 {
+		tableName = fmt.Sprintf("demo_%08x", crc32.ChecksumIEEE([]byte(prompt)))
+	}
+	comment := []rune(strings.NewReplacer("'", "", "\\", "", "\n", " ", "\r", "").Replace(strings.TrimSpace(prompt)))
+	if len(comment) > 60 {
+		comment = comment[:60]
+	}
+
+	return fmt.Sprintf("```sql\nCREATE TABLE `%s` (\n"+
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',\n"+
+		"  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '名称',\n"+
+		"  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：0禁用 1启用',\n"+
+		"  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',\n"+
+		"  `created_at` datetime DEFAULT NULL COMMENT '创建时间',\n"+
+		"  `updated_at` datetime DEFAULT NULL COMMENT '更新时间',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='%s';\n```", tableName, string(comment)), nil
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guonaihong/gout"
+)
+
+// ollama Ollama 兼容接口
+type ollama struct {
+	config Config
+}
+
+// ollamaMessage 对话消息
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ollamaRequest 对话请求
+type ollamaRequest struct {
+	Model    string                 `json:"model"`
+	Messages []ollamaMessage        `json:"messages"`
+	Stream   bool                   `json:"stream"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+}
+
+// ollamaResponse 对话响应
+type ollamaResponse struct {
+	Message ollamaMessage `json:"message"`
+	Error   string        `json:"error"`
+}
+
+// NewOllama 创建 Ollama 兼容接口服务，请求 {Url}/api/chat
+func NewOllama(config Config) Provider {
+	return &ollama{config: config}
+}
+
+// Name 服务类型
+func (p *ollama) Name() string {
+	return ProviderOllama
+}
+
+// Complete 根据系统提示词与用户提示词生成回复
+func (p *ollama) Complete(ctx context.Context, system, prompt string) (string, error) {
+	var (
+		url  = strings.TrimRight(p.config.Url, "/") + "/api/chat"
+		body = ollamaRequest{
+			Model: p.config.Model,
+			Messages: []ollamaMessage{
+				{Role: "system", Content: system},
+				{Role: "user", Content: prompt},
+			},
+			Options: map[string]interface{}{"temperature": 0},
+		}
+		content string
+	)
+
+	err := retry(ctx, p.config, func(ctx context.Context) error {
+		var response ollamaResponse
+		if err := postJSON(ctx, url, gout.H{"Accept": "application/json"}, body, &response); err != nil {
+			return err
+		}
+		if response.Error != "" {
+			return fmt.Errorf("服务返回错误: %s", response.Error)
+		}
+		if response.Message.Content == "" {
+			return fmt.Errorf("服务返回空结果")
+		}
+		content = response.Message.Content
+		return nil
+	})
+	return content, err
+}
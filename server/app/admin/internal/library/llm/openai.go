@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guonaihong/gout"
+)
+
+// openAI OpenAI 兼容接口
+type openAI struct {
+	config Config
+}
+
+// openAIMessage 对话消息
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIRequest 对话请求
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	Temperature float64         `json:"temperature"`
+	Stream      bool            `json:"stream"`
+}
+
+// openAIResponse 对话响应
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewOpenAI 创建 OpenAI 兼容接口服务，Url 为 base url，请求 {Url}/chat/completions
+func NewOpenAI(config Config) Provider {
+	return &openAI{config: config}
+}
+
+// Name 服务类型
+func (p *openAI) Name() string {
+	return ProviderOpenAI
+}
+
+// Complete 根据系统提示词与用户提示词生成回复
+func (p *openAI) Complete(ctx context.Context, system, prompt string) (string, error) {
+	var (
+		url    = strings.TrimRight(p.config.Url, "/") + "/chat/completions"
+		header = gout.H{"Accept": "application/json"}
+		body   = openAIRequest{
+			Model: p.config.Model,
+			Messages: []openAIMessage{
+				{Role: "system", Content: system},
+				{Role: "user", Content: prompt},
+			},
+		}
+		content string
+	)
+	if p.config.ApiKey != "" {
+		header["Authorization"] = "Bearer " + p.config.ApiKey
+	}
+
+	err := retry(ctx, p.config, func(ctx context.Context) error {
+		var response openAIResponse
+		if err := postJSON(ctx, url, header, body, &response); err != nil {
+			return err
+		}
+		if response.Error != nil {
+			return fmt.Errorf("服务返回错误: %s", response.Error.Message)
+		}
+		if len(response.Choices) == 0 {
+			return fmt.Errorf("服务返回空结果")
+		}
+		content = response.Choices[0].Message.Content
+		return nil
+	})
+	return content, err
+}
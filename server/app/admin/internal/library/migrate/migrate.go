@@ -0,0 +1,303 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// sqlFiles 内嵌的迁移文件，命名为 <版本号>_<名称>.up.sql / <版本号>_<名称>.down.sql
+//
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// TableName 迁移记录表
+const TableName = "schema_migrations"
+
+// createTableSQL 迁移记录表建表语句，执行迁移前自动创建
+const createTableSQL = "CREATE TABLE IF NOT EXISTS `" + TableName + "` (\n" +
+	"  `version` bigint unsigned NOT NULL COMMENT '迁移版本号',\n" +
+	"  `name` varchar(128) NOT NULL COMMENT '迁移名称',\n" +
+	"  `checksum` char(64) NOT NULL COMMENT 'up 脚本的 SHA-256 校验和',\n" +
+	"  `applied_at` datetime NOT NULL COMMENT '执行时间',\n" +
+	"  PRIMARY KEY (`version`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='数据库迁移记录'"
+
+// fileNamePattern 迁移文件名规则
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+// 迁移状态
+const (
+	StatusPending  = "pending"  // 未执行
+	StatusApplied  = "applied"  // 已执行
+	StatusModified = "modified" // 已执行，但迁移文件在执行后被修改
+	StatusMissing  = "missing"  // 已执行，但迁移文件已不存在
+)
+
+// Migration 迁移文件
+type Migration struct {
+	Version  uint64 // 版本号，按升序执行
+	Name     string // 名称
+	Up       string // 升级脚本
+	Down     string // 回滚脚本
+	Checksum string // 升级脚本的 SHA-256 校验和
+}
+
+// State 迁移状态
+type State struct {
+	Version   uint64      // 版本号
+	Name      string      // 名称
+	Status    string      // 状态：pending、applied、modified、missing
+	AppliedAt *gtime.Time // 执行时间，未执行时为 nil
+}
+
+// record 迁移记录表中的一条记录
+type record struct {
+	Version   uint64      `orm:"version"`
+	Name      string      `orm:"name"`
+	Checksum  string      `orm:"checksum"`
+	AppliedAt *gtime.Time `orm:"applied_at"`
+}
+
+// Migrator 数据库迁移执行器
+type Migrator struct {
+	db         gdb.DB
+	migrations []Migration
+}
+
+// New 创建使用内嵌迁移文件的迁移执行器
+func New(db gdb.DB) (*Migrator, error) {
+	migrations, err := Load(sqlFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load 读取目录中的迁移文件，每个版本必须同时提供 up 与 down 脚本
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("读取迁移文件失败: %v", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, file := range files {
+		match := fileNamePattern.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, fmt.Errorf("迁移文件名不合法: %s，应为 <版本号>_<名称>.up.sql 或 .down.sql", file)
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("迁移文件版本号不合法: %s", file)
+		}
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("读取迁移文件 %s 失败: %v", file, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("迁移版本 %d 的 up 与 down 文件名称不一致", version)
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			migration.Checksum = checksum(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("迁移版本 %d 缺少 up 脚本", migration.Version)
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("迁移版本 %d 缺少 down 脚本", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// checksum 返回内容的 SHA-256 十六进制校验和
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// applied 读取已执行的迁移记录
+func (m *Migrator) applied(ctx context.Context) (map[uint64]record, error) {
+	if _, err := m.db.Exec(ctx, createTableSQL); err != nil {
+		return nil, fmt.Errorf("创建迁移记录表失败: %v", err)
+	}
+	var records []record
+	if err := m.db.Model(TableName).Ctx(ctx).OrderAsc("version").Scan(&records); err != nil {
+		return nil, fmt.Errorf("读取迁移记录失败: %v", err)
+	}
+	result := make(map[uint64]record, len(records))
+	for _, item := range records {
+		result[item.Version] = item
+	}
+	return result, nil
+}
+
+// Status 返回所有迁移的执行状态，按版本号升序
+func (m *Migrator) Status(ctx context.Context) ([]State, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]State, 0, len(m.migrations)+len(applied))
+	for _, migration := range m.migrations {
+		state := State{Version: migration.Version, Name: migration.Name, Status: StatusPending}
+		if item, ok := applied[migration.Version]; ok {
+			state.Status, state.AppliedAt = StatusApplied, item.AppliedAt
+			if item.Checksum != migration.Checksum {
+				state.Status = StatusModified
+			}
+			delete(applied, migration.Version)
+		}
+		states = append(states, state)
+	}
+	for _, item := range applied {
+		states = append(states, State{Version: item.Version, Name: item.Name, Status: StatusMissing, AppliedAt: item.AppliedAt})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Version < states[j].Version })
+	return states, nil
+}
+
+// verify 校验已执行迁移的文件未被修改或删除
+func verify(states []State) error {
+	for _, state := range states {
+		switch state.Status {
+		case StatusModified:
+			return fmt.Errorf("迁移 %d_%s 已执行，但迁移文件在执行后被修改（校验和不一致），请新增迁移而不是修改已执行的迁移", state.Version, state.Name)
+		case StatusMissing:
+			return fmt.Errorf("迁移 %d_%s 已执行，但迁移文件不存在", state.Version, state.Name)
+		}
+	}
+	return nil
+}
+
+// find 按版本号查找迁移
+func (m *Migrator) find(version uint64) Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return Migration{}
+}
+
+// Up 按版本号升序执行未执行的迁移，steps 为 0 时执行全部，返回执行的迁移
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	states, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = verify(states); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, state := range states {
+		if state.Status != StatusPending {
+			continue
+		}
+		if steps > 0 && len(done) >= steps {
+			break
+		}
+		migration := m.find(state.Version)
+		if err = m.run(ctx, migration, migration.Up, true); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down 按版本号降序回滚已执行的迁移，steps 为 0 时回滚全部，返回回滚的迁移
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	states, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = verify(states); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(states) - 1; i >= 0; i-- {
+		if states[i].Status != StatusApplied {
+			continue
+		}
+		if steps > 0 && len(done) >= steps {
+			break
+		}
+		migration := m.find(states[i].Version)
+		if err = m.run(ctx, migration, migration.Down, false); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Redo 回滚最近执行的 steps 个迁移后重新执行
+func (m *Migrator) Redo(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	reverted, err := m.Down(ctx, steps)
+	if err != nil {
+		return nil, err
+	}
+	return m.Up(ctx, len(reverted))
+}
+
+// run 在事务中执行迁移脚本并更新迁移记录
+// 注意 MySQL 的 DDL 语句会隐式提交事务，脚本中途失败时已执行的 DDL 无法回滚，需要手动处理
+func (m *Migrator) run(ctx context.Context, migration Migration, script string, up bool) error {
+	statements, err := SplitStatements(script)
+	if err != nil {
+		return fmt.Errorf("解析迁移 %d_%s 失败: %v", migration.Version, migration.Name, err)
+	}
+
+	return m.db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		for i, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return fmt.Errorf("执行迁移 %d_%s 的第 %d 条语句失败: %v\n%s", migration.Version, migration.Name, i+1, err, statement)
+			}
+		}
+		if !up {
+			_, err := tx.Model(TableName).Where("version", migration.Version).Delete()
+			return err
+		}
+		_, err := tx.Model(TableName).Data(record{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: gtime.Now(),
+		}).Insert()
+		return err
+	})
+}
@@ -0,0 +1,169 @@
+package migrate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSplitStatements(t *testing.T) {
+	cases := []struct {
+		name   string
+		script string
+		want   []string
+		err    string
+	}{
+		{name: "空脚本", script: " \n-- 注释\n", want: nil},
+		{name: "多条语句", script: "CREATE TABLE a (id int);\nINSERT INTO a VALUES (1);", want: []string{"CREATE TABLE a (id int)", "INSERT INTO a VALUES (1)"}},
+		{name: "末尾没有分号", script: "SELECT 1;\nSELECT 2", want: []string{"SELECT 1", "SELECT 2"}},
+		{name: "连续分号", script: "SELECT 1;;\n;SELECT 2;", want: []string{"SELECT 1", "SELECT 2"}},
+		{name: "字符串中的分号", script: "INSERT INTO a VALUES ('a;b', \"c;d\");", want: []string{"INSERT INTO a VALUES ('a;b', \"c;d\")"}},
+		{name: "标识符中的分号", script: "SELECT `a;b` FROM t;", want: []string{"SELECT `a;b` FROM t"}},
+		{name: "转义的引号", script: `INSERT INTO a VALUES ('it\'s;', 'x''y;z');SELECT 1`, want: []string{`INSERT INTO a VALUES ('it\'s;', 'x''y;z')`, "SELECT 1"}},
+		{name: "标识符中的反斜杠不转义", script: "SELECT `a\\`;SELECT 1", want: []string{"SELECT `a\\`", "SELECT 1"}},
+		{name: "单行注释", script: "-- 建表; 注释\nCREATE TABLE a (id int); # 行尾注释;\n--\nSELECT 1", want: []string{"CREATE TABLE a (id int)", "SELECT 1"}},
+		{name: "双横线后不是空格时不是注释", script: "SELECT 1--1;", want: []string{"SELECT 1--1"}},
+		{name: "块注释", script: "SELECT /* a; b */ 1;/* 多行\n注释; */SELECT 2;", want: []string{"SELECT   1", "SELECT 2"}},
+		{name: "注释中的引号", script: "-- it's\nSELECT 1;", want: []string{"SELECT 1"}},
+		{name: "块注释未闭合", script: "SELECT 1; /* a", err: "注释未闭合"},
+		{name: "引号未闭合", script: "SELECT 'a;", err: "引号 ' 未闭合"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := SplitStatements(c.script)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("SplitStatements = %q，期望 %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	cases := []struct {
+		content string
+		want    string
+	}{
+		{"", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+	for _, c := range cases {
+		if got := checksum([]byte(c.content)); got != c.want {
+			t.Errorf("checksum(%q) = %s，期望 %s", c.content, got, c.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := func(content string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(content)}
+	}
+	cases := []struct {
+		name  string
+		files fstest.MapFS
+		want  []Migration
+		err   string
+	}{
+		{
+			name: "按版本号排序",
+			files: fstest.MapFS{
+				"sql/000010_add_index.up.sql":     file("ALTER TABLE a ADD KEY k (b);"),
+				"sql/000010_add_index.down.sql":   file("ALTER TABLE a DROP KEY k;"),
+				"sql/000002_create_a.up.sql":      file("CREATE TABLE a (b int);"),
+				"sql/000002_create_a.down.sql":    file("DROP TABLE a;"),
+				"sql/readme.txt":                  file("不是迁移文件"),
+				"other/000001_ignored.up.sql":     file("SELECT 1;"),
+				"other/000001_ignored.down.sql":   file("SELECT 1;"),
+				"sql/nested/000003_skip.up.sql":   file("SELECT 1;"),
+				"sql/nested/000003_skip.down.sql": file("SELECT 1;"),
+			},
+			want: []Migration{
+				{Version: 2, Name: "create_a", Up: "CREATE TABLE a (b int);", Down: "DROP TABLE a;", Checksum: checksum([]byte("CREATE TABLE a (b int);"))},
+				{Version: 10, Name: "add_index", Up: "ALTER TABLE a ADD KEY k (b);", Down: "ALTER TABLE a DROP KEY k;", Checksum: checksum([]byte("ALTER TABLE a ADD KEY k (b);"))},
+			},
+		},
+		{name: "没有迁移文件", files: fstest.MapFS{}, want: []Migration{}},
+		{
+			name:  "文件名不合法",
+			files: fstest.MapFS{"sql/create-a.up.sql": file("SELECT 1;")},
+			err:   "迁移文件名不合法",
+		},
+		{
+			name:  "版本号溢出",
+			files: fstest.MapFS{"sql/99999999999999999999_a.up.sql": file("SELECT 1;")},
+			err:   "版本号不合法",
+		},
+		{
+			name: "up 与 down 名称不一致",
+			files: fstest.MapFS{
+				"sql/000001_create_a.up.sql":   file("CREATE TABLE a (b int);"),
+				"sql/000001_create_b.down.sql": file("DROP TABLE a;"),
+			},
+			err: "名称不一致",
+		},
+		{
+			name:  "缺少 up 脚本",
+			files: fstest.MapFS{"sql/000001_create_a.down.sql": file("DROP TABLE a;")},
+			err:   "缺少 up 脚本",
+		},
+		{
+			name: "down 脚本为空",
+			files: fstest.MapFS{
+				"sql/000001_create_a.up.sql":   file("CREATE TABLE a (b int);"),
+				"sql/000001_create_a.down.sql": file(" \n"),
+			},
+			err: "缺少 down 脚本",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Load(c.files)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Load = %+v\n期望 %+v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestEmbeddedMigrations 内嵌的迁移文件可以加载，版本号连续，且所有脚本都能拆分为语句
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(sqlFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("没有内嵌的迁移文件")
+	}
+	for i, migration := range migrations {
+		if migration.Version != uint64(i+1) {
+			t.Errorf("迁移 %d_%s 的版本号不连续，期望 %d", migration.Version, migration.Name, i+1)
+		}
+		for kind, script := range map[string]string{"up": migration.Up, "down": migration.Down} {
+			statements, err := SplitStatements(script)
+			if err != nil {
+				t.Errorf("迁移 %d_%s 的 %s 脚本: %v", migration.Version, migration.Name, kind, err)
+			}
+			if len(statements) == 0 {
+				t.Errorf("迁移 %d_%s 的 %s 脚本没有语句", migration.Version, migration.Name, kind)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitStatements 按分号拆分 SQL 脚本为单条语句，忽略字符串、标识符与注释中的分号，并去除注释
+// MySQL 驱动默认不允许一次执行多条语句，迁移脚本需要逐条执行
+func SplitStatements(script string) ([]string, error) {
+	var (
+		statements []string
+		current    strings.Builder
+		quote      byte // 当前所在的引号：' " `，为 0 时不在引号中
+	)
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		if quote != 0 {
+			current.WriteByte(c)
+			switch {
+			case c == '\\' && quote != '`' && i+1 < len(script):
+				i++
+				current.WriteByte(script[i])
+			case c == quote && i+1 < len(script) && script[i+1] == quote:
+				i++
+				current.WriteByte(script[i])
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			current.WriteByte(c)
+		case c == '#' || (c == '-' && strings.HasPrefix(script[i:], "-- ")) || strings.HasPrefix(script[i:], "--\n"):
+			// 单行注释
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			current.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("注释未闭合")
+			}
+			i += end + 3
+			current.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("引号 %c 未闭合", quote)
+	}
+	flush()
+	return statements, nil
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS `attachment`;
+DROP TABLE IF EXISTS `dict`;
+DROP TABLE IF EXISTS `role_menu`;
+DROP TABLE IF EXISTS `menu`;
+DROP TABLE IF EXISTS `user_role`;
+DROP TABLE IF EXISTS `role`;
+DROP TABLE IF EXISTS `user`;
+DROP TABLE IF EXISTS `department`;
@@ -0,0 +1,134 @@
+-- 系统管理基础表：部门、用户、角色、菜单、字典、附件
+
+CREATE TABLE IF NOT EXISTS `department` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `parent_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '父级部门ID',
+  `name` varchar(64) NOT NULL COMMENT '部门名称',
+  `principal` varchar(64) NOT NULL DEFAULT '' COMMENT '负责人名称',
+  `phone` varchar(32) NOT NULL DEFAULT '' COMMENT '联系电话',
+  `email` varchar(128) NOT NULL DEFAULT '' COMMENT '邮箱地址',
+  `sort` int NOT NULL DEFAULT 0 COMMENT '排序号',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态（1启用，0禁用）',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_parent_id` (`parent_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='部门';
+
+CREATE TABLE IF NOT EXISTS `user` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `title` varchar(64) NOT NULL DEFAULT '' COMMENT '职位名称',
+  `department_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '所属部门ID',
+  `nickname` varchar(64) NOT NULL DEFAULT '' COMMENT '昵称',
+  `username` varchar(64) NOT NULL COMMENT '用户名',
+  `password` varchar(255) NOT NULL COMMENT '密码（加密存储）',
+  `avatar` varchar(255) NOT NULL DEFAULT '' COMMENT '头像',
+  `phone` varchar(32) NOT NULL DEFAULT '' COMMENT '联系电话',
+  `email` varchar(128) NOT NULL DEFAULT '' COMMENT '邮箱地址',
+  `sex` tinyint NOT NULL DEFAULT 0 COMMENT '性别（0未知，1男，2女）',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态（1启用，0禁用）',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_username` (`username`),
+  KEY `idx_department_id` (`department_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='用户';
+
+CREATE TABLE IF NOT EXISTS `role` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `name` varchar(64) NOT NULL COMMENT '角色名称',
+  `code` varchar(64) NOT NULL COMMENT '角色编码（唯一）',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态（1启用，0禁用）',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='角色';
+
+CREATE TABLE IF NOT EXISTS `user_role` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID',
+  `created_at` datetime NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_user_role` (`user_id`,`role_id`),
+  KEY `idx_role_id` (`role_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='用户角色关联';
+
+CREATE TABLE IF NOT EXISTS `menu` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `menu_type` tinyint NOT NULL DEFAULT 0 COMMENT '菜单类型（0菜单、1 iframe、2外链、3按钮）',
+  `parent_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '父级菜单ID',
+  `title` varchar(64) NOT NULL COMMENT '菜单名称',
+  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '路由名称（必须唯一）',
+  `path` varchar(255) NOT NULL DEFAULT '' COMMENT '路由路径',
+  `component` varchar(255) NOT NULL DEFAULT '' COMMENT '组件路径',
+  `rank` int NOT NULL DEFAULT 0 COMMENT '菜单排序（home 的 rank 应为 0）',
+  `redirect` varchar(255) NOT NULL DEFAULT '' COMMENT '重定向地址',
+  `icon` varchar(128) NOT NULL DEFAULT '' COMMENT '菜单图标',
+  `extra_icon` varchar(128) NOT NULL DEFAULT '' COMMENT '右侧额外图标',
+  `enter_transition` varchar(64) NOT NULL DEFAULT '' COMMENT '进场动画',
+  `leave_transition` varchar(64) NOT NULL DEFAULT '' COMMENT '离场动画',
+  `active_path` varchar(255) NOT NULL DEFAULT '' COMMENT '激活菜单的 path',
+  `auths` varchar(255) NOT NULL DEFAULT '' COMMENT '权限标识（按钮级别权限）',
+  `frame_src` varchar(255) NOT NULL DEFAULT '' COMMENT 'iframe 链接地址',
+  `frame_loading` tinyint NOT NULL DEFAULT 1 COMMENT 'iframe 页面是否首次加载显示动画',
+  `keep_alive` tinyint NOT NULL DEFAULT 0 COMMENT '是否缓存该页面',
+  `hidden_tag` tinyint NOT NULL DEFAULT 0 COMMENT '是否禁止添加到标签页',
+  `fixed_tag` tinyint NOT NULL DEFAULT 0 COMMENT '是否固定在标签页中',
+  `show_link` tinyint NOT NULL DEFAULT 1 COMMENT '是否在菜单中显示该项',
+  `show_parent` tinyint NOT NULL DEFAULT 0 COMMENT '是否显示父级菜单',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '修改时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_parent_id` (`parent_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='菜单';
+
+CREATE TABLE IF NOT EXISTS `role_menu` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID',
+  `menu_id` bigint unsigned NOT NULL COMMENT '菜单ID',
+  `created_at` datetime NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_role_menu` (`role_id`,`menu_id`),
+  KEY `idx_menu_id` (`menu_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='角色菜单关联';
+
+CREATE TABLE IF NOT EXISTS `dict` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `title` varchar(64) NOT NULL COMMENT '字典名称',
+  `dict_type` varchar(64) NOT NULL COMMENT '字典类型（如 sex、status、job_type）',
+  `dict_label` varchar(64) NOT NULL COMMENT '字典标签（如 男、启用）',
+  `dict_value` varchar(64) NOT NULL COMMENT '字典值（如 1、enabled）',
+  `sort` int NOT NULL DEFAULT 0 COMMENT '排序值（升序）',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态（1启用，0禁用）',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注说明',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_dict_type_value` (`dict_type`,`dict_value`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='字典';
+
+CREATE TABLE IF NOT EXISTS `attachment` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `file_name` varchar(255) NOT NULL COMMENT '文件名',
+  `original_name` varchar(255) NOT NULL DEFAULT '' COMMENT '原始文件名',
+  `file_size` bigint unsigned NOT NULL DEFAULT 0 COMMENT '文件大小（字节）',
+  `file_type` varchar(128) NOT NULL DEFAULT '' COMMENT '文件类型（MIME）',
+  `file_ext` varchar(32) NOT NULL DEFAULT '' COMMENT '文件扩展名',
+  `file_path` varchar(512) NOT NULL DEFAULT '' COMMENT '文件路径',
+  `file_url` varchar(512) NOT NULL DEFAULT '' COMMENT '文件URL',
+  `is_image` tinyint NOT NULL DEFAULT 0 COMMENT '是否为图片',
+  `thumbnail_url` varchar(512) NOT NULL DEFAULT '' COMMENT '缩略图URL',
+  `uploader_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '上传者ID',
+  `uploader_name` varchar(64) NOT NULL DEFAULT '' COMMENT '上传者名称',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态（1正常，0禁用/删除）',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',
+  `created_at` datetime NULL COMMENT '上传时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_uploader_id` (`uploader_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='附件';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `table_migration`;
+DROP TABLE IF EXISTS `code_gen_record`;
@@ -0,0 +1,28 @@
+-- 代码生成相关表：生成记录、表结构变更记录
+
+CREATE TABLE IF NOT EXISTS `code_gen_record` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `table_name` varchar(64) NOT NULL COMMENT '数据表名称',
+  `table_comment` varchar(255) NOT NULL DEFAULT '' COMMENT '表注释',
+  `package_name` varchar(64) NOT NULL DEFAULT '' COMMENT '生成的Go包名',
+  `module_name` varchar(64) NOT NULL DEFAULT '' COMMENT '模块名（例如 system、user）',
+  `options` text NULL COMMENT '配置选项',
+  `columns` mediumtext NULL COMMENT '表字段',
+  `status` tinyint NOT NULL DEFAULT 0 COMMENT '生成状态（1成功，0失败）代码是否已生成',
+  `created_at` datetime NULL COMMENT '生成时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_table_name` (`table_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='代码生成记录';
+
+CREATE TABLE IF NOT EXISTS `table_migration` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `table_name` varchar(64) NOT NULL COMMENT '数据表名称',
+  `action` varchar(16) NOT NULL COMMENT '变更类型（create建表，alter修改表结构，sql执行SQL）',
+  `statement` text NOT NULL COMMENT '执行的SQL语句',
+  `definition` json NULL COMMENT '表结构定义（JSON）',
+  `operator_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '操作人ID',
+  `created_at` datetime NULL COMMENT '执行时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_table_name` (`table_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='表结构变更记录';
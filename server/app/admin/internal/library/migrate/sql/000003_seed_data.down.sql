@@ -0,0 +1,12 @@
+-- 删除初始数据，按唯一键匹配，不影响后续新增的数据
+
+DELETE FROM `dict` WHERE (`dict_type`, `dict_value`) IN (
+  ('sys_status', '1'), ('sys_status', '0'),
+  ('sys_sex', '0'), ('sys_sex', '1'), ('sys_sex', '2'),
+  ('sys_yes_no', '1'), ('sys_yes_no', '0')
+);
+DELETE FROM `role_menu` WHERE `role_id` = 1 AND `menu_id` BETWEEN 1 AND 10;
+DELETE FROM `menu` WHERE `id` BETWEEN 1 AND 10;
+DELETE FROM `user_role` WHERE `user_id` = 1 AND `role_id` = 1;
+DELETE FROM `user` WHERE `id` = 1 AND `username` = 'admin';
+DELETE FROM `role` WHERE `id` = 1 AND `code` = 'developer';
@@ -0,0 +1,34 @@
+-- 初始数据：默认管理员（admin / admin123，登录后请立即修改密码）、基础菜单、常用字典
+-- 使用 INSERT IGNORE，已存在的数据不会被覆盖
+
+INSERT IGNORE INTO `role` (`id`, `name`, `code`, `status`, `remark`, `created_at`, `updated_at`) VALUES
+  (1, '超级管理员', 'developer', 1, '拥有全部菜单与按钮权限', NOW(), NOW());
+
+INSERT IGNORE INTO `user` (`id`, `nickname`, `username`, `password`, `status`, `remark`, `created_at`, `updated_at`) VALUES
+  (1, '管理员', 'admin', '$2a$10$gHR8SAdCoznVnRd/gbpHwOQN9KoirzspT4Ii.9u1golfP2Ix1.JtC', 1, '默认管理员', NOW(), NOW());
+
+INSERT IGNORE INTO `user_role` (`user_id`, `role_id`, `created_at`) VALUES (1, 1, NOW());
+
+INSERT IGNORE INTO `menu` (`id`, `menu_type`, `parent_id`, `title`, `name`, `path`, `component`, `rank`, `icon`, `active_path`, `keep_alive`, `show_link`, `created_at`, `updated_at`) VALUES
+  (1, 0, 0, '系统管理', 'System', '/system', '', 10, 'ri/settings-3-line', '', 0, 1, NOW(), NOW()),
+  (2, 0, 1, '用户管理', 'SystemUser', '/system/user', 'system/user/index', 1, 'ri/admin-line', '', 1, 1, NOW(), NOW()),
+  (3, 0, 1, '角色管理', 'SystemRole', '/system/role', 'system/role/index', 2, 'ri/admin-fill', '', 1, 1, NOW(), NOW()),
+  (4, 0, 1, '菜单管理', 'SystemMenu', '/system/menu', 'system/menu/index', 3, 'ep/menu', '', 1, 1, NOW(), NOW()),
+  (5, 0, 1, '部门管理', 'SystemDept', '/system/dept', 'system/dept/index', 4, 'ri/git-branch-line', '', 1, 1, NOW(), NOW()),
+  (6, 0, 1, '附件管理', 'AttachmentManagement', '/system/attachment', 'system/attachment/index', 5, 'ri/attachment-2', '', 1, 1, NOW(), NOW()),
+  (7, 0, 0, '开发工具', 'Develop', '/develop', '', 20, 'ri/code-box-line', '', 0, 1, NOW(), NOW()),
+  (8, 0, 7, '代码生成', 'CodeGenerate', '/develop/generate', 'develop/generate/index', 1, 'ri/terminal-window-line', '', 1, 1, NOW(), NOW()),
+  (9, 0, 7, '生成配置', 'CodeConfig', '/develop/code', 'develop/code/index', 2, '', '/develop/generate', 0, 0, NOW(), NOW()),
+  (10, 0, 7, '字典管理', 'DictManage', '/develop/dict', 'develop/dict/index', 3, 'ri/book-2-line', '', 1, 1, NOW(), NOW());
+
+INSERT IGNORE INTO `role_menu` (`role_id`, `menu_id`, `created_at`)
+  SELECT 1, `id`, NOW() FROM `menu` WHERE `id` BETWEEN 1 AND 10;
+
+INSERT IGNORE INTO `dict` (`title`, `dict_type`, `dict_label`, `dict_value`, `sort`, `status`, `created_at`, `updated_at`) VALUES
+  ('状态', 'sys_status', '启用', '1', 1, 1, NOW(), NOW()),
+  ('状态', 'sys_status', '禁用', '0', 2, 1, NOW(), NOW()),
+  ('性别', 'sys_sex', '未知', '0', 1, 1, NOW(), NOW()),
+  ('性别', 'sys_sex', '男', '1', 2, 1, NOW(), NOW()),
+  ('性别', 'sys_sex', '女', '2', 3, 1, NOW(), NOW()),
+  ('是否', 'sys_yes_no', '是', '1', 1, 1, NOW(), NOW()),
+  ('是否', 'sys_yes_no', '否', '0', 2, 1, NOW(), NOW());
@@ -0,0 +1,4 @@
+ALTER TABLE `attachment`
+  DROP KEY `idx_file_path`,
+  DROP KEY `idx_file_hash`,
+  DROP COLUMN `file_hash`;
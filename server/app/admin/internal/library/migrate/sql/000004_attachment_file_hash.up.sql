@@ -0,0 +1,5 @@
+-- 附件按内容去重：记录文件内容的 SHA-256，相同内容的附件共用同一个存储文件
+ALTER TABLE `attachment`
+  ADD COLUMN `file_hash` char(64) NOT NULL DEFAULT '' COMMENT '文件内容SHA-256' AFTER `file_url`,
+  ADD KEY `idx_file_hash` (`file_hash`),
+  ADD KEY `idx_file_path` (`file_path`(191));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `upload_part`;
+DROP TABLE IF EXISTS `upload_session`;
@@ -0,0 +1,30 @@
+-- 分片上传：上传会话与已上传的分片，分片内容保存在存储的 chunks/<上传ID>/ 目录下
+
+CREATE TABLE IF NOT EXISTS `upload_session` (
+  `id` char(32) NOT NULL COMMENT '上传ID',
+  `file_name` varchar(255) NOT NULL DEFAULT '' COMMENT '原始文件名',
+  `file_size` bigint unsigned NOT NULL DEFAULT 0 COMMENT '文件大小（字节）',
+  `file_type` varchar(128) NOT NULL DEFAULT '' COMMENT '文件类型（MIME）',
+  `file_hash` char(64) NOT NULL DEFAULT '' COMMENT '文件内容SHA-256（可选，合并时校验）',
+  `chunk_size` int unsigned NOT NULL DEFAULT 0 COMMENT '分片大小（字节）',
+  `chunk_count` int unsigned NOT NULL DEFAULT 0 COMMENT '分片数量',
+  `name` varchar(255) NOT NULL DEFAULT '' COMMENT '自定义附件名称',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',
+  `status` tinyint NOT NULL DEFAULT 0 COMMENT '状态（0上传中，1合并中）',
+  `uploader_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '上传者ID',
+  `expires_at` datetime NOT NULL COMMENT '过期时间',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_uploader_hash` (`uploader_id`, `file_hash`),
+  KEY `idx_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='分片上传会话';
+
+CREATE TABLE IF NOT EXISTS `upload_part` (
+  `upload_id` char(32) NOT NULL COMMENT '上传ID',
+  `part_index` int unsigned NOT NULL COMMENT '分片序号（从0开始）',
+  `part_size` int unsigned NOT NULL DEFAULT 0 COMMENT '分片大小（字节）',
+  `checksum` char(64) NOT NULL DEFAULT '' COMMENT '分片内容SHA-256',
+  `created_at` datetime NULL COMMENT '上传时间',
+  PRIMARY KEY (`upload_id`, `part_index`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='分片上传的分片';
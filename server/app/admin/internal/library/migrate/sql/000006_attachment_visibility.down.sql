@@ -0,0 +1,7 @@
+ALTER TABLE `upload_session`
+  DROP COLUMN `access_roles`,
+  DROP COLUMN `is_private`;
+
+ALTER TABLE `attachment`
+  DROP COLUMN `access_roles`,
+  DROP COLUMN `is_private`;
@@ -0,0 +1,8 @@
+-- 私有附件：私有文件保存在存储的 private/ 目录下，不能通过静态地址访问，需通过签名的限时地址下载
+ALTER TABLE `attachment`
+  ADD COLUMN `is_private` tinyint NOT NULL DEFAULT 0 COMMENT '是否私有（1私有，0公开）' AFTER `thumbnail_url`,
+  ADD COLUMN `access_roles` varchar(255) NOT NULL DEFAULT '' COMMENT '允许访问的角色编码（逗号分隔，为空时不限制）' AFTER `is_private`;
+
+ALTER TABLE `upload_session`
+  ADD COLUMN `is_private` tinyint NOT NULL DEFAULT 0 COMMENT '是否私有（1私有，0公开）' AFTER `remark`,
+  ADD COLUMN `access_roles` varchar(255) NOT NULL DEFAULT '' COMMENT '允许访问的角色编码（逗号分隔，为空时不限制）' AFTER `is_private`;
@@ -0,0 +1,11 @@
+ALTER TABLE `upload_session`
+  DROP COLUMN `folder_id`;
+
+ALTER TABLE `attachment`
+  DROP KEY `idx_folder_id`,
+  DROP COLUMN `folder_id`;
+
+DROP TABLE IF EXISTS `attachment_reference`;
+DROP TABLE IF EXISTS `attachment_tag_relation`;
+DROP TABLE IF EXISTS `attachment_tag`;
+DROP TABLE IF EXISTS `attachment_folder`;
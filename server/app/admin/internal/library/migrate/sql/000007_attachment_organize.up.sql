@@ -0,0 +1,46 @@
+-- 附件整理：文件夹（树形）、标签，以及记录业务数据使用附件情况的引用表，被引用的附件不能删除
+
+CREATE TABLE IF NOT EXISTS `attachment_folder` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `parent_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '上级文件夹ID（0为根目录）',
+  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '文件夹名称',
+  `sort` int NOT NULL DEFAULT 0 COMMENT '排序号',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_parent_name` (`parent_id`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='附件文件夹';
+
+CREATE TABLE IF NOT EXISTS `attachment_tag` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `name` varchar(32) NOT NULL DEFAULT '' COMMENT '标签名称',
+  `created_at` datetime NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='附件标签';
+
+CREATE TABLE IF NOT EXISTS `attachment_tag_relation` (
+  `attachment_id` bigint unsigned NOT NULL COMMENT '附件ID',
+  `tag_id` bigint unsigned NOT NULL COMMENT '标签ID',
+  PRIMARY KEY (`attachment_id`, `tag_id`),
+  KEY `idx_tag_id` (`tag_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='附件与标签关联';
+
+CREATE TABLE IF NOT EXISTS `attachment_reference` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `attachment_id` bigint unsigned NOT NULL COMMENT '附件ID',
+  `biz_type` varchar(64) NOT NULL DEFAULT '' COMMENT '业务类型（业务表名）',
+  `biz_id` varchar(64) NOT NULL DEFAULT '' COMMENT '业务记录ID',
+  `biz_field` varchar(64) NOT NULL DEFAULT '' COMMENT '业务字段',
+  `created_at` datetime NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_attachment_biz` (`attachment_id`, `biz_type`, `biz_id`, `biz_field`),
+  KEY `idx_biz` (`biz_type`, `biz_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='附件引用';
+
+ALTER TABLE `attachment`
+  ADD COLUMN `folder_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '所属文件夹ID（0为根目录）' AFTER `access_roles`,
+  ADD KEY `idx_folder_id` (`folder_id`);
+
+ALTER TABLE `upload_session`
+  ADD COLUMN `folder_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '所属文件夹ID（0为根目录）' AFTER `access_roles`;
@@ -0,0 +1,4 @@
+ALTER TABLE `attachment`
+  DROP KEY `idx_created_at`;
+
+DROP TABLE IF EXISTS `attachment_quota`;
@@ -0,0 +1,16 @@
+-- 附件存储配额：按用户、部门单独设置的配额，未设置时使用 attachment.quota 配置的默认配额
+
+CREATE TABLE IF NOT EXISTS `attachment_quota` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `target_type` varchar(16) NOT NULL DEFAULT '' COMMENT '配额对象类型（user用户，department部门）',
+  `target_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '配额对象ID（用户ID或部门ID）',
+  `quota_size` bigint unsigned NOT NULL DEFAULT 0 COMMENT '配额大小（字节，0为不限制）',
+  `created_at` datetime NULL COMMENT '创建时间',
+  `updated_at` datetime NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_target` (`target_type`, `target_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='附件存储配额';
+
+-- 附件统计按上传时间统计每日上传量
+ALTER TABLE `attachment`
+  ADD KEY `idx_created_at` (`created_at`);
@@ -0,0 +1,9 @@
+-- 删除存储配额管理的按钮权限及其角色授权
+
+DELETE FROM `role_menu` WHERE `menu_id` IN (
+  SELECT `id` FROM (
+    SELECT `id` FROM `menu`
+    WHERE `menu_type` = 3 AND `auths` IN ('attachment:btn:quotaList', 'attachment:btn:quotaSet', 'attachment:btn:quotaDelete')
+  ) m
+);
+DELETE FROM `menu` WHERE `menu_type` = 3 AND `auths` IN ('attachment:btn:quotaList', 'attachment:btn:quotaSet', 'attachment:btn:quotaDelete');
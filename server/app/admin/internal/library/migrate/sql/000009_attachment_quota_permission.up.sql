@@ -0,0 +1,17 @@
+-- 存储配额管理的按钮权限：在附件管理菜单下创建按钮并授予超级管理员，其他角色需在角色管理中分配
+-- 相同权限标识的按钮已存在时不重复创建
+
+INSERT INTO `menu` (`menu_type`, `parent_id`, `title`, `name`, `auths`, `rank`, `show_link`, `created_at`, `updated_at`)
+  SELECT 3, p.`id`, b.`title`, b.`name`, b.`auths`, b.`rank`, 0, NOW(), NOW()
+  FROM `menu` p
+  CROSS JOIN (
+    SELECT '查询存储配额' AS `title`, 'AttachmentManagementQuotaList' AS `name`, 'attachment:btn:quotaList' AS `auths`, 1 AS `rank`
+    UNION ALL SELECT '设置存储配额', 'AttachmentManagementQuotaSet', 'attachment:btn:quotaSet', 2
+    UNION ALL SELECT '删除存储配额', 'AttachmentManagementQuotaDelete', 'attachment:btn:quotaDelete', 3
+  ) b
+  WHERE p.`name` = 'AttachmentManagement'
+    AND NOT EXISTS (SELECT 1 FROM (SELECT `auths` FROM `menu` WHERE `menu_type` = 3) m WHERE m.`auths` = b.`auths`);
+
+INSERT IGNORE INTO `role_menu` (`role_id`, `menu_id`, `created_at`)
+  SELECT 1, `id`, NOW() FROM `menu`
+  WHERE `menu_type` = 3 AND `auths` IN ('attachment:btn:quotaList', 'attachment:btn:quotaSet', 'attachment:btn:quotaDelete');
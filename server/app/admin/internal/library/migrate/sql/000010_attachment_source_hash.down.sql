@@ -0,0 +1,3 @@
+ALTER TABLE `attachment`
+  DROP KEY `idx_source_hash`,
+  DROP COLUMN `source_hash`;
@@ -0,0 +1,8 @@
+-- 记录上传文件的原始内容 SHA-256：图片保存前会去除 EXIF 等元数据，file_hash 为保存内容的哈希，
+-- 秒传时浏览器计算的是原始文件的哈希，需按 source_hash 匹配
+ALTER TABLE `attachment`
+  ADD COLUMN `source_hash` char(64) NOT NULL DEFAULT '' COMMENT '上传文件的原始内容SHA-256' AFTER `file_hash`,
+  ADD KEY `idx_source_hash` (`source_hash`);
+
+-- 已有附件的原始内容无法还原，使用保存内容的哈希
+UPDATE `attachment` SET `source_hash` = `file_hash` WHERE `source_hash` = '';
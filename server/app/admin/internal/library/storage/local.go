@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalConfig 本地文件系统配置
+type LocalConfig struct {
+	Root    string `json:"root"`    // 存储根目录，默认 uploads
+	BaseUrl string `json:"baseUrl"` // 访问地址前缀，默认 /uploads，由服务的静态目录提供访问
+}
+
+// local 本地文件系统存储
+type local struct {
+	config LocalConfig
+}
+
+// WithDefaults 返回补全默认值后的配置
+func (c LocalConfig) WithDefaults() LocalConfig {
+	if c.Root == "" {
+		c.Root = "uploads"
+	}
+	if c.BaseUrl == "" {
+		c.BaseUrl = "/uploads"
+	}
+	return c
+}
+
+// NewLocal 创建本地文件系统存储
+func NewLocal(config LocalConfig) Driver {
+	return &local{config: config.WithDefaults()}
+}
+
+// Name 驱动名称
+func (d *local) Name() string {
+	return DriverLocal
+}
+
+// filePath 对象 key 对应的文件路径
+func (d *local) filePath(key string) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.config.Root, filepath.FromSlash(key)), nil
+}
+
+// Put 保存对象，先写入临时文件再重命名，避免读取到写了一半的文件
+func (d *local) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	name, err := d.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, contextReader{ctx: ctx, reader: reader})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("写入文件失败: %v", err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("写入文件失败: 内容长度 %d 与声明的 %d 不一致", written, size)
+	}
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("保存文件失败: %v", err)
+	}
+	return nil
+}
+
+// Get 读取对象
+func (d *local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := d.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开文件失败: %v", err)
+	}
+	return file, nil
+}
+
+// GetRange 从 offset 开始读取 length 字节，length 小于 0 时读取到末尾
+func (d *local) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	reader, err := d.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	file := reader.(*os.File)
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("读取文件失败: %v", err)
+	}
+	if length < 0 {
+		return file, nil
+	}
+	return limitedReadCloser{Reader: io.LimitReader(file, length), Closer: file}, nil
+}
+
+// Stat 读取对象信息
+func (d *local) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	name, err := d.filePath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(name)
+	if os.IsNotExist(err) || err == nil && info.IsDir() {
+		return ObjectInfo{}, ErrNotExist
+	}
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("读取文件信息失败: %v", err)
+	}
+	return ObjectInfo{Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete 删除对象
+func (d *local) Delete(ctx context.Context, key string) error {
+	name, err := d.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除文件失败: %v", err)
+	}
+	return nil
+}
+
+// Exists 判断对象是否存在
+func (d *local) Exists(ctx context.Context, key string) (bool, error) {
+	name, err := d.filePath(key)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(name)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("读取文件信息失败: %v", err)
+	}
+	return !info.IsDir(), nil
+}
+
+// URL 对象的访问地址
+func (d *local) URL(key string) string {
+	return joinURL(d.config.BaseUrl, key)
+}
+
+// List 遍历 prefix 目录下的所有文件，包含写入中断残留的临时文件
+func (d *local) List(ctx context.Context, prefix string, fn func(key string, info ObjectInfo) error) error {
+	dir := d.config.Root
+	if prefix != "" {
+		var err error
+		if dir, err = d.filePath(prefix); err != nil {
+			return err
+		}
+	}
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(d.config.Root, name)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel), ObjectInfo{Size: info.Size(), ModTime: info.ModTime()})
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// contextReader 在 ctx 取消后停止读取
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// Read 读取内容
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryS3 内存中的 S3 兼容服务，仅支持路径形式访问的对象上传、读取（含范围读取）、删除、查询与列出（ListObjectsV2），会校验请求签名
+// 用于在没有 MinIO 等对象存储服务的开发与测试环境中替代 S3，可配合 httptest.NewServer 使用
+type MemoryS3 struct {
+	config  S3Config
+	mu      sync.RWMutex
+	objects map[string]memoryObject // 存储桶/对象 key -> 对象
+}
+
+// memoryObject 内存中的对象
+type memoryObject struct {
+	data               []byte
+	contentType        string
+	contentDisposition string
+	modifiedAt         time.Time
+}
+
+// memoryS3Error S3 错误响应
+type memoryS3Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+// NewMemoryS3 创建内存中的 S3 兼容服务，只接受使用指定密钥签名的请求
+func NewMemoryS3(accessKey, secretKey, region string) *MemoryS3 {
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &MemoryS3{
+		config:  S3Config{AccessKey: accessKey, SecretKey: secretKey, Region: region},
+		objects: make(map[string]memoryObject),
+	}
+}
+
+// ServeHTTP 处理 S3 请求
+func (m *MemoryS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if code, message := m.verify(r); code != "" {
+		writeMemoryS3Error(w, http.StatusForbidden, code, message)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if bucket, key, _ := strings.Cut(name, "/"); key == "" && r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2" {
+		m.list(w, r, bucket)
+		return
+	}
+	if !strings.Contains(name, "/") {
+		writeMemoryS3Error(w, http.StatusNotImplemented, "NotImplemented", "仅支持对象操作")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeMemoryS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		m.mu.Lock()
+		m.objects[name] = memoryObject{
+			data:               data,
+			contentType:        r.Header.Get("Content-Type"),
+			contentDisposition: r.Header.Get("Content-Disposition"),
+			modifiedAt:         time.Now(),
+		}
+		m.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		m.mu.RLock()
+		object, ok := m.objects[name]
+		m.mu.RUnlock()
+		if !ok {
+			writeMemoryS3Error(w, http.StatusNotFound, "NoSuchKey", "对象不存在")
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		if object.contentDisposition != "" {
+			w.Header().Set("Content-Disposition", object.contentDisposition)
+		}
+		// 支持 Range 请求
+		http.ServeContent(w, r, "", object.modifiedAt, bytes.NewReader(object.data))
+	case http.MethodDelete:
+		m.mu.Lock()
+		delete(m.objects, name)
+		m.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMemoryS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "不支持的请求方法")
+	}
+}
+
+// memoryS3ListResult ListObjectsV2 响应
+type memoryS3ListResult struct {
+	XMLName               xml.Name             `xml:"ListBucketResult"`
+	Name                  string               `xml:"Name"`
+	Prefix                string               `xml:"Prefix"`
+	KeyCount              int                  `xml:"KeyCount"`
+	IsTruncated           bool                 `xml:"IsTruncated"`
+	NextContinuationToken string               `xml:"NextContinuationToken,omitempty"`
+	Contents              []memoryS3ListObject `xml:"Contents"`
+}
+
+// memoryS3ListObject 对象列表中的对象
+type memoryS3ListObject struct {
+	Key          string `xml:"Key"`
+	Size         int    `xml:"Size"`
+	LastModified string `xml:"LastModified"`
+}
+
+// list 按 key 顺序列出存储桶中 prefix 开头的对象，每页最多 max-keys（默认 1000）个，continuation-token 为上一页最后一个对象的 key
+func (m *MemoryS3) list(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	after := query.Get("continuation-token")
+	maxKeys := 1000
+	if value, err := strconv.Atoi(query.Get("max-keys")); err == nil && value > 0 && value < maxKeys {
+		maxKeys = value
+	}
+
+	m.mu.RLock()
+	keys := make([]string, 0)
+	for name := range m.objects {
+		if key, ok := strings.CutPrefix(name, bucket+"/"); ok && strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := memoryS3ListResult{Name: bucket, Prefix: prefix}
+	for _, key := range keys {
+		if len(result.Contents) == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Contents[len(result.Contents)-1].Key
+			break
+		}
+		object := m.objects[bucket+"/"+key]
+		result.Contents = append(result.Contents, memoryS3ListObject{
+			Key:          key,
+			Size:         len(object.data),
+			LastModified: object.modifiedAt.UTC().Format(time.RFC3339Nano),
+		})
+	}
+	m.mu.RUnlock()
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// verify 校验请求的 AWS Signature Version 4 签名，校验失败时返回错误码与错误信息
+func (m *MemoryS3) verify(r *http.Request) (string, string) {
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	fields := make(map[string]string)
+	for _, field := range strings.Split(auth, ",") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(field), "="); ok {
+			fields[name] = value
+		}
+	}
+	if fields["Credential"] == "" || fields["SignedHeaders"] == "" || fields["Signature"] == "" {
+		return "AccessDenied", "缺少签名信息"
+	}
+	if accessKey, _, _ := strings.Cut(fields["Credential"], "/"); accessKey != m.config.AccessKey {
+		return "InvalidAccessKeyId", "访问密钥不存在"
+	}
+	now, err := time.Parse(s3DateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return "AccessDenied", "X-Amz-Date 不合法"
+	}
+
+	headers := make(map[string]string)
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		if name == "host" {
+			headers[name] = r.Host
+		} else {
+			headers[name] = strings.TrimSpace(strings.Join(r.Header.Values(name), ","))
+		}
+	}
+	_, signature := s3Signature(m.config, r.Method, r.URL.EscapedPath(), r.URL.Query(), headers, now)
+	if signature != fields["Signature"] {
+		return "SignatureDoesNotMatch", "签名不匹配"
+	}
+	return "", ""
+}
+
+// writeMemoryS3Error 输出 S3 错误响应
+func writeMemoryS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(memoryS3Error{Code: code, Message: message})
+}
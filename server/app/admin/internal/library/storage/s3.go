@@ -0,0 +1,410 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD" // 不对请求体签名，上传时无需预先计算内容的哈希
+	s3DateFormat      = "20060102T150405Z" // X-Amz-Date 时间格式
+)
+
+// S3Config S3 兼容对象存储配置
+type S3Config struct {
+	Endpoint  string `json:"endpoint"`  // 服务地址，如 https://s3.amazonaws.com、http://localhost:9000
+	Region    string `json:"region"`    // 区域，默认 us-east-1
+	Bucket    string `json:"bucket"`    // 存储桶
+	AccessKey string `json:"accessKey"` // 访问密钥 ID
+	SecretKey string `json:"secretKey"` // 访问密钥
+	PathStyle bool   `json:"pathStyle"` // 使用路径形式访问存储桶（{endpoint}/{bucket}/{key}），MinIO 需要开启
+	BaseUrl   string `json:"baseUrl"`   // 访问地址前缀，如 CDN 地址，为空时使用存储桶地址
+}
+
+// s3 S3 兼容对象存储，使用 AWS Signature Version 4 签名
+type s3 struct {
+	config   S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3 创建 S3 兼容对象存储
+func NewS3(config S3Config) (Driver, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("s3 存储需要配置服务地址与存储桶")
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("s3 存储需要配置访问密钥")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(config.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3 服务地址不合法: %s", config.Endpoint)
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	return &s3{config: config, endpoint: endpoint, client: &http.Client{}}, nil
+}
+
+// Name 驱动名称
+func (d *s3) Name() string {
+	return DriverS3
+}
+
+// objectURL 对象在存储服务中的地址
+func (d *s3) objectURL(key string) *url.URL {
+	u := *d.endpoint
+	if d.config.PathStyle {
+		u.Path = d.endpoint.Path + "/" + d.config.Bucket + "/" + key
+	} else {
+		u.Host = d.config.Bucket + "." + d.endpoint.Host
+		u.Path = d.endpoint.Path + "/" + key
+	}
+	u.RawPath = escapePath(u.Path)
+	return &u
+}
+
+// do 规范化对象 key 后签名并发送对象请求
+func (d *s3) do(ctx context.Context, method, key string, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.send(ctx, method, d.objectURL(key), body, size, header)
+}
+
+// send 签名并向指定地址发送请求
+func (d *s3) send(ctx context.Context, method string, u *url.URL, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	d.sign(req, time.Now().UTC())
+	return d.client.Do(req)
+}
+
+// sign 按 AWS Signature Version 4 为请求添加 Authorization 头
+func (d *s3) sign(req *http.Request, now time.Time) {
+	req.Header.Set("X-Amz-Date", now.Format(s3DateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	// 参与签名的请求头：host、content-type、content-disposition 与所有 x-amz- 开头的请求头
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" || lower == "content-disposition" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	signedHeaders, signature := s3Signature(d.config, req.Method, req.URL.EscapedPath(), req.URL.Query(), headers, now)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		d.config.AccessKey, s3Scope(d.config, now), signedHeaders, signature,
+	))
+}
+
+// s3Scope 签名范围
+func s3Scope(config S3Config, now time.Time) string {
+	return now.Format("20060102") + "/" + config.Region + "/s3/aws4_request"
+}
+
+// s3Signature 计算 AWS Signature Version 4 签名，headers 为参与签名的请求头（名称小写），返回签名的请求头列表与签名
+func s3Signature(config S3Config, method, escapedPath string, query url.Values, headers map[string]string, now time.Time) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	payloadHash := headers["x-amz-content-sha256"]
+	if payloadHash == "" {
+		payloadHash = s3UnsignedPayload
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		canonicalQuery(query),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := "AWS4-HMAC-SHA256\n" + now.Format(s3DateFormat) + "\n" + s3Scope(config, now) + "\n" + sha256Hex(canonicalRequest)
+
+	key := hmacSHA256([]byte("AWS4"+config.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalQuery 按参数名排序并编码查询参数
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// Put 保存对象
+func (d *s3) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	if !Inline(contentType) {
+		header.Set("Content-Disposition", "attachment")
+	}
+	resp, err := d.do(ctx, http.MethodPut, key, reader, size, header)
+	if err != nil {
+		return fmt.Errorf("上传对象失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("上传对象失败: %s", s3Error(resp))
+	}
+	return nil
+}
+
+// Get 读取对象
+func (d *s3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := d.do(ctx, http.MethodGet, key, nil, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("读取对象失败: %v", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotExist
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("读取对象失败: %s", s3Error(resp))
+	}
+}
+
+// GetRange 从 offset 开始读取 length 字节，length 小于 0 时读取到末尾
+func (d *s3) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	header := http.Header{}
+	if length < 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	} else if length > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	resp, err := d.do(ctx, http.MethodGet, key, nil, 0, header)
+	if err != nil {
+		return nil, fmt.Errorf("读取对象失败: %v", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp.Body, nil
+	case http.StatusOK:
+		// 服务不支持范围读取时跳过 offset 之前的内容
+		if _, err = io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("读取对象失败: %v", err)
+		}
+		if length < 0 {
+			return resp.Body, nil
+		}
+		return limitedReadCloser{Reader: io.LimitReader(resp.Body, length), Closer: resp.Body}, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotExist
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("读取对象失败: %s", s3Error(resp))
+	}
+}
+
+// Stat 读取对象信息
+func (d *s3) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	resp, err := d.do(ctx, http.MethodHead, key, nil, 0, nil)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("读取对象信息失败: %v", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		info := ObjectInfo{Size: resp.ContentLength}
+		if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			info.ModTime = modTime
+		}
+		return info, nil
+	case http.StatusNotFound:
+		return ObjectInfo{}, ErrNotExist
+	default:
+		return ObjectInfo{}, fmt.Errorf("读取对象信息失败: 状态码 %d", resp.StatusCode)
+	}
+}
+
+// Delete 删除对象
+func (d *s3) Delete(ctx context.Context, key string) error {
+	resp, err := d.do(ctx, http.MethodDelete, key, nil, 0, nil)
+	if err != nil {
+		return fmt.Errorf("删除对象失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("删除对象失败: %s", s3Error(resp))
+	}
+	return nil
+}
+
+// Exists 判断对象是否存在
+func (d *s3) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := d.do(ctx, http.MethodHead, key, nil, 0, nil)
+	if err != nil {
+		return false, fmt.Errorf("读取对象信息失败: %v", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("读取对象信息失败: 状态码 %d", resp.StatusCode)
+	}
+}
+
+// URL 对象的访问地址，配置了 BaseUrl 时使用 BaseUrl
+func (d *s3) URL(key string) string {
+	if d.config.BaseUrl != "" {
+		return joinURL(d.config.BaseUrl, key)
+	}
+	return d.objectURL(key).String()
+}
+
+// s3ListResult ListObjectsV2 的响应
+type s3ListResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List 使用 ListObjectsV2 分页遍历 prefix 目录下的所有对象
+func (d *s3) List(ctx context.Context, prefix string, fn func(key string, info ObjectInfo) error) error {
+	query := url.Values{"list-type": {"2"}}
+	if prefix != "" {
+		prefix, err := CleanKey(prefix)
+		if err != nil {
+			return err
+		}
+		query.Set("prefix", prefix+"/")
+	}
+	for {
+		u := d.objectURL("")
+		u.RawQuery = query.Encode()
+		result, err := d.list(ctx, u)
+		if err != nil {
+			return err
+		}
+		for _, object := range result.Contents {
+			if err = fn(object.Key, ObjectInfo{Size: object.Size, ModTime: object.LastModified}); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// list 请求一页对象列表
+func (d *s3) list(ctx context.Context, u *url.URL) (*s3ListResult, error) {
+	resp, err := d.send(ctx, http.MethodGet, u, nil, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("列出对象失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("列出对象失败: %s", s3Error(resp))
+	}
+	result := &s3ListResult{}
+	if err = xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("解析对象列表失败: %v", err)
+	}
+	return result, nil
+}
+
+// s3Error 读取错误响应中的错误信息
+func s3Error(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("状态码 %d %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// escapePath 按 RFC 3986 编码路径，保留 / 分隔符
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// uriEncode 按 RFC 3986 编码，除字母、数字与 -_.~ 外的字符均编码
+func uriEncode(s string) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			builder.WriteByte(c)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", c)
+		}
+	}
+	return builder.String()
+}
+
+// sha256Hex 返回内容的 SHA-256 十六进制摘要
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
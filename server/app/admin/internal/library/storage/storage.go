@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// 支持的存储驱动
+const (
+	DriverLocal = "local" // 本地文件系统
+	DriverS3    = "s3"    // S3 兼容对象存储，如 AWS S3、MinIO、阿里云 OSS、腾讯云 COS
+)
+
+// ErrNotExist 对象不存在
+var ErrNotExist = errors.New("对象不存在")
+
+// inlineTypes 可以在浏览器中直接打开的内容类型，其他类型的文件访问时作为附件下载，避免 HTML、SVG 等文件中的脚本在站点域名下执行
+var inlineTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"application/pdf": true,
+}
+
+// Inline 判断内容类型的文件是否可以在浏览器中直接打开
+func Inline(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return inlineTypes[strings.ToLower(strings.TrimSpace(mediaType))]
+}
+
+// Driver 存储驱动，对象以 key 标识，key 为使用 / 分隔的相对路径，如 2025/01/02/a.png
+type Driver interface {
+	// Name 驱动名称
+	Name() string
+	// Put 保存对象，size 为内容长度
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	// Get 读取对象，对象不存在时返回 ErrNotExist，调用方负责关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange 从 offset 开始读取 length 字节，length 小于 0 时读取到末尾，对象不存在时返回 ErrNotExist，调用方负责关闭
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Stat 读取对象信息，对象不存在时返回 ErrNotExist
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete 删除对象，对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+	// Exists 判断对象是否存在
+	Exists(ctx context.Context, key string) (bool, error)
+	// URL 对象的访问地址
+	URL(key string) string
+	// List 按 key 顺序遍历 prefix 目录下的所有对象，prefix 为空时遍历全部对象，fn 返回错误时停止遍历并返回该错误
+	List(ctx context.Context, prefix string, fn func(key string, info ObjectInfo) error) error
+}
+
+// ObjectInfo 对象信息
+type ObjectInfo struct {
+	Size    int64     // 内容长度
+	ModTime time.Time // 最后修改时间
+}
+
+// Config 存储配置，对应配置文件中的 storage 节点
+type Config struct {
+	Driver string      `json:"driver"` // 驱动：local、s3，为空时使用 local
+	Local  LocalConfig `json:"local"`  // 本地文件系统配置
+	S3     S3Config    `json:"s3"`     // S3 兼容对象存储配置
+}
+
+var (
+	defaultMu     sync.Mutex
+	defaultDriver Driver
+)
+
+// LoadConfig 读取配置文件中的存储配置
+func LoadConfig(ctx context.Context) (Config, error) {
+	var config Config
+	if err := g.Cfg().MustGet(ctx, "storage").Scan(&config); err != nil {
+		return config, fmt.Errorf("读取存储配置失败: %v", err)
+	}
+	if config.Driver == "" {
+		config.Driver = DriverLocal
+	}
+	return config, nil
+}
+
+// New 根据配置创建存储驱动
+func New(config Config) (Driver, error) {
+	switch strings.ToLower(config.Driver) {
+	case "", DriverLocal:
+		return NewLocal(config.Local), nil
+	case DriverS3:
+		return NewS3(config.S3)
+	default:
+		return nil, fmt.Errorf("不支持的存储驱动: %s", config.Driver)
+	}
+}
+
+// Default 返回按配置文件创建的存储驱动，首次调用时创建
+func Default(ctx context.Context) (Driver, error) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultDriver != nil {
+		return defaultDriver, nil
+	}
+
+	config, err := LoadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	defaultDriver = driver
+	return defaultDriver, nil
+}
+
+// CleanKey 规范化对象 key，拒绝空 key 与跳出根目录的 key
+func CleanKey(key string) (string, error) {
+	key = strings.ReplaceAll(key, "\\", "/")
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("对象 key 不合法: %s", key)
+		}
+	}
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" {
+		return "", fmt.Errorf("对象 key 不能为空")
+	}
+	return cleaned, nil
+}
+
+// joinURL 拼接访问地址前缀与对象 key
+func joinURL(baseUrl, key string) string {
+	return strings.TrimRight(baseUrl, "/") + "/" + escapePath(key)
+}
+
+// objectReader 按需分段读取对象的 io.ReadSeeker，Seek 后从新位置重新发起范围读取
+type objectReader struct {
+	ctx    context.Context
+	driver Driver
+	key    string
+	size   int64
+	offset int64
+	reader io.ReadCloser
+}
+
+// NewReadSeeker 创建读取对象的 io.ReadSeekCloser，size 为对象长度，用于 http.ServeContent 等需要随机访问的场景
+func NewReadSeeker(ctx context.Context, driver Driver, key string, size int64) io.ReadSeekCloser {
+	return &objectReader{ctx: ctx, driver: driver, key: key, size: size}
+}
+
+// Read 读取内容，首次读取或 Seek 后打开从当前位置到末尾的范围读取
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.reader == nil {
+		reader, err := r.driver.GetRange(r.ctx, r.key, r.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+	}
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+// Seek 设置读取位置
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("读取位置不能为负数")
+	}
+	if offset != r.offset {
+		r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+// Close 关闭当前的范围读取
+func (r *objectReader) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
+
+// limitedReadCloser 读取部分内容，关闭时关闭原始内容
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -1,22 +1,27 @@
 package attachment
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"mime/multipart"
-
+	"io"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/gogf/gf/v2/crypto/gmd5"
+	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
-	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/util/gconv"
 
 	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
 	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/filetype"
+	"server/app/admin/internal/library/storage"
 	"server/app/admin/internal/middleware"
 	"server/app/admin/internal/model/entity"
 )
@@ -43,6 +48,26 @@ func (s *sAttachment) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.
 	if req.IsImage != nil {
 		m = m.Where(dao.Attachment.Columns().IsImage, *req.IsImage)
 	}
+	if req.IsPrivate != nil {
+		m = m.Where(dao.Attachment.Columns().IsPrivate, *req.IsPrivate)
+	}
+	if req.FolderId != nil {
+		m = m.Where(dao.Attachment.Columns().FolderId, *req.FolderId)
+	}
+	if req.TagId != nil {
+		m = m.Where(dao.Attachment.Columns().Id+" IN (?)", dao.AttachmentTagRelation.Ctx(ctx).
+			Fields(dao.AttachmentTagRelation.Columns().AttachmentId).
+			Where(dao.AttachmentTagRelation.Columns().TagId, *req.TagId))
+	}
+	if req.Referenced != nil {
+		// 未被引用的附件可以清理
+		referenced := dao.AttachmentReference.Ctx(ctx).Fields(dao.AttachmentReference.Columns().AttachmentId)
+		if *req.Referenced {
+			m = m.Where(dao.Attachment.Columns().Id+" IN (?)", referenced)
+		} else {
+			m = m.Where(dao.Attachment.Columns().Id+" NOT IN (?)", referenced)
+		}
+	}
 
 	// 获取总数
 	total, err := m.Count()
@@ -64,12 +89,23 @@ func (s *sAttachment) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.
 	if err := gconv.Scan(list, &res.List); err != nil {
 		return nil, gerror.Wrap(err, "数据转换失败")
 	}
+	// 私有附件的文件地址不能直接访问，替换为签名下载地址
+	if err := s.signPrivateUrls(ctx, res.List); err != nil {
+		return nil, err
+	}
+	// 填充标签与引用次数
+	if err := s.fillTags(ctx, res.List); err != nil {
+		return nil, err
+	}
+	if err := s.fillRefCounts(ctx, res.List); err != nil {
+		return nil, err
+	}
 
 	res.CurrentPage = req.CurrentPage
 	return res, nil
 }
 
-// Upload 上传附件
+// Upload 上传附件，超出用户或所属部门的存储配额时拒绝上传
 func (s *sAttachment) Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error) {
 	res = &v1.UploadRes{}
 
@@ -80,56 +116,206 @@ func (s *sAttachment) Upload(ctx context.Context, req *v1.UploadReq) (res *v1.Up
 		return nil, gerror.New("请选择要上传的文件")
 	}
 
-	// 验证文件大小（限制为50MB）
-	maxSize := int64(50 * 1024 * 1024)
-	if file.Size > maxSize {
-		return nil, gerror.New("文件大小不能超过50MB")
-	}
-
-	// 验证文件类型安全性
-	if err = s.validateFileType(file.FileHeader); err != nil {
-		return nil, err
+	// 验证文件大小，更大的文件使用分片上传
+	if file.Size > consts.SingleUploadSizeMax {
+		return nil, gerror.New("文件大小不能超过50MB，更大的文件请使用分片上传")
 	}
 
 	// 获取文件信息
 	originalName := file.Filename
 	extension := strings.ToLower(filepath.Ext(originalName))
-	mimeType := file.Header.Get("Content-Type")
-	if mimeType == "" {
-		mimeType = s.getMimeTypeByExtension(extension)
+
+	// 根据文件内容识别文件类型，校验扩展名是否允许在上传场景中上传、是否与文件内容一致
+	f, err := file.Open()
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取上传文件失败")
+	}
+	defer f.Close()
+	reader := bufio.NewReader(f)
+	head, err := reader.Peek(filetype.SniffMaxSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, gerror.Wrap(err, "读取上传文件失败")
+	}
+	fileType, err := s.validateFile(ctx, req.Scene, originalName, head)
+	if err != nil {
+		return nil, err
+	}
+	mimeType := fileType.MIME
+	isImage := fileType.Category == filetype.CategoryImage
+	accessRoles, err := s.normalizeAccessRoles(ctx, req.AccessRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkFolder(ctx, req.FolderId); err != nil {
+		return nil, err
+	}
+	if err = s.checkQuota(ctx, uint64(file.Size)); err != nil {
+		return nil, err
 	}
 
-	// 生成文件名
-	fileName := s.generateFileName(originalName)
+	// 生成文件名，存储中始终使用唯一文件名
+	storedName := s.generateFileName(originalName)
+	fileName := storedName
 	if req.Name != nil && *req.Name != "" {
 		fileName = *req.Name + extension
 	}
 
-	// 确定文件分类
-	isImage := s.isImageFile(mimeType, extension)
+	// 保存文件到配置的存储，按日期分目录，同时计算文件内容的SHA-256（图片会先去除 EXIF 等元数据）
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	blob := entity.Attachment{
+		FilePath:  s.blobPath(storedName, req.IsPrivate),
+		FileSize:  uint64(file.Size),
+		FileType:  mimeType,
+		IsImage:   gconv.Int(isImage),
+		IsPrivate: req.IsPrivate,
+	}
+	content, err := s.storeBlob(ctx, store, reader, &blob)
+	if err != nil {
+		return nil, gerror.Wrap(err, "保存文件失败")
+	}
+	blob.FileUrl = store.URL(blob.FilePath)
+
+	// 已存在相同内容的文件时复用已有文件，删除刚上传的文件，否则为图片生成缩略图
+	existing, err := s.findBlob(ctx, store, blob.FileHash, blob.IsPrivate)
+	if err != nil {
+		s.removeFiles(ctx, blob)
+		return nil, err
+	}
+	if existing != nil {
+		s.removeFiles(ctx, blob)
+		sourceHash := blob.SourceHash
+		blob = *existing
+		blob.FileType = mimeType
+		blob.SourceHash = sourceHash
+	} else {
+		s.prepareThumbnail(ctx, store, &blob, content)
+	}
+	blob.AccessRoles = accessRoles
+	blob.FolderId = req.FolderId
 
-	// 创建上传目录
-	uploadDir := fmt.Sprintf("uploads/%s", time.Now().Format("2006/01/02"))
-	fmt.Println("上传目录", uploadDir)
-	if err = gfile.Mkdir(uploadDir); err != nil {
-		return nil, gerror.Wrap(err, "创建上传目录失败")
+	id, err := s.insert(ctx, blob, fileName, originalName, extension, req.Remark)
+	if err != nil {
+		// 删除已上传的文件（复用的文件仍被其他附件引用，不会被删除）
+		s.removeFiles(ctx, blob)
+		return nil, err
 	}
 
-	// 保存文件
-	filePath := filepath.Join(uploadDir)
-	if _, err = file.Save(filePath); err != nil {
-		return nil, gerror.Wrap(err, "保存文件失败")
+	res.Id = id
+	res.Url, err = s.fileUrl(ctx, id, blob)
+	if err != nil {
+		return nil, err
 	}
+	return res, nil
+}
 
-	// 生成访问URL
-	baseURL := g.Cfg().MustGet(ctx, "server.baseUrl", "http://localhost:8000").String()
-	fileURL := fmt.Sprintf("%s/%s", strings.TrimRight(baseURL, "/"), filePath)
+// InstantUpload 秒传：已存在相同内容的文件时直接创建附件，无需上传文件
+func (s *sAttachment) InstantUpload(ctx context.Context, req *v1.InstantUploadReq) (res *v1.InstantUploadRes, err error) {
+	res = &v1.InstantUploadRes{}
 
-	// 从上下文获取当前用户信息
-	var uploaderId uint64
-	var uploaderName string
+	// 验证文件扩展名是否允许在上传场景中上传
+	fileType, err := s.validateFileName(ctx, req.Scene, req.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	accessRoles, err := s.normalizeAccessRoles(ctx, req.AccessRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkFolder(ctx, req.FolderId); err != nil {
+		return nil, err
+	}
+	hash := strings.ToLower(req.Hash)
+	blob, err := s.findBlob(ctx, store, hash, req.IsPrivate)
+	if err != nil {
+		return nil, err
+	}
+	// 已有文件的内容类型与扩展名不一致时（如修改了扩展名）不能秒传，需上传文件校验内容
+	if blob == nil || blob.FileType != fileType.MIME {
+		return res, nil
+	}
+	blob.SourceHash = hash
+	if err = s.checkQuota(ctx, blob.FileSize); err != nil {
+		return nil, err
+	}
+
+	extension := strings.ToLower(filepath.Ext(req.FileName))
+	fileName := s.generateFileName(req.FileName)
+	if req.Name != nil && *req.Name != "" {
+		fileName = *req.Name + extension
+	}
+	blob.AccessRoles = accessRoles
+	blob.FolderId = req.FolderId
+	id, err := s.insert(ctx, *blob, fileName, req.FileName, extension, req.Remark)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Hit = true
+	res.Id = id
+	res.Url, err = s.fileUrl(ctx, id, *blob)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// findBlob 查找保存内容或原始内容的哈希为 hash、可见性相同且存储中文件仍然存在的附件，返回其文件信息，不存在时返回 nil
+// 图片保存前会去除元数据，按原始内容的哈希匹配时，上传相同的原始文件也能复用；私有与公开附件的文件保存在不同目录，不会互相复用
+func (s *sAttachment) findBlob(ctx context.Context, store storage.Driver, hash string, isPrivate int) (*entity.Attachment, error) {
+	var candidates []entity.Attachment
+	m := dao.Attachment.Ctx(ctx)
+	err := m.
+		Fields(
+			dao.Attachment.Columns().FilePath,
+			dao.Attachment.Columns().FileUrl,
+			dao.Attachment.Columns().FileHash,
+			dao.Attachment.Columns().FileSize,
+			dao.Attachment.Columns().FileType,
+			dao.Attachment.Columns().IsImage,
+			dao.Attachment.Columns().ThumbnailUrl,
+			dao.Attachment.Columns().IsPrivate,
+		).
+		Where(m.Builder().
+			Where(dao.Attachment.Columns().FileHash, hash).
+			WhereOr(dao.Attachment.Columns().SourceHash, hash)).
+		Where(dao.Attachment.Columns().IsPrivate, isPrivate).
+		WhereNot(dao.Attachment.Columns().FilePath, "").
+		OrderAsc(dao.Attachment.Columns().Id).
+		Scan(&candidates)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询相同内容的附件失败")
+	}
+
+	checked := make(map[string]bool)
+	for _, candidate := range candidates {
+		if checked[candidate.FilePath] {
+			continue
+		}
+		checked[candidate.FilePath] = true
+		exists, err := store.Exists(ctx, candidate.FilePath)
+		if err != nil {
+			return nil, gerror.Wrap(err, "检查文件是否存在失败")
+		}
+		if exists {
+			return &candidate, nil
+		}
+	}
+	return nil, nil
+}
 
+// insert 保存附件记录，blob 为附件引用的存储文件信息、可见性及所属文件夹
+func (s *sAttachment) insert(ctx context.Context, blob entity.Attachment, fileName, originalName, extension string, remark *string) (uint64, error) {
 	// 使用中间件提供的用户信息
+	var uploaderId uint64
+	var uploaderName string
 	if userID, ok := ctx.Value(middleware.CtxUserID).(uint64); ok {
 		uploaderId = userID
 	}
@@ -137,41 +323,34 @@ func (s *sAttachment) Upload(ctx context.Context, req *v1.UploadReq) (res *v1.Up
 		uploaderName = username
 	}
 
-	// 保存到数据库
 	data := g.Map{
 		dao.Attachment.Columns().FileName:     fileName,
 		dao.Attachment.Columns().OriginalName: originalName,
-		dao.Attachment.Columns().FilePath:     filePath,
-		dao.Attachment.Columns().FileUrl:      fileURL + "/" + originalName,
-		dao.Attachment.Columns().FileSize:     file.Size,
-		dao.Attachment.Columns().FileType:     mimeType,
+		dao.Attachment.Columns().FilePath:     blob.FilePath,
+		dao.Attachment.Columns().FileUrl:      blob.FileUrl,
+		dao.Attachment.Columns().FileHash:     blob.FileHash,
+		dao.Attachment.Columns().SourceHash:   blob.SourceHash,
+		dao.Attachment.Columns().FileSize:     blob.FileSize,
+		dao.Attachment.Columns().FileType:     blob.FileType,
 		dao.Attachment.Columns().FileExt:      extension,
-		dao.Attachment.Columns().IsImage:      gconv.Int(isImage),
+		dao.Attachment.Columns().IsImage:      blob.IsImage,
+		dao.Attachment.Columns().ThumbnailUrl: blob.ThumbnailUrl,
+		dao.Attachment.Columns().IsPrivate:    blob.IsPrivate,
+		dao.Attachment.Columns().AccessRoles:  blob.AccessRoles,
+		dao.Attachment.Columns().FolderId:     blob.FolderId,
 		dao.Attachment.Columns().UploaderId:   uploaderId,
 		dao.Attachment.Columns().UploaderName: uploaderName,
 		dao.Attachment.Columns().Status:       1, // 默认启用
 	}
-
-	if req.Remark != nil {
-		data[dao.Attachment.Columns().Remark] = *req.Remark
-	}
-
-	// 如果是图片，生成缩略图URL（可选）
-	if isImage {
-		thumbnailURL := s.generateThumbnailURL(fileURL)
-		data[dao.Attachment.Columns().ThumbnailUrl] = thumbnailURL
+	if remark != nil {
+		data[dao.Attachment.Columns().Remark] = *remark
 	}
 
 	id, err := dao.Attachment.Ctx(ctx).Data(data).InsertAndGetId()
 	if err != nil {
-		// 删除已上传的文件
-		gfile.Remove(filePath)
-		return nil, gerror.Wrap(err, "保存附件信息失败")
+		return 0, gerror.Wrap(err, "保存附件信息失败")
 	}
-
-	res.Id = uint64(id)
-	res.Url = fileURL
-	return res, nil
+	return uint64(id), nil
 }
 
 // Update 更新附件
@@ -232,22 +411,29 @@ func (s *sAttachment) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.De
 	if attachment.Id == 0 {
 		return nil, gerror.New("附件不存在")
 	}
+	// 被业务数据引用的附件不能删除
+	if err = s.checkUnreferenced(ctx, req.Id); err != nil {
+		return nil, err
+	}
 
-	// 删除数据库记录
-	_, err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Delete()
+	// 删除数据库记录及标签
+	err = dao.Attachment.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		_, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "删除附件记录失败")
+		}
+		_, err = dao.AttachmentTagRelation.Ctx(ctx).Where(dao.AttachmentTagRelation.Columns().AttachmentId, req.Id).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "删除附件标签失败")
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, gerror.Wrap(err, "删除附件记录失败")
+		return nil, err
 	}
 
 	// 删除物理文件
-	if attachment.FilePath != "" && gfile.Exists(attachment.FilePath) {
-		if err := gfile.Remove(attachment.FilePath); err != nil {
-			g.Log().Warning(ctx, "删除物理文件失败", g.Map{
-				"path":  attachment.FilePath,
-				"error": err,
-			})
-		}
-	}
+	s.removeFiles(ctx, attachment)
 
 	return res, nil
 }
@@ -267,31 +453,35 @@ func (s *sAttachment) BatchDelete(ctx context.Context, req *v1.BatchDeleteReq) (
 		return nil, gerror.Wrap(err, "查询附件信息失败")
 	}
 
-	// 删除数据库记录
-	_, err = dao.Attachment.Ctx(ctx).WhereIn(dao.Attachment.Columns().Id, req.Ids).Delete()
-	if err != nil {
-		return nil, gerror.Wrap(err, "批量删除附件记录失败")
+	// 被业务数据引用的附件不能删除
+	if err = s.checkUnreferenced(ctx, req.Ids...); err != nil {
+		return nil, err
 	}
 
-	// 删除物理文件
-	for _, attachment := range attachments {
-		if attachment.FilePath != "" && gfile.Exists(attachment.FilePath) {
-			if err := gfile.Remove(attachment.FilePath); err != nil {
-				g.Log().Warning(ctx, "删除物理文件失败", g.Map{
-					"path":  attachment.FilePath,
-					"error": err,
-				})
-			}
+	// 删除数据库记录及标签
+	err = dao.Attachment.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		_, err := dao.Attachment.Ctx(ctx).WhereIn(dao.Attachment.Columns().Id, req.Ids).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "批量删除附件记录失败")
 		}
+		_, err = dao.AttachmentTagRelation.Ctx(ctx).WhereIn(dao.AttachmentTagRelation.Columns().AttachmentId, req.Ids).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "删除附件标签失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// 删除物理文件
+	s.removeFiles(ctx, attachments...)
+
 	return res, nil
 }
 
-// Download 下载附件
+// Download 下载附件，直接将文件内容流式写入响应，支持 Range 断点续传与 ETag/Last-Modified 条件请求
 func (s *sAttachment) Download(ctx context.Context, req *v1.DownloadReq) (res *v1.DownloadRes, err error) {
-	res = &v1.DownloadRes{}
-
 	// 查询附件信息
 	var attachment entity.Attachment
 	err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Scan(&attachment)
@@ -302,73 +492,156 @@ func (s *sAttachment) Download(ctx context.Context, req *v1.DownloadReq) (res *v
 		return nil, gerror.New("附件不存在")
 	}
 
-	// 检查文件状态
+	// 检查文件状态与私有附件的访问权限
 	if attachment.Status != 1 {
 		return nil, gerror.New("文件已被禁用")
 	}
+	if err = s.checkAccess(ctx, &attachment); err != nil {
+		return nil, err
+	}
 
-	// 检查文件是否存在
-	if !gfile.Exists(attachment.FilePath) {
-		return nil, gerror.New("文件不存在")
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	// 文件按内容去重保存，内容哈希可作为强校验的 ETag
+	etag := ""
+	if attachment.FileHash != "" {
+		etag = `"` + attachment.FileHash + `"`
 	}
+	return nil, s.serveFile(ctx, store, attachment.FilePath, attachment.FileType, contentDisposition("attachment", attachment.OriginalName), etag)
+}
 
-	// 读取文件内容
-	content := gfile.GetBytes(attachment.FilePath)
-	if content == nil {
-		return nil, gerror.New("读取文件失败")
+// serveFile 将存储中的文件流式写入响应，支持 Range 请求与 ETag/Last-Modified 条件请求，etag 为空时不设置
+func (s *sAttachment) serveFile(ctx context.Context, store storage.Driver, key, contentType, disposition, etag string) error {
+	info, err := store.Stat(ctx, key)
+	if errors.Is(err, storage.ErrNotExist) {
+		return gerror.New("文件不存在")
+	}
+	if err != nil {
+		return gerror.Wrap(err, "读取文件信息失败")
 	}
 
-	res.FileName = attachment.OriginalName
-	res.Content = content
-	return res, nil
+	r := g.RequestFromCtx(ctx)
+	header := r.Response.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Disposition", disposition)
+	header.Set("X-Content-Type-Options", "nosniff")
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	content := storage.NewReadSeeker(ctx, store, key, info.Size)
+	defer content.Close()
+	r.Response.ServeContent("", info.ModTime, content)
+	// 304、HEAD 请求与空文件没有响应内容，标记响应已输出，避免统一响应中间件追加 JSON
+	r.ExitAll()
+	return nil
 }
 
-// validateFileType 验证文件类型安全性
-func (s *sAttachment) validateFileType(file *multipart.FileHeader) error {
-	// 危险文件扩展名黑名单
-	dangerousExts := []string{".exe", ".bat", ".cmd", ".com", ".pif", ".scr", ".vbs", ".js", ".jar", ".php", ".asp", ".jsp"}
-	ext := strings.ToLower(filepath.Ext(file.Filename))
+// contentDisposition 下载文件的 Content-Disposition，disposition 为 attachment 或 inline，
+// filename 为仅含 ASCII 的兼容文件名，filename* 为 RFC 5987 编码的原始文件名
+func contentDisposition(disposition, fileName string) string {
+	fallback := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, fileName)
+	return fmt.Sprintf(`%s; filename="%s"; filename*=UTF-8''%s`, disposition, fallback, url.PathEscape(fileName))
+}
 
-	for _, dangerousExt := range dangerousExts {
-		if ext == dangerousExt {
-			return gerror.New("不允许上传此类型的文件")
+// removeFiles 从存储中删除不再被任何附件引用的文件及其图片规格，需在删除附件记录后调用，删除失败仅记录日志
+// 相同内容的附件共用同一个文件，文件的引用数即引用该文件路径的附件记录数
+func (s *sAttachment) removeFiles(ctx context.Context, attachments ...entity.Attachment) {
+	store, err := storage.Default(ctx)
+	if err != nil {
+		g.Log().Warning(ctx, "初始化存储失败，附件文件未删除", g.Map{"error": err})
+		return
+	}
+	removed := make(map[string]bool)
+	for _, attachment := range attachments {
+		if attachment.FilePath == "" || removed[attachment.FilePath] {
+			continue
+		}
+		removed[attachment.FilePath] = true
+
+		references, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().FilePath, attachment.FilePath).Count()
+		if err != nil {
+			g.Log().Warning(ctx, "查询文件引用数失败，附件文件未删除", g.Map{
+				"path":  attachment.FilePath,
+				"error": err,
+			})
+			continue
+		}
+		if references > 0 {
+			continue
+		}
+		if err = store.Delete(ctx, attachment.FilePath); err != nil {
+			g.Log().Warning(ctx, "删除物理文件失败", g.Map{
+				"path":  attachment.FilePath,
+				"error": err,
+			})
+		}
+		if attachment.IsImage == 1 {
+			s.removeVariants(ctx, store, attachment.FilePath)
 		}
 	}
-	return nil
 }
 
-// isImageFile 判断是否为图片文件
-func (s *sAttachment) isImageFile(mimeType, extension string) bool {
-	if strings.HasPrefix(mimeType, "image/") {
-		return true
+// sceneCategories 上传场景允许的文件分类，可通过 attachment.scenes 配置（场景名称 -> 文件分类列表）增加或修改场景
+func (s *sAttachment) sceneCategories(ctx context.Context, scene string) ([]string, error) {
+	if scene == "" {
+		scene = consts.UploadSceneDefault
+	}
+	scenes := map[string][]string{
+		consts.UploadSceneDefault: filetype.Categories(),
+		consts.UploadSceneImage:   {filetype.CategoryImage},
 	}
-	imageExts := []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".webp", ".svg"}
-	for _, ext := range imageExts {
-		if extension == ext {
-			return true
+	value, err := g.Cfg().Get(ctx, "attachment.scenes")
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取上传场景配置失败")
+	}
+	if !value.IsNil() {
+		configured := make(map[string][]string)
+		if err = value.Scan(&configured); err != nil {
+			return nil, gerror.Wrap(err, "解析上传场景配置失败")
+		}
+		for name, categories := range configured {
+			scenes[name] = categories
 		}
 	}
-	return false
+
+	categories, ok := scenes[scene]
+	if !ok {
+		return nil, gerror.Newf("上传场景不存在: %s", scene)
+	}
+	return categories, nil
 }
 
-// getMimeTypeByExtension 根据扩展名获取MIME类型
-func (s *sAttachment) getMimeTypeByExtension(extension string) string {
-	mimeTypes := map[string]string{
-		".jpg":  "image/jpeg",
-		".jpeg": "image/jpeg",
-		".png":  "image/png",
-		".gif":  "image/gif",
-		".pdf":  "application/pdf",
-		".doc":  "application/msword",
-		".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-		".xls":  "application/vnd.ms-excel",
-		".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
-		".txt":  "text/plain",
-	}
-	if mimeType, exists := mimeTypes[extension]; exists {
-		return mimeType
-	}
-	return "application/octet-stream"
+// validateFileName 校验文件扩展名是否允许在上传场景中上传，用于上传文件内容之前的检查
+func (s *sAttachment) validateFileName(ctx context.Context, scene, fileName string) (filetype.Type, error) {
+	categories, err := s.sceneCategories(ctx, scene)
+	if err != nil {
+		return filetype.Type{}, err
+	}
+	fileType, err := filetype.ValidateName(fileName, categories)
+	if err != nil {
+		return fileType, gerror.Wrap(err, "文件类型校验失败")
+	}
+	return fileType, nil
+}
+
+// validateFile 校验文件扩展名是否允许在上传场景中上传，且与文件开头内容识别出的类型一致
+func (s *sAttachment) validateFile(ctx context.Context, scene, fileName string, head []byte) (filetype.Type, error) {
+	categories, err := s.sceneCategories(ctx, scene)
+	if err != nil {
+		return filetype.Type{}, err
+	}
+	fileType, err := filetype.Validate(fileName, head, categories)
+	if err != nil {
+		return fileType, gerror.Wrap(err, "文件类型校验失败")
+	}
+	return fileType, nil
 }
 
 // generateFileName 生成唯一文件名
@@ -380,9 +653,11 @@ func (s *sAttachment) generateFileName(originalName string) string {
 	return fmt.Sprintf("%s_%s%s", name, hash[:8], ext)
 }
 
-// generateThumbnailURL 生成缩略图URL（简单实现）
-func (s *sAttachment) generateThumbnailURL(originalURL string) string {
-	// 这里可以实现缩略图生成逻辑
-	// 简单实现：返回原图URL，实际项目中可以集成图片处理服务
-	return originalURL
+// blobPath 新保存文件在存储中的路径，按日期分目录，私有文件保存在 private 目录下
+func (s *sAttachment) blobPath(storedName string, isPrivate int) string {
+	filePath := fmt.Sprintf("%s/%s", time.Now().Format("2006/01/02"), storedName)
+	if isPrivate == 1 {
+		filePath = consts.PrivateStoragePrefix + "/" + filePath
+	}
+	return filePath
 }
@@ -0,0 +1,175 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/gogf/gf/contrib/drivers/sqlite/v2"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcfg"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/imaging"
+)
+
+// testAttachmentTable SQLite 中的附件表，字段与 MySQL 迁移一致
+const testAttachmentTable = `
+CREATE TABLE attachment (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  file_name     TEXT NOT NULL DEFAULT '',
+  original_name TEXT NOT NULL DEFAULT '',
+  file_size     INTEGER NOT NULL DEFAULT 0,
+  file_type     TEXT NOT NULL DEFAULT '',
+  file_ext      TEXT NOT NULL DEFAULT '',
+  file_path     TEXT NOT NULL DEFAULT '',
+  file_url      TEXT NOT NULL DEFAULT '',
+  file_hash     TEXT NOT NULL DEFAULT '',
+  source_hash   TEXT NOT NULL DEFAULT '',
+  is_image      INTEGER NOT NULL DEFAULT 0,
+  thumbnail_url TEXT NOT NULL DEFAULT '',
+  is_private    INTEGER NOT NULL DEFAULT 0,
+  access_roles  TEXT NOT NULL DEFAULT '',
+  folder_id     INTEGER NOT NULL DEFAULT 0,
+  uploader_id   INTEGER NOT NULL DEFAULT 0,
+  uploader_name TEXT NOT NULL DEFAULT '',
+  status        INTEGER NOT NULL DEFAULT 1,
+  remark        TEXT NOT NULL DEFAULT '',
+  created_at    DATETIME NULL,
+  updated_at    DATETIME NULL
+)`
+
+// setConfig 使用指定的 JSON 内容作为配置
+func setConfig(t *testing.T, content string) {
+	t.Helper()
+	adapter, err := gcfg.NewAdapterContent(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Cfg().SetAdapter(adapter)
+}
+
+// setupTestEnv 使用临时目录的本地存储与 SQLite 数据库
+func setupTestEnv(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	setConfig(t, fmt.Sprintf(`{
+		"storage": {"driver": "local", "local": {"root": %q, "baseUrl": "/uploads"}},
+		"attachment": {"signedUrl": {"secret": %q}}
+	}`, filepath.Join(dir, "uploads"), testSignedUrlSecret))
+
+	if err := gdb.SetConfig(gdb.Config{
+		gdb.DefaultGroupName: gdb.ConfigGroup{{Type: "sqlite", Link: "sqlite::@file(" + filepath.Join(dir, "test.db") + ")"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.DB().Exec(context.Background(), testAttachmentTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// exifJPEG 带有 EXIF 段（含拍摄位置等信息）的 JPEG 图片
+func exifJPEG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for i := 0; i < 16; i++ {
+		img.Set(i, i, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	payload := append([]byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x00"), "GPS 31.2304N 121.4737E"...)
+	segment := append([]byte{0xff, 0xe1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+// TestInstantUploadAfterExifStripped 上传带 EXIF 的图片后，保存的是去除元数据的内容，
+// 使用原始文件的哈希秒传仍然命中，并复用同一个文件
+func TestInstantUploadAfterExifStripped(t *testing.T) {
+	setupTestEnv(t)
+	ctx := context.Background()
+	original := exifJPEG(t)
+	if bytes.Equal(imaging.StripMetadata(original), original) {
+		t.Fatal("测试图片的元数据未被去除")
+	}
+	sum := sha256.Sum256(original)
+	sourceHash := hex.EncodeToString(sum[:])
+
+	s := g.Server(t.Name())
+	s.SetAddr("127.0.0.1:0")
+	s.SetDumpRouterMap(false)
+	s.BindHandler("POST:/upload", func(r *ghttp.Request) {
+		res, err := New().Upload(r.Context(), &v1.UploadReq{})
+		if err != nil {
+			r.Response.WriteJsonExit(g.Map{"error": err.Error()})
+		}
+		r.Response.WriteJsonExit(res)
+	})
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "photo.jpg")
+	_, _ = part.Write(original)
+	_ = writer.Close()
+	resp, err := http.Post(fmt.Sprintf("http://127.0.0.1:%d/upload", s.GetListenedPort()), writer.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var uploaded struct {
+		Id    uint64 `json:"id"`
+		Error string `json:"error"`
+	}
+	if err = json.Unmarshal(content, &uploaded); err != nil || uploaded.Id == 0 {
+		t.Fatalf("上传失败: %s", content)
+	}
+
+	record, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, uploaded.Id).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record["source_hash"].String() != sourceHash {
+		t.Errorf("source_hash = %s，应为原始文件的哈希 %s", record["source_hash"], sourceHash)
+	}
+	if record["file_hash"].String() == sourceHash {
+		t.Error("file_hash 应为去除元数据后内容的哈希")
+	}
+
+	res, err := New().InstantUpload(ctx, &v1.InstantUploadReq{FileName: "photo.jpg", Hash: sourceHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Hit {
+		t.Fatal("使用原始文件的哈希秒传未命中")
+	}
+	hit, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, res.Id).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hit["file_path"].String() != record["file_path"].String() || hit["source_hash"].String() != sourceHash {
+		t.Errorf("秒传的附件应复用已上传的文件: %v", hit)
+	}
+}
@@ -0,0 +1,521 @@
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/filetype"
+	"server/app/admin/internal/library/storage"
+	"server/app/admin/internal/middleware"
+	"server/app/admin/internal/model/do"
+	"server/app/admin/internal/model/entity"
+)
+
+// ChunkInit 初始化分片上传，提供文件哈希且存在同一文件未完成的上传时返回该上传用于续传
+func (s *sAttachment) ChunkInit(ctx context.Context, req *v1.ChunkInitReq) (res *v1.ChunkInitRes, err error) {
+	// 验证文件扩展名是否允许在上传场景中上传，文件内容在合并时校验
+	fileType, err := s.validateFileName(ctx, req.Scene, req.FileName)
+	if err != nil {
+		return nil, err
+	}
+	if req.FileSize > consts.ChunkFileSizeMax {
+		return nil, gerror.Newf("文件大小不能超过%dGB", consts.ChunkFileSizeMax/1024/1024/1024)
+	}
+	chunkSize := req.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = consts.ChunkSizeDefault
+	}
+	if chunkSize < consts.ChunkSizeMin || chunkSize > consts.ChunkSizeMax {
+		return nil, gerror.Newf("分片大小应在%dKB到%dMB之间", consts.ChunkSizeMin/1024, consts.ChunkSizeMax/1024/1024)
+	}
+
+	accessRoles, err := s.normalizeAccessRoles(ctx, req.AccessRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkFolder(ctx, req.FolderId); err != nil {
+		return nil, err
+	}
+	if err = s.checkQuota(ctx, req.FileSize); err != nil {
+		return nil, err
+	}
+
+	uploaderId, _ := ctx.Value(middleware.CtxUserID).(uint64)
+	fileHash := strings.ToLower(req.FileHash)
+
+	// 续传：同一用户以相同可见性上传同一文件的未完成上传
+	if fileHash != "" {
+		var session *entity.UploadSession
+		err = dao.UploadSession.Ctx(ctx).
+			Where(dao.UploadSession.Columns().UploaderId, uploaderId).
+			Where(dao.UploadSession.Columns().FileHash, fileHash).
+			Where(dao.UploadSession.Columns().FileSize, req.FileSize).
+			Where(dao.UploadSession.Columns().IsPrivate, req.IsPrivate).
+			Where(dao.UploadSession.Columns().Status, consts.UploadSessionUploading).
+			WhereGT(dao.UploadSession.Columns().ExpiresAt, gtime.Now()).
+			OrderDesc(dao.UploadSession.Columns().CreatedAt).
+			Scan(&session)
+		if err != nil {
+			return nil, gerror.Wrap(err, "查询未完成的上传失败")
+		}
+		if session != nil {
+			state, err := s.chunkSession(ctx, session)
+			if err != nil {
+				return nil, err
+			}
+			return &v1.ChunkInitRes{ChunkSession: *state}, nil
+		}
+	}
+
+	session := &entity.UploadSession{
+		Id:          guid.S(),
+		FileName:    req.FileName,
+		FileSize:    req.FileSize,
+		FileType:    fileType.MIME,
+		FileHash:    fileHash,
+		ChunkSize:   chunkSize,
+		ChunkCount:  uint((req.FileSize + uint64(chunkSize) - 1) / uint64(chunkSize)),
+		IsPrivate:   req.IsPrivate,
+		AccessRoles: accessRoles,
+		FolderId:    req.FolderId,
+		Status:      consts.UploadSessionUploading,
+		UploaderId:  uploaderId,
+		ExpiresAt:   gtime.Now().Add(consts.ChunkSessionTTL),
+	}
+	if req.Name != nil {
+		session.Name = *req.Name
+	}
+	if req.Remark != nil {
+		session.Remark = *req.Remark
+	}
+	_, err = dao.UploadSession.Ctx(ctx).Data(do.UploadSession{
+		Id:          session.Id,
+		FileName:    session.FileName,
+		FileSize:    session.FileSize,
+		FileType:    session.FileType,
+		FileHash:    session.FileHash,
+		ChunkSize:   session.ChunkSize,
+		ChunkCount:  session.ChunkCount,
+		Name:        session.Name,
+		Remark:      session.Remark,
+		IsPrivate:   session.IsPrivate,
+		AccessRoles: session.AccessRoles,
+		FolderId:    session.FolderId,
+		Status:      session.Status,
+		UploaderId:  session.UploaderId,
+		ExpiresAt:   session.ExpiresAt,
+	}).Insert()
+	if err != nil {
+		return nil, gerror.Wrap(err, "创建上传会话失败")
+	}
+
+	state, err := s.chunkSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ChunkInitRes{ChunkSession: *state}, nil
+}
+
+// ChunkStatus 查询分片上传进度，用于页面刷新后续传
+func (s *sAttachment) ChunkStatus(ctx context.Context, req *v1.ChunkStatusReq) (res *v1.ChunkStatusRes, err error) {
+	session, err := s.getUploadSession(ctx, req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.chunkSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ChunkStatusRes{ChunkSession: *state}, nil
+}
+
+// ChunkUpload 上传分片，校验分片大小与SHA-256后保存到存储，重复上传同一分片会覆盖
+func (s *sAttachment) ChunkUpload(ctx context.Context, req *v1.ChunkUploadReq) (res *v1.ChunkUploadRes, err error) {
+	session, err := s.getUploadSession(ctx, req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != consts.UploadSessionUploading {
+		return nil, gerror.New("上传正在合并中，不能继续上传分片")
+	}
+	index := *req.Index
+	if index >= session.ChunkCount {
+		return nil, gerror.Newf("分片序号应小于%d", session.ChunkCount)
+	}
+
+	file := g.RequestFromCtx(ctx).GetUploadFile("file")
+	if file == nil {
+		return nil, gerror.New("请选择要上传的分片")
+	}
+	expectedSize := s.partSize(session, index)
+	if file.Size != int64(expectedSize) {
+		return nil, gerror.Newf("分片 %d 的大小应为 %d 字节，实际为 %d 字节", index, expectedSize, file.Size)
+	}
+
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取分片失败")
+	}
+	defer f.Close()
+
+	// 分片已由请求解析缓存在本地，先校验再保存，校验失败时不会覆盖已上传的同一分片
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, f); err != nil {
+		return nil, gerror.Wrap(err, "读取分片失败")
+	}
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	if checksum != strings.ToLower(req.Checksum) {
+		return nil, gerror.Newf("分片 %d 校验失败，请重新上传", index)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return nil, gerror.Wrap(err, "读取分片失败")
+	}
+	if err = store.Put(ctx, s.partKey(session.Id, index), f, file.Size, "application/octet-stream"); err != nil {
+		return nil, gerror.Wrap(err, "保存分片失败")
+	}
+
+	// 记录分片并延长会话有效期
+	_, err = dao.UploadPart.Ctx(ctx).Data(do.UploadPart{
+		UploadId:  session.Id,
+		PartIndex: index,
+		PartSize:  expectedSize,
+		Checksum:  checksum,
+	}).Save()
+	if err != nil {
+		return nil, gerror.Wrap(err, "保存分片记录失败")
+	}
+	_, err = dao.UploadSession.Ctx(ctx).
+		Where(dao.UploadSession.Columns().Id, session.Id).
+		Data(do.UploadSession{ExpiresAt: gtime.Now().Add(consts.ChunkSessionTTL)}).
+		Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "更新上传会话失败")
+	}
+
+	return &v1.ChunkUploadRes{Index: index, Size: expectedSize}, nil
+}
+
+// ChunkComplete 完成分片上传：按序合并分片保存到存储并创建附件，相同内容的文件已存在时复用已有文件
+func (s *sAttachment) ChunkComplete(ctx context.Context, req *v1.ChunkCompleteReq) (res *v1.ChunkCompleteRes, err error) {
+	session, err := s.getUploadSession(ctx, req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 标记为合并中，避免重复合并以及合并期间继续上传分片
+	result, err := dao.UploadSession.Ctx(ctx).
+		Where(dao.UploadSession.Columns().Id, session.Id).
+		Where(dao.UploadSession.Columns().Status, consts.UploadSessionUploading).
+		Data(do.UploadSession{Status: consts.UploadSessionMerging}).
+		Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "更新上传会话失败")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.New("上传正在合并中，请勿重复提交")
+	}
+
+	attachment, err := s.mergeChunks(ctx, session)
+	if err != nil {
+		// 合并失败时恢复为上传中，可重新上传分片后再次合并
+		_, updateErr := dao.UploadSession.Ctx(ctx).
+			Where(dao.UploadSession.Columns().Id, session.Id).
+			Data(do.UploadSession{Status: consts.UploadSessionUploading}).
+			Update()
+		if updateErr != nil {
+			g.Log().Warning(ctx, "恢复上传会话状态失败", g.Map{"uploadId": session.Id, "error": updateErr})
+		}
+		return nil, err
+	}
+
+	s.removeUploadSession(ctx, session.Id)
+	url, err := s.fileUrl(ctx, attachment.Id, *attachment)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ChunkCompleteRes{Id: attachment.Id, Url: url}, nil
+}
+
+// ChunkAbort 取消分片上传，删除已上传的分片
+func (s *sAttachment) ChunkAbort(ctx context.Context, req *v1.ChunkAbortReq) (res *v1.ChunkAbortRes, err error) {
+	session, err := s.getUploadSession(ctx, req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != consts.UploadSessionUploading {
+		return nil, gerror.New("上传正在合并中，不能取消")
+	}
+	s.removeUploadSession(ctx, session.Id)
+	return &v1.ChunkAbortRes{}, nil
+}
+
+// CleanupUploadSessions 清理过期未完成的分片上传会话及其分片，返回清理的会话数
+func (s *sAttachment) CleanupUploadSessions(ctx context.Context) (int, error) {
+	ids, err := dao.UploadSession.Ctx(ctx).
+		WhereLT(dao.UploadSession.Columns().ExpiresAt, gtime.Now()).
+		Array(dao.UploadSession.Columns().Id)
+	if err != nil {
+		return 0, gerror.Wrap(err, "查询过期的上传会话失败")
+	}
+	for _, id := range ids {
+		s.removeUploadSession(ctx, id.String())
+	}
+	if len(ids) > 0 {
+		g.Log().Info(ctx, "已清理过期的分片上传会话", g.Map{"count": len(ids)})
+	}
+	return len(ids), nil
+}
+
+// mergeChunks 校验分片完整后按序合并分片到存储，计算合并后文件的SHA-256，返回创建的附件
+func (s *sAttachment) mergeChunks(ctx context.Context, session *entity.UploadSession) (*entity.Attachment, error) {
+	var parts []entity.UploadPart
+	err := dao.UploadPart.Ctx(ctx).
+		Where(dao.UploadPart.Columns().UploadId, session.Id).
+		OrderAsc(dao.UploadPart.Columns().PartIndex).
+		Scan(&parts)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询已上传的分片失败")
+	}
+	var (
+		keys    = make([]string, 0, len(parts))
+		total   uint64
+		missing []string
+		next    uint
+	)
+	for _, part := range parts {
+		for ; next < part.PartIndex; next++ {
+			missing = append(missing, gconv.String(next))
+		}
+		next = part.PartIndex + 1
+		keys = append(keys, s.partKey(session.Id, part.PartIndex))
+		total += uint64(part.PartSize)
+	}
+	for ; next < session.ChunkCount; next++ {
+		missing = append(missing, gconv.String(next))
+	}
+	if len(missing) > 0 {
+		return nil, gerror.Newf("分片未上传完成，缺少分片: %s", strings.Join(missing, ","))
+	}
+	if total != session.FileSize {
+		return nil, gerror.Newf("分片总大小 %d 与文件大小 %d 不一致", total, session.FileSize)
+	}
+	// 上传分片期间可能已保存了其他附件，合并前重新检查存储配额
+	if err = s.checkQuota(ctx, session.FileSize); err != nil {
+		return nil, err
+	}
+
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	// 根据合并后的文件开头内容识别文件类型，校验是否与扩展名一致
+	fileType, ok := filetype.Lookup(session.FileName)
+	if !ok {
+		return nil, gerror.New("不允许上传此类型的文件")
+	}
+	merged := &partsReader{ctx: ctx, store: store, keys: keys}
+	defer merged.Close()
+	reader := bufio.NewReader(merged)
+	head, err := reader.Peek(filetype.SniffMaxSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, gerror.Wrap(err, "读取分片失败")
+	}
+	if err = fileType.Match(head); err != nil {
+		return nil, gerror.Wrap(err, "文件类型校验失败")
+	}
+
+	extension := strings.ToLower(filepath.Ext(session.FileName))
+	blob := entity.Attachment{
+		FilePath:  s.blobPath(s.generateFileName(session.FileName), session.IsPrivate),
+		FileSize:  session.FileSize,
+		FileType:  fileType.MIME,
+		IsImage:   gconv.Int(fileType.Category == filetype.CategoryImage),
+		IsPrivate: session.IsPrivate,
+	}
+	content, err := s.storeBlob(ctx, store, reader, &blob)
+	if err != nil {
+		return nil, gerror.Wrap(err, "合并分片失败")
+	}
+	blob.FileUrl = store.URL(blob.FilePath)
+	if session.FileHash != "" && blob.SourceHash != session.FileHash {
+		s.removeFiles(ctx, blob)
+		return nil, gerror.New("合并后的文件与初始化时提供的文件哈希不一致")
+	}
+
+	// 已存在相同内容的文件时复用已有文件
+	existing, err := s.findBlob(ctx, store, blob.FileHash, blob.IsPrivate)
+	if err != nil {
+		s.removeFiles(ctx, blob)
+		return nil, err
+	}
+	if existing != nil {
+		s.removeFiles(ctx, blob)
+		sourceHash := blob.SourceHash
+		blob = *existing
+		blob.SourceHash = sourceHash
+	} else {
+		s.prepareThumbnail(ctx, store, &blob, content)
+	}
+	blob.AccessRoles = session.AccessRoles
+	blob.FolderId = session.FolderId
+
+	fileName := s.generateFileName(session.FileName)
+	if session.Name != "" {
+		fileName = session.Name + extension
+	}
+	var remark *string
+	if session.Remark != "" {
+		remark = &session.Remark
+	}
+	id, err := s.insert(ctx, blob, fileName, session.FileName, extension, remark)
+	if err != nil {
+		s.removeFiles(ctx, blob)
+		return nil, err
+	}
+	blob.Id = id
+	return &blob, nil
+}
+
+// getUploadSession 查询当前用户的未过期上传会话
+func (s *sAttachment) getUploadSession(ctx context.Context, uploadId string) (*entity.UploadSession, error) {
+	var session *entity.UploadSession
+	err := dao.UploadSession.Ctx(ctx).Where(dao.UploadSession.Columns().Id, uploadId).Scan(&session)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询上传会话失败")
+	}
+	uploaderId, _ := ctx.Value(middleware.CtxUserID).(uint64)
+	if session == nil || session.UploaderId != uploaderId || session.ExpiresAt == nil || session.ExpiresAt.Before(gtime.Now()) {
+		return nil, gerror.New("上传会话不存在或已过期")
+	}
+	return session, nil
+}
+
+// chunkSession 返回上传会话及已上传的分片序号
+func (s *sAttachment) chunkSession(ctx context.Context, session *entity.UploadSession) (*v1.ChunkSession, error) {
+	indexes, err := dao.UploadPart.Ctx(ctx).
+		Where(dao.UploadPart.Columns().UploadId, session.Id).
+		OrderAsc(dao.UploadPart.Columns().PartIndex).
+		Array(dao.UploadPart.Columns().PartIndex)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询已上传的分片失败")
+	}
+	uploaded := make([]uint, 0, len(indexes))
+	for _, index := range indexes {
+		uploaded = append(uploaded, index.Uint())
+	}
+	return &v1.ChunkSession{
+		UploadId:      session.Id,
+		FileName:      session.FileName,
+		FileSize:      session.FileSize,
+		ChunkSize:     session.ChunkSize,
+		ChunkCount:    session.ChunkCount,
+		UploadedParts: uploaded,
+		ExpiresAt:     session.ExpiresAt,
+	}, nil
+}
+
+// removeUploadSession 删除上传会话、分片记录及存储中的分片，删除分片失败仅记录日志
+func (s *sAttachment) removeUploadSession(ctx context.Context, uploadId string) {
+	indexes, err := dao.UploadPart.Ctx(ctx).
+		Where(dao.UploadPart.Columns().UploadId, uploadId).
+		Array(dao.UploadPart.Columns().PartIndex)
+	if err != nil {
+		g.Log().Warning(ctx, "查询上传会话的分片失败", g.Map{"uploadId": uploadId, "error": err})
+		return
+	}
+	if store, err := storage.Default(ctx); err != nil {
+		g.Log().Warning(ctx, "初始化存储失败，分片未删除", g.Map{"uploadId": uploadId, "error": err})
+	} else {
+		for _, index := range indexes {
+			key := s.partKey(uploadId, index.Uint())
+			if err = store.Delete(ctx, key); err != nil {
+				g.Log().Warning(ctx, "删除分片失败", g.Map{"key": key, "error": err})
+			}
+		}
+	}
+
+	if _, err = dao.UploadPart.Ctx(ctx).Where(dao.UploadPart.Columns().UploadId, uploadId).Delete(); err != nil {
+		g.Log().Warning(ctx, "删除分片记录失败", g.Map{"uploadId": uploadId, "error": err})
+	}
+	if _, err = dao.UploadSession.Ctx(ctx).Where(dao.UploadSession.Columns().Id, uploadId).Delete(); err != nil {
+		g.Log().Warning(ctx, "删除上传会话失败", g.Map{"uploadId": uploadId, "error": err})
+	}
+}
+
+// partSize 分片的大小，最后一个分片为剩余大小
+func (s *sAttachment) partSize(session *entity.UploadSession, index uint) uint {
+	if index == session.ChunkCount-1 {
+		return uint(session.FileSize - uint64(session.ChunkSize)*uint64(session.ChunkCount-1))
+	}
+	return session.ChunkSize
+}
+
+// partKey 分片在存储中的 key
+func (s *sAttachment) partKey(uploadId string, index uint) string {
+	return fmt.Sprintf("%s/%s/%d", consts.ChunkStoragePrefix, uploadId, index)
+}
+
+// partsReader 按序读取存储中的分片，读完一个分片后再打开下一个
+type partsReader struct {
+	ctx     context.Context
+	store   storage.Driver
+	keys    []string
+	current io.ReadCloser
+}
+
+// Read 读取分片内容
+func (r *partsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			reader, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, fmt.Errorf("读取分片 %s 失败: %v", r.keys[0], err)
+			}
+			r.current, r.keys = reader, r.keys[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+// Close 关闭正在读取的分片
+func (r *partsReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
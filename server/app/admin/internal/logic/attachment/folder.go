@@ -0,0 +1,246 @@
+package attachment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/dao"
+)
+
+// FolderList 获取附件文件夹列表，附带各文件夹下的附件数
+func (s *sAttachment) FolderList(ctx context.Context, req *v1.FolderListReq) (res *v1.FolderListRes, err error) {
+	res = &v1.FolderListRes{List: make([]v1.FolderInfo, 0)}
+
+	err = dao.AttachmentFolder.Ctx(ctx).
+		OrderAsc(dao.AttachmentFolder.Columns().Sort).
+		OrderAsc(dao.AttachmentFolder.Columns().Id).
+		Scan(&res.List)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询文件夹列表失败")
+	}
+	if len(res.List) == 0 {
+		return res, nil
+	}
+
+	// 统计各文件夹下的附件数
+	var counts []struct {
+		FolderId uint64
+		Count    int
+	}
+	err = dao.Attachment.Ctx(ctx).
+		Fields(dao.Attachment.Columns().FolderId, "COUNT(*) AS count").
+		WhereGT(dao.Attachment.Columns().FolderId, 0).
+		Group(dao.Attachment.Columns().FolderId).
+		Scan(&counts)
+	if err != nil {
+		return nil, gerror.Wrap(err, "统计文件夹附件数失败")
+	}
+	countMap := make(map[uint64]int, len(counts))
+	for _, count := range counts {
+		countMap[count.FolderId] = count.Count
+	}
+	for i := range res.List {
+		res.List[i].Count = countMap[res.List[i].Id]
+	}
+	return res, nil
+}
+
+// FolderCreate 创建附件文件夹
+func (s *sAttachment) FolderCreate(ctx context.Context, req *v1.FolderCreateReq) (res *v1.FolderCreateRes, err error) {
+	res = &v1.FolderCreateRes{}
+
+	if req.Name == nil || strings.TrimSpace(*req.Name) == "" {
+		return nil, gerror.New("文件夹名称不能为空")
+	}
+	name := strings.TrimSpace(*req.Name)
+	parentId := uint64(0)
+	if req.ParentId != nil {
+		parentId = *req.ParentId
+	}
+	if err = s.checkFolder(ctx, parentId); err != nil {
+		return nil, err
+	}
+	if err = s.checkFolderName(ctx, 0, parentId, name); err != nil {
+		return nil, err
+	}
+
+	data := g.Map{
+		dao.AttachmentFolder.Columns().ParentId: parentId,
+		dao.AttachmentFolder.Columns().Name:     name,
+	}
+	if req.Sort != nil {
+		data[dao.AttachmentFolder.Columns().Sort] = *req.Sort
+	}
+	id, err := dao.AttachmentFolder.Ctx(ctx).Data(data).InsertAndGetId()
+	if err != nil {
+		return nil, gerror.Wrap(err, "创建文件夹失败")
+	}
+
+	res.Id = uint64(id)
+	return res, nil
+}
+
+// FolderUpdate 更新附件文件夹，修改上级文件夹时不能移动到自身或其子文件夹下
+func (s *sAttachment) FolderUpdate(ctx context.Context, req *v1.FolderUpdateReq) (res *v1.FolderUpdateRes, err error) {
+	res = &v1.FolderUpdateRes{}
+
+	// 检查文件夹是否存在
+	record, err := dao.AttachmentFolder.Ctx(ctx).Where(dao.AttachmentFolder.Columns().Id, req.Id).One()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询文件夹失败")
+	}
+	if record.IsEmpty() {
+		return nil, gerror.New("文件夹不存在")
+	}
+	parentId := record[dao.AttachmentFolder.Columns().ParentId].Uint64()
+	name := record[dao.AttachmentFolder.Columns().Name].String()
+
+	// 动态构建更新数据
+	updateData := g.Map{}
+	if req.ParentId != nil && *req.ParentId != parentId {
+		if err = s.checkFolderParent(ctx, req.Id, *req.ParentId); err != nil {
+			return nil, err
+		}
+		parentId = *req.ParentId
+		updateData[dao.AttachmentFolder.Columns().ParentId] = parentId
+	}
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, gerror.New("文件夹名称不能为空")
+		}
+		updateData[dao.AttachmentFolder.Columns().Name] = name
+	}
+	if req.Sort != nil {
+		updateData[dao.AttachmentFolder.Columns().Sort] = *req.Sort
+	}
+	if len(updateData) == 0 {
+		return nil, gerror.New("没有需要更新的字段")
+	}
+
+	// 修改名称或上级文件夹时检查同级下名称唯一性
+	if err = s.checkFolderName(ctx, req.Id, parentId, name); err != nil {
+		return nil, err
+	}
+
+	_, err = dao.AttachmentFolder.Ctx(ctx).Where(dao.AttachmentFolder.Columns().Id, req.Id).Data(updateData).Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "更新文件夹失败")
+	}
+	return res, nil
+}
+
+// FolderDelete 删除附件文件夹，文件夹下还有子文件夹或附件时不能删除
+func (s *sAttachment) FolderDelete(ctx context.Context, req *v1.FolderDeleteReq) (res *v1.FolderDeleteRes, err error) {
+	res = &v1.FolderDeleteRes{}
+
+	if err = s.checkFolder(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	// 检查是否有子文件夹
+	count, err := dao.AttachmentFolder.Ctx(ctx).Where(dao.AttachmentFolder.Columns().ParentId, req.Id).Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询子文件夹失败")
+	}
+	if count > 0 {
+		return nil, gerror.New("该文件夹下还有子文件夹，无法删除")
+	}
+
+	// 检查是否有附件
+	count, err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().FolderId, req.Id).Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询文件夹附件失败")
+	}
+	if count > 0 {
+		return nil, gerror.New("该文件夹下还有附件，请先移动或删除附件")
+	}
+
+	_, err = dao.AttachmentFolder.Ctx(ctx).Where(dao.AttachmentFolder.Columns().Id, req.Id).Delete()
+	if err != nil {
+		return nil, gerror.Wrap(err, "删除文件夹失败")
+	}
+	return res, nil
+}
+
+// Move 移动附件到文件夹
+func (s *sAttachment) Move(ctx context.Context, req *v1.MoveReq) (res *v1.MoveRes, err error) {
+	res = &v1.MoveRes{}
+
+	if len(req.Ids) == 0 {
+		return nil, gerror.New("请选择要移动的附件")
+	}
+	if err = s.checkFolder(ctx, req.FolderId); err != nil {
+		return nil, err
+	}
+
+	_, err = dao.Attachment.Ctx(ctx).
+		WhereIn(dao.Attachment.Columns().Id, req.Ids).
+		Data(g.Map{dao.Attachment.Columns().FolderId: req.FolderId}).
+		Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "移动附件失败")
+	}
+	return res, nil
+}
+
+// checkFolder 检查文件夹是否存在，0 表示根目录
+func (s *sAttachment) checkFolder(ctx context.Context, folderId uint64) error {
+	if folderId == 0 {
+		return nil
+	}
+	count, err := dao.AttachmentFolder.Ctx(ctx).Where(dao.AttachmentFolder.Columns().Id, folderId).Count()
+	if err != nil {
+		return gerror.Wrap(err, "查询文件夹失败")
+	}
+	if count == 0 {
+		return gerror.New("文件夹不存在")
+	}
+	return nil
+}
+
+// checkFolderName 检查文件夹名称在同级下是否唯一，id 为当前文件夹，新增时为 0
+func (s *sAttachment) checkFolderName(ctx context.Context, id, parentId uint64, name string) error {
+	m := dao.AttachmentFolder.Ctx(ctx).
+		Where(dao.AttachmentFolder.Columns().ParentId, parentId).
+		Where(dao.AttachmentFolder.Columns().Name, name)
+	if id > 0 {
+		m = m.WhereNot(dao.AttachmentFolder.Columns().Id, id)
+	}
+	count, err := m.Count()
+	if err != nil {
+		return gerror.Wrap(err, "查询文件夹名称失败")
+	}
+	if count > 0 {
+		return gerror.Newf("同级下文件夹名称 %s 已存在", name)
+	}
+	return nil
+}
+
+// checkFolderParent 检查上级文件夹存在，且不是 id 对应文件夹自身或其子文件夹，避免形成循环
+func (s *sAttachment) checkFolderParent(ctx context.Context, id, parentId uint64) error {
+	if err := s.checkFolder(ctx, parentId); err != nil {
+		return err
+	}
+	// 沿上级逐层向上查找，路径中出现当前文件夹说明上级是其自身或子文件夹
+	visited := make(map[uint64]bool)
+	for current := parentId; current != 0 && !visited[current]; {
+		if current == id {
+			return gerror.New("上级文件夹不能为自身或其子文件夹")
+		}
+		visited[current] = true
+
+		value, err := dao.AttachmentFolder.Ctx(ctx).
+			Where(dao.AttachmentFolder.Columns().Id, current).
+			Value(dao.AttachmentFolder.Columns().ParentId)
+		if err != nil {
+			return gerror.Wrap(err, "查询上级文件夹失败")
+		}
+		current = value.Uint64()
+	}
+	return nil
+}
@@ -0,0 +1,427 @@
+package attachment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/storage"
+	"server/app/admin/internal/middleware"
+	"server/app/admin/internal/model/entity"
+)
+
+// signedUrlConfig 签名下载地址配置（attachment.signedUrl）
+type signedUrlConfig struct {
+	Secret string `json:"secret"` // 签名密钥，必须单独配置，不能使用 JWT 密钥
+	Expire int    `json:"expire"` // 默认有效期（秒）
+}
+
+// SignedUrl 为有访问权限的附件生成签名的限时下载地址
+func (s *sAttachment) SignedUrl(ctx context.Context, req *v1.SignedUrlReq) (res *v1.SignedUrlRes, err error) {
+	res = &v1.SignedUrlRes{}
+
+	var attachment entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Scan(&attachment)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件失败")
+	}
+	if attachment.Id == 0 {
+		return nil, gerror.New("附件不存在")
+	}
+	if attachment.Status != 1 {
+		return nil, gerror.New("文件已被禁用")
+	}
+	if err = s.checkAccess(ctx, &attachment); err != nil {
+		return nil, err
+	}
+
+	if req.Variant != "" {
+		if attachment.IsImage != 1 {
+			return nil, gerror.New("附件不是图片，没有图片规格")
+		}
+		config, err := s.loadImageConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, variant := range config.Variants {
+			found = found || variant.Name == req.Variant
+		}
+		if !found {
+			return nil, gerror.Newf("图片规格不存在: %s", req.Variant)
+		}
+	}
+
+	expire := time.Duration(req.Expire) * time.Second
+	if expire > consts.SignedUrlExpireMax {
+		return nil, gerror.Newf("有效期不能超过%d天", consts.SignedUrlExpireMax/(24*time.Hour))
+	}
+	res.Url, res.ExpiresAt, err = s.signUrl(ctx, attachment.Id, req.Variant, expire)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SignedDownload 校验签名与有效期后下载附件，不需要登录认证，可在浏览器中直接打开的文件以 inline 方式返回
+func (s *sAttachment) SignedDownload(ctx context.Context, req *v1.SignedDownloadReq) (res *v1.SignedDownloadRes, err error) {
+	if time.Now().Unix() > req.Expires {
+		return nil, gerror.New("下载地址已过期")
+	}
+	config, err := s.loadSignedUrlConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expected := s.signature(config.Secret, req.Id, req.Variant, req.Expires)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(req.Signature))) {
+		return nil, gerror.New("下载地址签名不正确")
+	}
+
+	var attachment entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Scan(&attachment)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件失败")
+	}
+	if attachment.Id == 0 {
+		return nil, gerror.New("附件不存在")
+	}
+	if attachment.Status != 1 {
+		return nil, gerror.New("文件已被禁用")
+	}
+
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	key, contentType, fileName := attachment.FilePath, attachment.FileType, attachment.OriginalName
+	etag := ""
+	if attachment.FileHash != "" {
+		etag = `"` + attachment.FileHash + `"`
+	}
+	if req.Variant != "" {
+		key, contentType, err = s.findVariant(ctx, store, attachment.FilePath, req.Variant)
+		if err != nil {
+			return nil, err
+		}
+		fileName = strings.TrimSuffix(fileName, path.Ext(fileName)) + path.Ext(key)
+		etag = ""
+	}
+
+	disposition := "attachment"
+	if storage.Inline(contentType) {
+		disposition = "inline"
+	}
+	return nil, s.serveFile(ctx, store, key, contentType, contentDisposition(disposition, fileName), etag)
+}
+
+// SetVisibility 设置附件是否私有及允许访问的角色，可见性变化时将附件引用的文件复制到对应的存储目录
+// 原文件仍被其他附件引用时保留，否则删除
+func (s *sAttachment) SetVisibility(ctx context.Context, req *v1.SetVisibilityReq) (res *v1.SetVisibilityRes, err error) {
+	res = &v1.SetVisibilityRes{}
+
+	var attachment entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Scan(&attachment)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件失败")
+	}
+	if attachment.Id == 0 {
+		return nil, gerror.New("附件不存在")
+	}
+	accessRoles, err := s.normalizeAccessRoles(ctx, req.AccessRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	data := g.Map{dao.Attachment.Columns().AccessRoles: accessRoles}
+	isPrivate := *req.IsPrivate
+	moved := isPrivate != attachment.IsPrivate
+	if moved {
+		store, err := storage.Default(ctx)
+		if err != nil {
+			return nil, gerror.Wrap(err, "初始化存储失败")
+		}
+		blob, err := s.moveBlob(ctx, store, &attachment, isPrivate)
+		if err != nil {
+			return nil, err
+		}
+		data[dao.Attachment.Columns().FilePath] = blob.FilePath
+		data[dao.Attachment.Columns().FileUrl] = blob.FileUrl
+		data[dao.Attachment.Columns().FileHash] = blob.FileHash
+		data[dao.Attachment.Columns().FileSize] = blob.FileSize
+		data[dao.Attachment.Columns().ThumbnailUrl] = blob.ThumbnailUrl
+		data[dao.Attachment.Columns().IsPrivate] = isPrivate
+	}
+
+	_, err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Data(data).Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "更新附件可见性失败")
+	}
+	if moved {
+		s.removeFiles(ctx, attachment)
+	}
+	return res, nil
+}
+
+// moveBlob 返回附件文件在目标可见性下的文件信息：已存在相同内容的文件时复用，否则复制文件并生成图片规格
+func (s *sAttachment) moveBlob(ctx context.Context, store storage.Driver, attachment *entity.Attachment, isPrivate int) (*entity.Attachment, error) {
+	if attachment.FileHash != "" {
+		existing, err := s.findBlob(ctx, store, attachment.FileHash, isPrivate)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	reader, err := store.Get(ctx, attachment.FilePath)
+	if errors.Is(err, storage.ErrNotExist) {
+		return nil, gerror.New("文件不存在")
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取文件失败")
+	}
+	defer reader.Close()
+
+	filePath := strings.TrimPrefix(attachment.FilePath, consts.PrivateStoragePrefix+"/")
+	if isPrivate == 1 {
+		filePath = consts.PrivateStoragePrefix + "/" + filePath
+	}
+	blob := &entity.Attachment{
+		FilePath:  filePath,
+		FileSize:  attachment.FileSize,
+		FileType:  attachment.FileType,
+		IsImage:   attachment.IsImage,
+		IsPrivate: isPrivate,
+	}
+	content, err := s.storeBlob(ctx, store, reader, blob)
+	if err != nil {
+		return nil, gerror.Wrap(err, "复制文件失败")
+	}
+	blob.FileUrl = store.URL(blob.FilePath)
+	s.prepareThumbnail(ctx, store, blob, content)
+	return blob, nil
+}
+
+// checkAccess 校验当前用户是否可以访问私有附件，未限制角色时登录用户均可访问，开发者可访问全部附件
+func (s *sAttachment) checkAccess(ctx context.Context, attachment *entity.Attachment) error {
+	if attachment.IsPrivate != 1 || attachment.AccessRoles == "" {
+		return nil
+	}
+	checker, err := s.newAccessChecker(ctx)
+	if err != nil {
+		return err
+	}
+	if !checker.allow(attachment.AccessRoles) {
+		return gerror.New("没有访问该附件的权限")
+	}
+	return nil
+}
+
+// accessChecker 当前用户的角色，用于校验私有附件的访问权限
+type accessChecker struct {
+	all   bool            // 是否可访问全部附件（开发者）
+	roles map[string]bool // 启用的角色编码
+}
+
+// newAccessChecker 查询当前用户启用的角色
+func (s *sAttachment) newAccessChecker(ctx context.Context) (*accessChecker, error) {
+	checker := &accessChecker{roles: make(map[string]bool)}
+	if username, ok := ctx.Value(middleware.CtxUsername).(string); ok && username == middleware.DeveloperCode {
+		checker.all = true
+		return checker, nil
+	}
+	userID, _ := ctx.Value(middleware.CtxUserID).(uint64)
+	if userID == 0 {
+		return checker, nil
+	}
+
+	var (
+		userRole = dao.UserRole.Table()
+		role     = dao.Role.Table()
+	)
+	codes, err := dao.UserRole.Ctx(ctx).
+		InnerJoin(role, fmt.Sprintf("%s.%s=%s.%s", userRole, dao.UserRole.Columns().RoleId, role, dao.Role.Columns().Id)).
+		Where(fmt.Sprintf("%s.%s", userRole, dao.UserRole.Columns().UserId), userID).
+		Where(fmt.Sprintf("%s.%s", role, dao.Role.Columns().Status), 1).
+		Array(fmt.Sprintf("%s.%s", role, dao.Role.Columns().Code))
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询用户角色失败")
+	}
+	for _, code := range codes {
+		checker.roles[code.String()] = true
+	}
+	checker.all = checker.roles[middleware.DeveloperCode]
+	return checker, nil
+}
+
+// allow 判断是否可以访问限制为 accessRoles（逗号分隔）的附件
+func (c *accessChecker) allow(accessRoles string) bool {
+	if c.all || accessRoles == "" {
+		return true
+	}
+	for _, code := range strings.Split(accessRoles, ",") {
+		if c.roles[code] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAccessRoles 整理逗号分隔的角色编码（去除空白与重复项），并校验角色是否存在
+func (s *sAttachment) normalizeAccessRoles(ctx context.Context, accessRoles string) (string, error) {
+	var (
+		codes []string
+		seen  = make(map[string]bool)
+	)
+	for _, code := range strings.Split(accessRoles, ",") {
+		code = strings.TrimSpace(code)
+		if code != "" && !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return "", nil
+	}
+
+	existing, err := dao.Role.Ctx(ctx).WhereIn(dao.Role.Columns().Code, codes).Array(dao.Role.Columns().Code)
+	if err != nil {
+		return "", gerror.Wrap(err, "查询角色失败")
+	}
+	for _, code := range existing {
+		delete(seen, code.String())
+	}
+	for _, code := range codes {
+		if seen[code] {
+			return "", gerror.Newf("角色不存在: %s", code)
+		}
+	}
+	normalized := strings.Join(codes, ",")
+	if len(normalized) > 255 {
+		return "", gerror.New("允许访问的角色过多")
+	}
+	return normalized, nil
+}
+
+// signPrivateUrls 将列表中私有附件的文件与缩略图地址替换为签名下载地址，没有访问权限时置空
+func (s *sAttachment) signPrivateUrls(ctx context.Context, list []v1.AttachmentInfo) error {
+	var (
+		checker   *accessChecker
+		thumbnail string
+	)
+	for i := range list {
+		item := &list[i]
+		if item.IsPrivate != 1 {
+			continue
+		}
+		if item.AccessRoles != "" {
+			if checker == nil {
+				var err error
+				if checker, err = s.newAccessChecker(ctx); err != nil {
+					return err
+				}
+			}
+			if !checker.allow(item.AccessRoles) {
+				item.FileUrl, item.ThumbnailUrl = "", ""
+				continue
+			}
+		}
+
+		// 生成了缩略图的图片使用缩略图规格，否则使用原图
+		hasThumbnail := item.IsImage == 1 && item.ThumbnailUrl != "" && item.ThumbnailUrl != item.FileUrl
+		fileUrl, _, err := s.signUrl(ctx, item.Id, "", 0)
+		if err != nil {
+			return err
+		}
+		item.FileUrl, item.ThumbnailUrl = fileUrl, ""
+		switch {
+		case hasThumbnail:
+			if thumbnail == "" {
+				config, err := s.loadImageConfig(ctx)
+				if err != nil {
+					return err
+				}
+				thumbnail = config.Thumbnail
+			}
+			if item.ThumbnailUrl, _, err = s.signUrl(ctx, item.Id, thumbnail, 0); err != nil {
+				return err
+			}
+		case item.IsImage == 1:
+			item.ThumbnailUrl = fileUrl
+		}
+	}
+	return nil
+}
+
+// fileUrl 附件的访问地址，私有附件返回默认有效期的签名下载地址
+func (s *sAttachment) fileUrl(ctx context.Context, id uint64, blob entity.Attachment) (string, error) {
+	if blob.IsPrivate != 1 {
+		return blob.FileUrl, nil
+	}
+	signed, _, err := s.signUrl(ctx, id, "", 0)
+	return signed, err
+}
+
+// signUrl 生成签名下载地址，expire 为 0 时使用配置的默认有效期
+func (s *sAttachment) signUrl(ctx context.Context, id uint64, variant string, expire time.Duration) (string, *gtime.Time, error) {
+	config, err := s.loadSignedUrlConfig(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if expire <= 0 {
+		expire = time.Duration(config.Expire) * time.Second
+	}
+	expiresAt := time.Now().Add(expire).Unix()
+
+	query := url.Values{}
+	query.Set("expires", fmt.Sprint(expiresAt))
+	if variant != "" {
+		query.Set("variant", variant)
+	}
+	query.Set("signature", s.signature(config.Secret, id, variant, expiresAt))
+	return fmt.Sprintf("%s%d?%s", consts.SignedUrlPath, id, query.Encode()), gtime.NewFromTimeStamp(expiresAt), nil
+}
+
+// signature 签名下载地址的签名：对附件ID、图片规格与过期时间计算 HMAC-SHA256
+func (s *sAttachment) signature(secret string, id uint64, variant string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d\n%s\n%d", id, variant, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// loadSignedUrlConfig 读取签名下载地址配置，未配置密钥时返回错误，拒绝生成与校验签名
+func (s *sAttachment) loadSignedUrlConfig(ctx context.Context) (*signedUrlConfig, error) {
+	config := &signedUrlConfig{}
+	value, err := g.Cfg().Get(ctx, "attachment.signedUrl")
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取签名下载地址配置失败")
+	}
+	if !value.IsNil() {
+		if err = value.Scan(config); err != nil {
+			return nil, gerror.Wrap(err, "解析签名下载地址配置失败")
+		}
+	}
+	if config.Secret == "" {
+		return nil, gerror.New("未配置签名下载地址密钥 attachment.signedUrl.secret")
+	}
+	expire := time.Duration(config.Expire) * time.Second
+	if expire <= 0 || expire > consts.SignedUrlExpireMax {
+		config.Expire = int(consts.SignedUrlExpireDefault / time.Second)
+	}
+	return config, nil
+}
@@ -0,0 +1,173 @@
+package attachment
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+)
+
+// testSignedUrlSecret 测试使用的签名下载地址密钥
+const testSignedUrlSecret = "test-secret"
+
+func TestSignature(t *testing.T) {
+	cases := []struct {
+		name    string
+		secret  string
+		id      uint64
+		variant string
+		expires int64
+		want    string
+	}{
+		{name: "原文件", secret: testSignedUrlSecret, id: 1, expires: 1700000000, want: "5bb4d3ce5ae6fbae4bad0b53c57ec450f47bc4e2a9c7f7ae145897360a54585c"},
+		{name: "图片规格", secret: testSignedUrlSecret, id: 1, variant: "thumb", expires: 1700000000, want: "7bd133422a22902523524c2c2983d3cc00017bcc90ab6b3742bfd03ae5147821"},
+		{name: "其他密钥", secret: "another-secret", id: 1, expires: 1700000000, want: "7d0d56af1447a695f2ffe764e510bea1778375647d368d0eed218e069a22c9ab"},
+		{name: "其他附件", secret: testSignedUrlSecret, id: 12, expires: 1700000000, want: "83271cc353155dea87e3c0c28698d331614164f9bc0829b8eede3115997a0d79"},
+	}
+	s := &sAttachment{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := s.signature(c.secret, c.id, c.variant, c.expires); got != c.want {
+				t.Errorf("signature = %s，期望 %s", got, c.want)
+			}
+		})
+	}
+}
+
+// parseSignedUrl 解析签名下载地址中的附件ID与查询参数
+func parseSignedUrl(t *testing.T, signed string) (uint64, url.Values) {
+	t.Helper()
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(u.Path, consts.SignedUrlPath) {
+		t.Fatalf("签名下载地址 %s 的路径前缀不是 %s", signed, consts.SignedUrlPath)
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(u.Path, consts.SignedUrlPath), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id, u.Query()
+}
+
+func TestSignUrlExpiry(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string        // attachment.signedUrl 配置
+		expire time.Duration // 请求的有效期，为 0 时使用默认有效期
+		want   time.Duration
+	}{
+		{name: "配置的默认有效期", config: `{"secret": "test-secret", "expire": 3600}`, want: time.Hour},
+		{name: "请求指定有效期", config: `{"secret": "test-secret", "expire": 3600}`, expire: time.Minute, want: time.Minute},
+		{name: "未配置有效期", config: `{"secret": "test-secret"}`, want: consts.SignedUrlExpireDefault},
+		{name: "配置的有效期超过上限", config: `{"secret": "test-secret", "expire": 86400000}`, want: consts.SignedUrlExpireDefault},
+	}
+	ctx := context.Background()
+	s := &sAttachment{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setConfig(t, `{"attachment": {"signedUrl": `+c.config+`}}`)
+			before := time.Now()
+			signed, expiresAt, err := s.signUrl(ctx, 42, "thumb", c.expire)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id, query := parseSignedUrl(t, signed)
+			expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+			if id != 42 || query.Get("variant") != "thumb" || expires != expiresAt.Unix() {
+				t.Fatalf("签名下载地址 %s 的参数不正确，过期时间 %s", signed, expiresAt)
+			}
+			if got := time.Unix(expires, 0).Sub(before); got < c.want-time.Second || got > c.want+time.Second {
+				t.Errorf("有效期 %s，期望 %s", got, c.want)
+			}
+			if query.Get("signature") != s.signature(testSignedUrlSecret, 42, "thumb", expires) {
+				t.Errorf("签名 %s 不正确", query.Get("signature"))
+			}
+		})
+	}
+
+	setConfig(t, `{"attachment": {"signedUrl": {"expire": 3600}}}`)
+	if _, _, err := s.signUrl(ctx, 42, "", 0); err == nil || !strings.Contains(err.Error(), "未配置签名下载地址密钥") {
+		t.Errorf("未配置密钥时错误 = %v", err)
+	}
+}
+
+// TestSignedDownloadVerify 签名下载地址过期、签名不正确或被篡改时拒绝下载
+func TestSignedDownloadVerify(t *testing.T) {
+	setupTestEnv(t)
+	ctx := context.Background()
+	s := &sAttachment{}
+	valid := time.Now().Add(time.Minute).Unix()
+	expired := time.Now().Add(-time.Second).Unix()
+
+	cases := []struct {
+		name string
+		req  v1.SignedDownloadReq
+		err  string // 为空时表示校验通过，此时才会查询附件
+	}{
+		{
+			name: "签名正确",
+			req:  v1.SignedDownloadReq{Id: 7, Expires: valid, Signature: s.signature(testSignedUrlSecret, 7, "", valid)},
+		},
+		{
+			name: "签名为大写",
+			req:  v1.SignedDownloadReq{Id: 7, Variant: "thumb", Expires: valid, Signature: strings.ToUpper(s.signature(testSignedUrlSecret, 7, "thumb", valid))},
+		},
+		{
+			name: "已过期",
+			req:  v1.SignedDownloadReq{Id: 7, Expires: expired, Signature: s.signature(testSignedUrlSecret, 7, "", expired)},
+			err:  "下载地址已过期",
+		},
+		{
+			name: "修改过期时间",
+			req:  v1.SignedDownloadReq{Id: 7, Expires: valid + 3600, Signature: s.signature(testSignedUrlSecret, 7, "", valid)},
+			err:  "下载地址签名不正确",
+		},
+		{
+			name: "修改附件ID",
+			req:  v1.SignedDownloadReq{Id: 8, Expires: valid, Signature: s.signature(testSignedUrlSecret, 7, "", valid)},
+			err:  "下载地址签名不正确",
+		},
+		{
+			name: "使用图片规格的签名下载原文件",
+			req:  v1.SignedDownloadReq{Id: 7, Expires: valid, Signature: s.signature(testSignedUrlSecret, 7, "thumb", valid)},
+			err:  "下载地址签名不正确",
+		},
+		{
+			name: "其他密钥签名",
+			req:  v1.SignedDownloadReq{Id: 7, Expires: valid, Signature: s.signature("another-secret", 7, "", valid)},
+			err:  "下载地址签名不正确",
+		},
+		{
+			name: "签名为空",
+			req:  v1.SignedDownloadReq{Id: 7, Expires: valid},
+			err:  "下载地址签名不正确",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := s.SignedDownload(ctx, &c.req)
+			if c.err == "" {
+				if err == nil || !strings.Contains(err.Error(), "查询附件失败") {
+					t.Errorf("错误 = %v，期望校验通过后查询附件", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("错误 = %v，期望包含 %q", err, c.err)
+			}
+		})
+	}
+
+	setConfig(t, `{"attachment": {"signedUrl": {"secret": ""}}}`)
+	req := &v1.SignedDownloadReq{Id: 7, Expires: valid, Signature: s.signature("", 7, "", valid)}
+	if _, err := s.SignedDownload(ctx, req); err == nil || !strings.Contains(err.Error(), "未配置签名下载地址密钥") {
+		t.Errorf("未配置密钥时错误 = %v", err)
+	}
+}
@@ -0,0 +1,347 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/middleware"
+	"server/app/admin/internal/model/entity"
+)
+
+// quotaConfig 默认存储配额配置（attachment.quota），单位 MB，0 为不限制
+type quotaConfig struct {
+	User       uint64 `json:"user"`       // 未单独设置配额的用户的默认配额
+	Department uint64 `json:"department"` // 未单独设置配额的部门的默认配额
+}
+
+// QuotaList 获取单独设置的存储配额列表，附带各配额对象的已用空间
+func (s *sAttachment) QuotaList(ctx context.Context, req *v1.QuotaListReq) (res *v1.QuotaListRes, err error) {
+	res = &v1.QuotaListRes{List: make([]v1.QuotaInfo, 0)}
+
+	config, err := s.loadQuotaConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res.DefaultUser = config.User
+	res.DefaultDepartment = config.Department
+
+	m := dao.AttachmentQuota.Ctx(ctx)
+	if req.TargetType != "" {
+		m = m.Where(dao.AttachmentQuota.Columns().TargetType, req.TargetType)
+	}
+	err = m.OrderAsc(dao.AttachmentQuota.Columns().TargetType).
+		OrderAsc(dao.AttachmentQuota.Columns().TargetId).
+		Scan(&res.List)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询存储配额列表失败")
+	}
+	if len(res.List) == 0 {
+		return res, nil
+	}
+
+	// 填充配额对象名称与已用空间
+	var userIds, departmentIds []uint64
+	for _, item := range res.List {
+		if item.TargetType == consts.QuotaTargetUser {
+			userIds = append(userIds, item.TargetId)
+		} else {
+			departmentIds = append(departmentIds, item.TargetId)
+		}
+	}
+	userNames, userUsed, err := s.userUsage(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+	departmentNames, departmentUsed, err := s.departmentUsage(ctx, departmentIds)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range res.List {
+		if item.TargetType == consts.QuotaTargetUser {
+			res.List[i].TargetName = userNames[item.TargetId]
+			res.List[i].UsedSize = userUsed[item.TargetId]
+		} else {
+			res.List[i].TargetName = departmentNames[item.TargetId]
+			res.List[i].UsedSize = departmentUsed[item.TargetId]
+		}
+	}
+	return res, nil
+}
+
+// QuotaSet 设置用户或部门的存储配额，已设置过时覆盖原配额
+func (s *sAttachment) QuotaSet(ctx context.Context, req *v1.QuotaSetReq) (res *v1.QuotaSetRes, err error) {
+	res = &v1.QuotaSetRes{}
+
+	// 检查配额对象是否存在
+	var count int
+	if req.TargetType == consts.QuotaTargetUser {
+		count, err = dao.User.Ctx(ctx).Where(dao.User.Columns().Id, req.TargetId).Count()
+	} else {
+		count, err = dao.Department.Ctx(ctx).Where(dao.Department.Columns().Id, req.TargetId).Count()
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询配额对象失败")
+	}
+	if count == 0 {
+		return nil, gerror.New("配额对象不存在")
+	}
+
+	id, err := dao.AttachmentQuota.Ctx(ctx).
+		Where(dao.AttachmentQuota.Columns().TargetType, req.TargetType).
+		Where(dao.AttachmentQuota.Columns().TargetId, req.TargetId).
+		Value(dao.AttachmentQuota.Columns().Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询存储配额失败")
+	}
+	if !id.IsEmpty() {
+		_, err = dao.AttachmentQuota.Ctx(ctx).
+			Where(dao.AttachmentQuota.Columns().Id, id.Uint64()).
+			Data(g.Map{dao.AttachmentQuota.Columns().QuotaSize: *req.QuotaSize}).
+			Update()
+	} else {
+		_, err = dao.AttachmentQuota.Ctx(ctx).Data(g.Map{
+			dao.AttachmentQuota.Columns().TargetType: req.TargetType,
+			dao.AttachmentQuota.Columns().TargetId:   req.TargetId,
+			dao.AttachmentQuota.Columns().QuotaSize:  *req.QuotaSize,
+		}).Insert()
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "保存存储配额失败")
+	}
+	return res, nil
+}
+
+// QuotaDelete 删除单独设置的存储配额，删除后使用默认配额
+func (s *sAttachment) QuotaDelete(ctx context.Context, req *v1.QuotaDeleteReq) (res *v1.QuotaDeleteRes, err error) {
+	res = &v1.QuotaDeleteRes{}
+
+	result, err := dao.AttachmentQuota.Ctx(ctx).Where(dao.AttachmentQuota.Columns().Id, req.Id).Delete()
+	if err != nil {
+		return nil, gerror.Wrap(err, "删除存储配额失败")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.New("存储配额不存在")
+	}
+	return res, nil
+}
+
+// QuotaUsage 获取当前用户及其所属部门的存储配额与已用空间
+func (s *sAttachment) QuotaUsage(ctx context.Context, req *v1.QuotaUsageReq) (res *v1.QuotaUsageRes, err error) {
+	res = &v1.QuotaUsageRes{}
+
+	userId, _ := ctx.Value(middleware.CtxUserID).(uint64)
+	usage, department, err := s.quotaUsage(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	res.User = usage
+	res.Department = department
+	return res, nil
+}
+
+// RemoveQuotas 删除用户或部门单独设置的存储配额，用户或部门删除时调用，targetIds 为单个ID或ID切片
+func (s *sAttachment) RemoveQuotas(ctx context.Context, targetType string, targetIds interface{}) error {
+	ids := gconv.Uint64s(targetIds)
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := dao.AttachmentQuota.Ctx(ctx).
+		Where(dao.AttachmentQuota.Columns().TargetType, targetType).
+		WhereIn(dao.AttachmentQuota.Columns().TargetId, ids).
+		Delete()
+	if err != nil {
+		return gerror.Wrap(err, "删除存储配额失败")
+	}
+	return nil
+}
+
+// checkQuota 检查当前用户及其所属部门的剩余存储空间是否足够保存 size 字节的新附件，
+// 按附件大小统计，相同内容的附件共用文件时也分别计入上传者的已用空间
+func (s *sAttachment) checkQuota(ctx context.Context, size uint64) error {
+	userId, _ := ctx.Value(middleware.CtxUserID).(uint64)
+	if userId == 0 {
+		return nil
+	}
+	user, department, err := s.quotaUsage(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.QuotaSize > 0 && user.UsedSize+size > user.QuotaSize {
+		return gerror.Newf("存储空间不足：个人配额%s，已使用%s，本次上传%s", formatSize(user.QuotaSize), formatSize(user.UsedSize), formatSize(size))
+	}
+	if department != nil && department.QuotaSize > 0 && department.UsedSize+size > department.QuotaSize {
+		return gerror.Newf("存储空间不足：部门配额%s，已使用%s，本次上传%s", formatSize(department.QuotaSize), formatSize(department.UsedSize), formatSize(size))
+	}
+	return nil
+}
+
+// quotaUsage 查询用户及其所属部门的存储配额与已用空间，用户未分配部门时部门为 nil
+func (s *sAttachment) quotaUsage(ctx context.Context, userId uint64) (user v1.QuotaUsage, department *v1.QuotaUsage, err error) {
+	config, err := s.loadQuotaConfig(ctx)
+	if err != nil {
+		return user, nil, err
+	}
+
+	if user.QuotaSize, err = s.quotaSize(ctx, consts.QuotaTargetUser, userId, config.User); err != nil {
+		return user, nil, err
+	}
+	_, used, err := s.userUsage(ctx, []uint64{userId})
+	if err != nil {
+		return user, nil, err
+	}
+	user.UsedSize = used[userId]
+
+	value, err := dao.User.Ctx(ctx).Where(dao.User.Columns().Id, userId).Value(dao.User.Columns().DepartmentId)
+	if err != nil {
+		return user, nil, gerror.Wrap(err, "查询用户部门失败")
+	}
+	departmentId := value.Uint64()
+	if departmentId == 0 {
+		return user, nil, nil
+	}
+	department = &v1.QuotaUsage{}
+	if department.QuotaSize, err = s.quotaSize(ctx, consts.QuotaTargetDepartment, departmentId, config.Department); err != nil {
+		return user, nil, err
+	}
+	_, used, err = s.departmentUsage(ctx, []uint64{departmentId})
+	if err != nil {
+		return user, nil, err
+	}
+	department.UsedSize = used[departmentId]
+	return user, department, nil
+}
+
+// quotaSize 返回配额对象单独设置的配额，未设置时返回默认配额
+func (s *sAttachment) quotaSize(ctx context.Context, targetType string, targetId, defaultSize uint64) (uint64, error) {
+	var quota *entity.AttachmentQuota
+	err := dao.AttachmentQuota.Ctx(ctx).
+		Where(dao.AttachmentQuota.Columns().TargetType, targetType).
+		Where(dao.AttachmentQuota.Columns().TargetId, targetId).
+		Scan(&quota)
+	if err != nil {
+		return 0, gerror.Wrap(err, "查询存储配额失败")
+	}
+	if quota == nil {
+		return defaultSize, nil
+	}
+	return quota.QuotaSize, nil
+}
+
+// userUsage 查询用户名称及各用户上传的附件总大小
+func (s *sAttachment) userUsage(ctx context.Context, ids []uint64) (names map[uint64]string, used map[uint64]uint64, err error) {
+	names, used = make(map[uint64]string), make(map[uint64]uint64)
+	if len(ids) == 0 {
+		return names, used, nil
+	}
+
+	var users []entity.User
+	err = dao.User.Ctx(ctx).
+		Fields(dao.User.Columns().Id, dao.User.Columns().Username).
+		WhereIn(dao.User.Columns().Id, ids).
+		Scan(&users)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "查询用户失败")
+	}
+	for _, user := range users {
+		names[user.Id] = user.Username
+	}
+
+	var sizes []struct {
+		UploaderId uint64
+		Size       uint64
+	}
+	err = dao.Attachment.Ctx(ctx).
+		Fields(dao.Attachment.Columns().UploaderId, fmt.Sprintf("SUM(%s) AS size", dao.Attachment.Columns().FileSize)).
+		WhereIn(dao.Attachment.Columns().UploaderId, ids).
+		Group(dao.Attachment.Columns().UploaderId).
+		Scan(&sizes)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "统计用户已用空间失败")
+	}
+	for _, size := range sizes {
+		used[size.UploaderId] = size.Size
+	}
+	return names, used, nil
+}
+
+// departmentUsage 查询部门名称及各部门下用户上传的附件总大小（不含子部门）
+func (s *sAttachment) departmentUsage(ctx context.Context, ids []uint64) (names map[uint64]string, used map[uint64]uint64, err error) {
+	names, used = make(map[uint64]string), make(map[uint64]uint64)
+	if len(ids) == 0 {
+		return names, used, nil
+	}
+
+	var departments []entity.Department
+	err = dao.Department.Ctx(ctx).
+		Fields(dao.Department.Columns().Id, dao.Department.Columns().Name).
+		WhereIn(dao.Department.Columns().Id, ids).
+		Scan(&departments)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "查询部门失败")
+	}
+	for _, department := range departments {
+		names[department.Id] = department.Name
+	}
+
+	var (
+		attachment = dao.Attachment.Table()
+		user       = dao.User.Table()
+		sizes      []struct {
+			DepartmentId uint64
+			Size         uint64
+		}
+	)
+	err = dao.Attachment.Ctx(ctx).
+		InnerJoin(user, fmt.Sprintf("%s.%s=%s.%s", attachment, dao.Attachment.Columns().UploaderId, user, dao.User.Columns().Id)).
+		Fields(
+			fmt.Sprintf("%s.%s", user, dao.User.Columns().DepartmentId),
+			fmt.Sprintf("SUM(%s.%s) AS size", attachment, dao.Attachment.Columns().FileSize),
+		).
+		WhereIn(fmt.Sprintf("%s.%s", user, dao.User.Columns().DepartmentId), ids).
+		Group(fmt.Sprintf("%s.%s", user, dao.User.Columns().DepartmentId)).
+		Scan(&sizes)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "统计部门已用空间失败")
+	}
+	for _, size := range sizes {
+		used[size.DepartmentId] = size.Size
+	}
+	return names, used, nil
+}
+
+// loadQuotaConfig 读取默认存储配额配置，返回的配额单位为字节
+func (s *sAttachment) loadQuotaConfig(ctx context.Context) (*quotaConfig, error) {
+	config := &quotaConfig{}
+	value, err := g.Cfg().Get(ctx, "attachment.quota")
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取存储配额配置失败")
+	}
+	if !value.IsNil() {
+		if err = value.Scan(config); err != nil {
+			return nil, gerror.Wrap(err, "解析存储配额配置失败")
+		}
+	}
+	config.User *= 1024 * 1024
+	config.Department *= 1024 * 1024
+	return config, nil
+}
+
+// formatSize 格式化文件大小，用于提示信息
+func formatSize(size uint64) string {
+	switch {
+	case size >= 1024*1024*1024:
+		return fmt.Sprintf("%.2fGB", float64(size)/1024/1024/1024)
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.2fMB", float64(size)/1024/1024)
+	case size >= 1024:
+		return fmt.Sprintf("%.2fKB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%dB", size)
+}
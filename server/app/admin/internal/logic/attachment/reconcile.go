@@ -0,0 +1,228 @@
+package attachment
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/storage"
+	"server/app/admin/internal/model"
+)
+
+// storageRefs 数据库记录引用的存储文件
+type storageRefs struct {
+	files    map[string][]uint64 // 附件文件路径 -> 附件ID
+	stems    map[string]bool     // 附件文件路径去掉扩展名，用于匹配图片规格
+	avatars  map[string][]uint64 // 头像在存储中的 key -> 用户ID
+	sessions map[string]bool     // 未完成的分片上传ID
+}
+
+// Reconcile 比对存储中的文件与数据库记录：找出没有被附件（含图片规格）、用户头像、未完成的分片上传引用的孤儿文件，
+// 以及附件、用户头像引用但存储中不存在的文件；非试运行时将孤儿文件移入隔离目录或删除
+func (s *sAttachment) Reconcile(ctx context.Context, in model.ReconcileInput) (*model.ReconcileOutput, error) {
+	action := in.Action
+	if action == "" {
+		action = consts.ReconcileActionQuarantine
+	}
+	if action != consts.ReconcileActionQuarantine && action != consts.ReconcileActionDelete {
+		return nil, gerror.Newf("不支持的孤儿文件处理方式: %s", action)
+	}
+	minAge := in.MinAge
+	if minAge <= 0 {
+		minAge = consts.ReconcileMinAgeDefault
+	}
+
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	// 先读取数据库记录再遍历存储，遍历期间新保存的文件修改时间晚于 before，不会被当作孤儿文件
+	refs, err := s.loadStorageRefs(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &model.ReconcileOutput{
+		Orphans: make([]model.ReconcileOrphan, 0),
+		Missing: make([]model.ReconcileMissing, 0),
+	}
+	before := time.Now().Add(-minAge)
+	existing := make(map[string]bool)
+	err = store.List(ctx, "", func(key string, info storage.ObjectInfo) error {
+		if strings.HasPrefix(key, consts.QuarantineStoragePrefix+"/") {
+			return nil
+		}
+		out.Scanned++
+		existing[key] = true
+		if refs.referenced(key) || info.ModTime.After(before) {
+			return nil
+		}
+		out.Orphans = append(out.Orphans, model.ReconcileOrphan{Key: key, Size: info.Size, ModTime: info.ModTime})
+		return nil
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "遍历存储文件失败")
+	}
+
+	out.Missing = append(out.Missing, missingFiles("attachment", refs.files, existing)...)
+	out.Missing = append(out.Missing, missingFiles("user", refs.avatars, existing)...)
+
+	if in.DryRun || len(out.Orphans) == 0 {
+		return out, nil
+	}
+	if action == consts.ReconcileActionQuarantine {
+		out.Quarantine = path.Join(consts.QuarantineStoragePrefix, time.Now().Format("20060102150405"))
+	}
+	for i := range out.Orphans {
+		orphan := &out.Orphans[i]
+		if err = s.handleOrphan(ctx, store, orphan.Key, out.Quarantine, before); err != nil {
+			orphan.Error = err.Error()
+			g.Log().Warning(ctx, "处理孤儿文件失败", g.Map{"key": orphan.Key, "action": action, "error": err})
+			continue
+		}
+		orphan.Handled = true
+		out.Handled++
+	}
+	g.Log().Info(ctx, "存储对账已处理孤儿文件", g.Map{"action": action, "count": out.Handled, "quarantine": out.Quarantine})
+	return out, nil
+}
+
+// loadStorageRefs 读取附件、用户头像与未完成的分片上传引用的存储文件
+func (s *sAttachment) loadStorageRefs(ctx context.Context, store storage.Driver) (*storageRefs, error) {
+	refs := &storageRefs{
+		files:    make(map[string][]uint64),
+		stems:    make(map[string]bool),
+		avatars:  make(map[string][]uint64),
+		sessions: make(map[string]bool),
+	}
+
+	var attachments []struct {
+		Id       uint64
+		FilePath string
+	}
+	err := dao.Attachment.Ctx(ctx).
+		Fields(dao.Attachment.Columns().Id, dao.Attachment.Columns().FilePath).
+		WhereNot(dao.Attachment.Columns().FilePath, "").
+		Scan(&attachments)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件文件失败")
+	}
+	for _, attachment := range attachments {
+		refs.files[attachment.FilePath] = append(refs.files[attachment.FilePath], attachment.Id)
+		refs.stems[strings.TrimSuffix(attachment.FilePath, path.Ext(attachment.FilePath))] = true
+	}
+
+	// 头像保存为访问地址，只有以存储访问地址为前缀的头像才是存储中的文件
+	var users []struct {
+		Id     uint64
+		Avatar string
+	}
+	err = dao.User.Ctx(ctx).
+		Fields(dao.User.Columns().Id, dao.User.Columns().Avatar).
+		WhereNot(dao.User.Columns().Avatar, "").
+		Scan(&users)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询用户头像失败")
+	}
+	baseUrl := store.URL("")
+	for _, user := range users {
+		escaped, ok := strings.CutPrefix(user.Avatar, baseUrl)
+		if !ok {
+			continue
+		}
+		key, err := url.PathUnescape(escaped)
+		if err != nil {
+			continue
+		}
+		refs.avatars[key] = append(refs.avatars[key], user.Id)
+	}
+
+	sessions, err := dao.UploadSession.Ctx(ctx).Array(dao.UploadSession.Columns().Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询分片上传会话失败")
+	}
+	for _, id := range sessions {
+		refs.sessions[id.String()] = true
+	}
+	return refs, nil
+}
+
+// referenced 判断存储中的文件是否被数据库记录引用
+func (r *storageRefs) referenced(key string) bool {
+	if len(r.files[key]) > 0 || len(r.avatars[key]) > 0 {
+		return true
+	}
+	// 分片：chunks/{上传ID}/{分片序号}
+	if rest, ok := strings.CutPrefix(key, consts.ChunkStoragePrefix+"/"); ok {
+		uploadId, _, _ := strings.Cut(rest, "/")
+		return r.sessions[uploadId]
+	}
+	// 图片规格：[private/]variants/{规格名称}/{原文件路径（替换扩展名）}，与当前配置的规格无关，原文件存在即视为引用
+	dir, rest := "", key
+	if after, ok := strings.CutPrefix(rest, consts.PrivateStoragePrefix+"/"); ok {
+		dir, rest = consts.PrivateStoragePrefix, after
+	}
+	if after, ok := strings.CutPrefix(rest, consts.ImageVariantPrefix+"/"); ok {
+		if _, filePath, ok := strings.Cut(after, "/"); ok {
+			return r.stems[path.Join(dir, strings.TrimSuffix(filePath, path.Ext(filePath)))]
+		}
+	}
+	return false
+}
+
+// missingFiles 列出 refs（key -> 记录ID）中在存储中不存在的文件，按 key 排序
+func missingFiles(source string, refs map[string][]uint64, existing map[string]bool) []model.ReconcileMissing {
+	keys := make([]string, 0)
+	for key := range refs {
+		if !existing[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	list := make([]model.ReconcileMissing, 0, len(keys))
+	for _, key := range keys {
+		for _, id := range refs[key] {
+			list = append(list, model.ReconcileMissing{Source: source, Id: id, Key: key})
+		}
+	}
+	return list
+}
+
+// handleOrphan 将孤儿文件移入隔离目录 quarantine，quarantine 为空时删除；处理前重新检查文件，避免处理对账期间被重新保存或引用的文件
+func (s *sAttachment) handleOrphan(ctx context.Context, store storage.Driver, key, quarantine string, before time.Time) error {
+	info, err := store.Stat(ctx, key)
+	if err != nil {
+		return err
+	}
+	if info.ModTime.After(before) {
+		return gerror.New("文件在对账期间被修改，已跳过")
+	}
+	count, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().FilePath, key).Count()
+	if err != nil {
+		return gerror.Wrap(err, "查询附件引用失败")
+	}
+	if count > 0 {
+		return gerror.New("文件在对账期间被附件引用，已跳过")
+	}
+
+	if quarantine != "" {
+		reader, err := store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		err = store.Put(ctx, path.Join(quarantine, key), reader, info.Size, "application/octet-stream")
+		reader.Close()
+		if err != nil {
+			return gerror.Wrap(err, "移入隔离目录失败")
+		}
+	}
+	return store.Delete(ctx, key)
+}
@@ -0,0 +1,193 @@
+package attachment
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+)
+
+// References 查询附件被哪些业务数据引用
+func (s *sAttachment) References(ctx context.Context, req *v1.ReferencesReq) (res *v1.ReferencesRes, err error) {
+	res = &v1.ReferencesRes{List: make([]v1.ReferenceInfo, 0)}
+
+	count, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件失败")
+	}
+	if count == 0 {
+		return nil, gerror.New("附件不存在")
+	}
+
+	err = dao.AttachmentReference.Ctx(ctx).
+		Where(dao.AttachmentReference.Columns().AttachmentId, req.Id).
+		OrderAsc(dao.AttachmentReference.Columns().BizType).
+		OrderAsc(dao.AttachmentReference.Columns().Id).
+		Scan(&res.List)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件引用失败")
+	}
+	return res, nil
+}
+
+// SyncReferences 更新业务记录字段引用的附件，替换该字段原有的引用，业务记录新增或修改时调用；
+// urls 可以是附件的文件地址、缩略图地址或私有附件的签名下载地址，不属于任何附件的地址会被忽略
+func (s *sAttachment) SyncReferences(ctx context.Context, bizType string, bizId interface{}, bizField string, urls []string) error {
+	ids, err := s.attachmentIdsByUrls(ctx, urls)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.AttachmentReference.Ctx(ctx).
+		Where(dao.AttachmentReference.Columns().BizType, bizType).
+		Where(dao.AttachmentReference.Columns().BizId, gconv.String(bizId)).
+		Where(dao.AttachmentReference.Columns().BizField, bizField).
+		Delete()
+	if err != nil {
+		return gerror.Wrap(err, "清空附件引用失败")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	list := make([]g.Map, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, g.Map{
+			dao.AttachmentReference.Columns().AttachmentId: id,
+			dao.AttachmentReference.Columns().BizType:      bizType,
+			dao.AttachmentReference.Columns().BizId:        gconv.String(bizId),
+			dao.AttachmentReference.Columns().BizField:     bizField,
+		})
+	}
+	if _, err = dao.AttachmentReference.Ctx(ctx).Data(list).InsertIgnore(); err != nil {
+		return gerror.Wrap(err, "保存附件引用失败")
+	}
+	return nil
+}
+
+// RemoveReferences 删除业务记录的全部附件引用，业务记录删除时调用，bizIds 为单个业务记录ID或ID切片
+func (s *sAttachment) RemoveReferences(ctx context.Context, bizType string, bizIds interface{}) error {
+	ids := gconv.Strings(bizIds)
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := dao.AttachmentReference.Ctx(ctx).
+		Where(dao.AttachmentReference.Columns().BizType, bizType).
+		WhereIn(dao.AttachmentReference.Columns().BizId, ids).
+		Delete()
+	if err != nil {
+		return gerror.Wrap(err, "删除附件引用失败")
+	}
+	return nil
+}
+
+// attachmentIdsByUrls 按地址查找附件ID，相同内容的附件共用文件地址，地址匹配多个附件时全部返回
+func (s *sAttachment) attachmentIdsByUrls(ctx context.Context, urls []string) ([]uint64, error) {
+	var (
+		fileUrls []string
+		ids      []uint64
+		seen     = make(map[uint64]bool)
+	)
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		// 私有附件的签名下载地址中包含附件ID，签名会过期，不参与校验
+		if parsed, err := url.Parse(u); err == nil && strings.HasPrefix(parsed.Path, consts.SignedUrlPath) {
+			if id, err := strconv.ParseUint(strings.TrimPrefix(parsed.Path, consts.SignedUrlPath), 10, 64); err == nil && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+			continue
+		}
+		fileUrls = append(fileUrls, u)
+	}
+	if len(ids) == 0 && len(fileUrls) == 0 {
+		return nil, nil
+	}
+
+	m := dao.Attachment.Ctx(ctx)
+	if len(fileUrls) > 0 {
+		m = m.WhereIn(dao.Attachment.Columns().FileUrl, fileUrls).
+			WhereOrIn(dao.Attachment.Columns().ThumbnailUrl, fileUrls)
+	}
+	if len(ids) > 0 {
+		m = m.WhereOrIn(dao.Attachment.Columns().Id, ids)
+	}
+	values, err := m.Array(dao.Attachment.Columns().Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询引用的附件失败")
+	}
+	result := make([]uint64, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.Uint64())
+	}
+	return result, nil
+}
+
+// checkUnreferenced 检查附件未被业务数据引用，被引用的附件不能删除
+func (s *sAttachment) checkUnreferenced(ctx context.Context, ids ...uint64) error {
+	var references []struct {
+		AttachmentId uint64
+		BizType      string
+		BizField     string
+	}
+	err := dao.AttachmentReference.Ctx(ctx).
+		Fields(
+			dao.AttachmentReference.Columns().AttachmentId,
+			dao.AttachmentReference.Columns().BizType,
+			dao.AttachmentReference.Columns().BizField,
+		).
+		WhereIn(dao.AttachmentReference.Columns().AttachmentId, ids).
+		Limit(1).
+		Scan(&references)
+	if err != nil {
+		return gerror.Wrap(err, "查询附件引用失败")
+	}
+	if len(references) > 0 {
+		reference := references[0]
+		return gerror.Newf("附件（ID %d）正在被 %s.%s 使用，无法删除", reference.AttachmentId, reference.BizType, reference.BizField)
+	}
+	return nil
+}
+
+// fillRefCounts 填充附件列表项被引用的次数
+func (s *sAttachment) fillRefCounts(ctx context.Context, list []v1.AttachmentInfo) error {
+	if len(list) == 0 {
+		return nil
+	}
+	ids := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.Id)
+	}
+
+	var counts []struct {
+		AttachmentId uint64
+		Count        int
+	}
+	err := dao.AttachmentReference.Ctx(ctx).
+		Fields(dao.AttachmentReference.Columns().AttachmentId, "COUNT(*) AS count").
+		WhereIn(dao.AttachmentReference.Columns().AttachmentId, ids).
+		Group(dao.AttachmentReference.Columns().AttachmentId).
+		Scan(&counts)
+	if err != nil {
+		return gerror.Wrap(err, "统计附件引用失败")
+	}
+	countMap := make(map[uint64]int, len(counts))
+	for _, count := range counts {
+		countMap[count.AttachmentId] = count.Count
+	}
+	for i := range list {
+		list[i].RefCount = countMap[list[i].Id]
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+)
+
+// Stats 获取附件统计：总量、按文件类型与扩展名统计、上传量最多的上传者及每日上传量
+func (s *sAttachment) Stats(ctx context.Context, req *v1.StatsReq) (res *v1.StatsRes, err error) {
+	res = &v1.StatsRes{
+		ByType:       make([]v1.StatsGroup, 0),
+		ByExt:        make([]v1.StatsGroup, 0),
+		TopUploaders: make([]v1.UploaderStats, 0),
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = consts.AttachmentStatsDaysDefault
+	}
+	top := req.Top
+	if top <= 0 {
+		top = consts.AttachmentStatsTopDefault
+	}
+	countField := "COUNT(*) AS count"
+	sizeField := fmt.Sprintf("SUM(%s) AS size", dao.Attachment.Columns().FileSize)
+
+	// 总量
+	var total struct {
+		Count int
+		Size  uint64
+	}
+	if err = dao.Attachment.Ctx(ctx).Fields(countField, sizeField).Scan(&total); err != nil {
+		return nil, gerror.Wrap(err, "统计附件总量失败")
+	}
+	res.TotalCount = total.Count
+	res.TotalSize = total.Size
+
+	// 相同内容的附件共用一个存储文件，按文件路径去重统计实际占用的存储空间
+	files := dao.Attachment.Ctx(ctx).
+		Fields(fmt.Sprintf("MAX(%s) AS size", dao.Attachment.Columns().FileSize)).
+		WhereNot(dao.Attachment.Columns().FilePath, "").
+		Group(dao.Attachment.Columns().FilePath)
+	storageSize, err := dao.Attachment.DB().Model("? AS files", files).Ctx(ctx).Value("SUM(size)")
+	if err != nil {
+		return nil, gerror.Wrap(err, "统计存储空间失败")
+	}
+	res.StorageSize = storageSize.Uint64()
+
+	// 按文件类型、扩展名统计
+	err = dao.Attachment.Ctx(ctx).
+		Fields(dao.Attachment.Columns().FileType+" AS name", countField, sizeField).
+		Group(dao.Attachment.Columns().FileType).
+		OrderDesc("count").
+		Scan(&res.ByType)
+	if err != nil {
+		return nil, gerror.Wrap(err, "按文件类型统计附件失败")
+	}
+	err = dao.Attachment.Ctx(ctx).
+		Fields(dao.Attachment.Columns().FileExt+" AS name", countField, sizeField).
+		Group(dao.Attachment.Columns().FileExt).
+		OrderDesc("count").
+		Limit(top).
+		Scan(&res.ByExt)
+	if err != nil {
+		return nil, gerror.Wrap(err, "按扩展名统计附件失败")
+	}
+
+	// 上传量最多的上传者
+	err = dao.Attachment.Ctx(ctx).
+		Fields(
+			dao.Attachment.Columns().UploaderId,
+			fmt.Sprintf("MAX(%s) AS uploader_name", dao.Attachment.Columns().UploaderName),
+			countField,
+			sizeField,
+		).
+		Group(dao.Attachment.Columns().UploaderId).
+		OrderDesc("size").
+		Limit(top).
+		Scan(&res.TopUploaders)
+	if err != nil {
+		return nil, gerror.Wrap(err, "按上传者统计附件失败")
+	}
+
+	res.Daily, err = s.dailyStats(ctx, days, countField, sizeField)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// dailyStats 统计最近 days 天（含今天）的每日上传量，没有上传的日期补 0
+func (s *sAttachment) dailyStats(ctx context.Context, days int, countField, sizeField string) ([]v1.DailyStats, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1-days)
+
+	dateField := fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", dao.Attachment.Columns().CreatedAt)
+	var list []v1.DailyStats
+	err := dao.Attachment.Ctx(ctx).
+		Fields(dateField+" AS date", countField, sizeField).
+		WhereGTE(dao.Attachment.Columns().CreatedAt, gtime.New(start)).
+		Group(dateField).
+		Scan(&list)
+	if err != nil {
+		return nil, gerror.Wrap(err, "统计每日上传量失败")
+	}
+	stats := make(map[string]v1.DailyStats, len(list))
+	for _, item := range list {
+		stats[item.Date] = item
+	}
+
+	daily := make([]v1.DailyStats, 0, days)
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i).Format("2006-01-02")
+		item, ok := stats[date]
+		if !ok {
+			item = v1.DailyStats{Date: date}
+		}
+		daily = append(daily, item)
+	}
+	return daily, nil
+}
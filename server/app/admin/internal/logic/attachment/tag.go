@@ -0,0 +1,220 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/model/entity"
+)
+
+// TagList 获取附件标签列表，附带使用各标签的附件数
+func (s *sAttachment) TagList(ctx context.Context, req *v1.TagListReq) (res *v1.TagListRes, err error) {
+	res = &v1.TagListRes{List: make([]v1.TagInfo, 0)}
+
+	err = dao.AttachmentTag.Ctx(ctx).OrderAsc(dao.AttachmentTag.Columns().Name).Scan(&res.List)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询标签列表失败")
+	}
+	if len(res.List) == 0 {
+		return res, nil
+	}
+
+	// 统计使用各标签的附件数
+	var counts []struct {
+		TagId uint64
+		Count int
+	}
+	err = dao.AttachmentTagRelation.Ctx(ctx).
+		Fields(dao.AttachmentTagRelation.Columns().TagId, "COUNT(*) AS count").
+		Group(dao.AttachmentTagRelation.Columns().TagId).
+		Scan(&counts)
+	if err != nil {
+		return nil, gerror.Wrap(err, "统计标签附件数失败")
+	}
+	countMap := make(map[uint64]int, len(counts))
+	for _, count := range counts {
+		countMap[count.TagId] = count.Count
+	}
+	for i := range res.List {
+		res.List[i].Count = countMap[res.List[i].Id]
+	}
+	return res, nil
+}
+
+// TagDelete 删除附件标签，同时移除附件上的该标签
+func (s *sAttachment) TagDelete(ctx context.Context, req *v1.TagDeleteReq) (res *v1.TagDeleteRes, err error) {
+	res = &v1.TagDeleteRes{}
+
+	count, err := dao.AttachmentTag.Ctx(ctx).Where(dao.AttachmentTag.Columns().Id, req.Id).Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询标签失败")
+	}
+	if count == 0 {
+		return nil, gerror.New("标签不存在")
+	}
+
+	err = dao.AttachmentTag.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		_, err := dao.AttachmentTagRelation.Ctx(ctx).Where(dao.AttachmentTagRelation.Columns().TagId, req.Id).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "移除附件标签失败")
+		}
+		_, err = dao.AttachmentTag.Ctx(ctx).Where(dao.AttachmentTag.Columns().Id, req.Id).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "删除标签失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetTags 设置附件标签，整体替换附件的标签，不存在的标签会自动创建
+func (s *sAttachment) SetTags(ctx context.Context, req *v1.SetTagsReq) (res *v1.SetTagsRes, err error) {
+	res = &v1.SetTagsRes{}
+
+	count, err := dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件失败")
+	}
+	if count == 0 {
+		return nil, gerror.New("附件不存在")
+	}
+	names, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dao.AttachmentTagRelation.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		tagIds, err := s.ensureTags(ctx, names)
+		if err != nil {
+			return err
+		}
+		_, err = dao.AttachmentTagRelation.Ctx(ctx).Where(dao.AttachmentTagRelation.Columns().AttachmentId, req.Id).Delete()
+		if err != nil {
+			return gerror.Wrap(err, "清空附件标签失败")
+		}
+		if len(tagIds) == 0 {
+			return nil
+		}
+		list := make([]g.Map, 0, len(tagIds))
+		for _, tagId := range tagIds {
+			list = append(list, g.Map{
+				dao.AttachmentTagRelation.Columns().AttachmentId: req.Id,
+				dao.AttachmentTagRelation.Columns().TagId:        tagId,
+			})
+		}
+		if _, err = dao.AttachmentTagRelation.Ctx(ctx).Data(list).Insert(); err != nil {
+			return gerror.Wrap(err, "保存附件标签失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ensureTags 返回标签名称对应的标签ID，不存在的标签会自动创建
+func (s *sAttachment) ensureTags(ctx context.Context, names []string) ([]uint64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var tags []entity.AttachmentTag
+	err := dao.AttachmentTag.Ctx(ctx).WhereIn(dao.AttachmentTag.Columns().Name, names).Scan(&tags)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询标签失败")
+	}
+	existing := make(map[string]uint64, len(tags))
+	for _, tag := range tags {
+		existing[strings.ToLower(tag.Name)] = tag.Id
+	}
+
+	tagIds := make([]uint64, 0, len(names))
+	for _, name := range names {
+		if id, ok := existing[strings.ToLower(name)]; ok {
+			tagIds = append(tagIds, id)
+			continue
+		}
+		id, err := dao.AttachmentTag.Ctx(ctx).Data(g.Map{dao.AttachmentTag.Columns().Name: name}).InsertAndGetId()
+		if err != nil {
+			return nil, gerror.Wrap(err, "创建标签失败")
+		}
+		tagIds = append(tagIds, uint64(id))
+	}
+	return tagIds, nil
+}
+
+// fillTags 填充附件列表项的标签
+func (s *sAttachment) fillTags(ctx context.Context, list []v1.AttachmentInfo) error {
+	if len(list) == 0 {
+		return nil
+	}
+	ids := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.Id)
+	}
+
+	var (
+		relation = dao.AttachmentTagRelation.Table()
+		tag      = dao.AttachmentTag.Table()
+		tags     []struct {
+			AttachmentId uint64
+			Name         string
+		}
+	)
+	err := dao.AttachmentTagRelation.Ctx(ctx).
+		InnerJoin(tag, fmt.Sprintf("%s.%s=%s.%s", relation, dao.AttachmentTagRelation.Columns().TagId, tag, dao.AttachmentTag.Columns().Id)).
+		Fields(
+			fmt.Sprintf("%s.%s", relation, dao.AttachmentTagRelation.Columns().AttachmentId),
+			fmt.Sprintf("%s.%s", tag, dao.AttachmentTag.Columns().Name),
+		).
+		WhereIn(fmt.Sprintf("%s.%s", relation, dao.AttachmentTagRelation.Columns().AttachmentId), ids).
+		OrderAsc(fmt.Sprintf("%s.%s", tag, dao.AttachmentTag.Columns().Name)).
+		Scan(&tags)
+	if err != nil {
+		return gerror.Wrap(err, "查询附件标签失败")
+	}
+	tagMap := make(map[uint64][]string)
+	for _, item := range tags {
+		tagMap[item.AttachmentId] = append(tagMap[item.AttachmentId], item.Name)
+	}
+	for i := range list {
+		list[i].Tags = tagMap[list[i].Id]
+		if list[i].Tags == nil {
+			list[i].Tags = []string{}
+		}
+	}
+	return nil
+}
+
+// normalizeTags 规范化标签名称：去除首尾空白、忽略空标签、按名称去重（不区分大小写），并校验数量与长度
+func normalizeTags(tags []string) ([]string, error) {
+	names := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		name := strings.TrimSpace(tag)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		if utf8.RuneCountInString(name) > consts.AttachmentTagNameMax {
+			return nil, gerror.Newf("标签名称不能超过%d个字符: %s", consts.AttachmentTagNameMax, name)
+		}
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	if len(names) > consts.AttachmentTagMax {
+		return nil, gerror.Newf("每个附件最多%d个标签", consts.AttachmentTagMax)
+	}
+	return names, nil
+}
@@ -0,0 +1,265 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/imaging"
+	"server/app/admin/internal/library/storage"
+	"server/app/admin/internal/model/entity"
+)
+
+// variantFormats 图片规格可能的输出格式，输出格式与原图格式有关
+var variantFormats = []string{imaging.FormatJPEG, imaging.FormatPNG, imaging.FormatWebP}
+
+// imageConfig 图片处理配置（attachment.image）
+type imageConfig struct {
+	Variants  []imaging.Variant `json:"variants"`  // 图片规格，未配置时使用 imaging.DefaultVariants
+	Thumbnail string            `json:"thumbnail"` // 作为缩略图的图片规格名称，默认 thumb，不存在时使用第一个规格
+}
+
+// RegenerateVariants 按当前配置重新生成图片附件的缩略图等图片规格，并更新引用同一文件的附件的缩略图地址
+func (s *sAttachment) RegenerateVariants(ctx context.Context, req *v1.RegenerateVariantsReq) (res *v1.RegenerateVariantsRes, err error) {
+	res = &v1.RegenerateVariantsRes{}
+
+	var attachment entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(dao.Attachment.Columns().Id, req.Id).Scan(&attachment)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询附件失败")
+	}
+	if attachment.Id == 0 {
+		return nil, gerror.New("附件不存在")
+	}
+	if attachment.IsImage != 1 {
+		return nil, gerror.New("附件不是图片")
+	}
+	if attachment.FileSize > consts.ImageProcessSizeMax {
+		return nil, gerror.Newf("图片大小超过%dMB，不支持生成缩略图", consts.ImageProcessSizeMax/1024/1024)
+	}
+
+	store, err := storage.Default(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "初始化存储失败")
+	}
+	reader, err := store.Get(ctx, attachment.FilePath)
+	if errors.Is(err, storage.ErrNotExist) {
+		return nil, gerror.New("文件不存在")
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取文件失败")
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(io.LimitReader(reader, consts.ImageProcessSizeMax+1))
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取文件失败")
+	}
+
+	// 先删除已有的图片规格，配置修改后不再使用的规格也一并删除
+	s.removeVariants(ctx, store, attachment.FilePath)
+	variants, err := s.generateVariants(ctx, store, &attachment, content)
+	if err != nil {
+		return nil, err
+	}
+	_, err = dao.Attachment.Ctx(ctx).
+		Where(dao.Attachment.Columns().FilePath, attachment.FilePath).
+		Data(dao.Attachment.Columns().ThumbnailUrl, attachment.ThumbnailUrl).
+		Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "更新缩略图地址失败")
+	}
+
+	res.ThumbnailUrl = attachment.ThumbnailUrl
+	res.Variants = variants
+	// 私有图片的图片规格不能直接访问，替换为签名下载地址
+	if attachment.IsPrivate == 1 {
+		config, err := s.loadImageConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range res.Variants {
+			if res.Variants[i].Url, _, err = s.signUrl(ctx, attachment.Id, res.Variants[i].Name, 0); err != nil {
+				return nil, err
+			}
+			if res.Variants[i].Name == config.Thumbnail {
+				res.ThumbnailUrl = res.Variants[i].Url
+			}
+		}
+	}
+	return res, nil
+}
+
+// storeBlob 保存文件内容到存储，并将保存内容的SHA-256写入 blob.FileHash，处理前内容的SHA-256写入 blob.SourceHash
+// 不超过 consts.ImageProcessSizeMax 的图片会先去除 EXIF 等元数据再保存，同时返回处理后的内容用于生成缩略图
+func (s *sAttachment) storeBlob(ctx context.Context, store storage.Driver, reader io.Reader, blob *entity.Attachment) (content []byte, err error) {
+	if blob.IsImage != 1 || blob.FileSize > consts.ImageProcessSizeMax {
+		hasher := sha256.New()
+		if err = store.Put(ctx, blob.FilePath, io.TeeReader(reader, hasher), int64(blob.FileSize), blob.FileType); err != nil {
+			return nil, err
+		}
+		blob.FileHash = hex.EncodeToString(hasher.Sum(nil))
+		blob.SourceHash = blob.FileHash
+		return nil, nil
+	}
+
+	source, err := io.ReadAll(io.LimitReader(reader, consts.ImageProcessSizeMax+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(source)) != blob.FileSize {
+		return nil, gerror.Newf("文件内容长度 %d 与文件大小 %d 不一致", len(source), blob.FileSize)
+	}
+	sum := sha256.Sum256(source)
+	blob.SourceHash = hex.EncodeToString(sum[:])
+
+	content = imaging.StripMetadata(source)
+	if err = store.Put(ctx, blob.FilePath, bytes.NewReader(content), int64(len(content)), blob.FileType); err != nil {
+		return nil, err
+	}
+	sum = sha256.Sum256(content)
+	blob.FileHash = hex.EncodeToString(sum[:])
+	blob.FileSize = uint64(len(content))
+	return content, nil
+}
+
+// prepareThumbnail 为新保存的图片生成图片规格并设置缩略图地址，无法生成时（如 SVG、超过大小限制）使用原图地址
+func (s *sAttachment) prepareThumbnail(ctx context.Context, store storage.Driver, blob *entity.Attachment, content []byte) {
+	if blob.IsImage != 1 {
+		return
+	}
+	blob.ThumbnailUrl = blob.FileUrl
+	if content == nil {
+		return
+	}
+	if _, err := s.generateVariants(ctx, store, blob, content); err != nil {
+		blob.ThumbnailUrl = blob.FileUrl
+		g.Log().Warning(ctx, "生成缩略图失败，使用原图作为缩略图", g.Map{"path": blob.FilePath, "error": err})
+	}
+}
+
+// generateVariants 按配置的图片规格生成图片并保存到存储，设置 blob.ThumbnailUrl
+func (s *sAttachment) generateVariants(ctx context.Context, store storage.Driver, blob *entity.Attachment, content []byte) ([]v1.ImageVariant, error) {
+	config, err := s.loadImageConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	img, format, err := imaging.Decode(content)
+	if err != nil {
+		return nil, gerror.Wrap(err, "解析图片失败")
+	}
+
+	variants := make([]v1.ImageVariant, 0, len(config.Variants))
+	for _, variant := range config.Variants {
+		outputFormat := variant.OutputFormat(format)
+		resized := imaging.Resize(img, variant)
+		data, contentType, err := imaging.Encode(resized, outputFormat, variant.Quality)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "生成图片规格 %s 失败", variant.Name)
+		}
+		key := s.variantKey(blob.FilePath, variant.Name, imaging.Extension(outputFormat))
+		if err = store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+			return nil, gerror.Wrapf(err, "保存图片规格 %s 失败", variant.Name)
+		}
+
+		url := store.URL(key)
+		if variant.Name == config.Thumbnail {
+			blob.ThumbnailUrl = url
+		}
+		variants = append(variants, v1.ImageVariant{
+			Name:   variant.Name,
+			Url:    url,
+			Width:  resized.Bounds().Dx(),
+			Height: resized.Bounds().Dy(),
+			Size:   len(data),
+		})
+	}
+	return variants, nil
+}
+
+// removeVariants 删除文件的所有图片规格，删除失败仅记录日志
+func (s *sAttachment) removeVariants(ctx context.Context, store storage.Driver, filePath string) {
+	config, err := s.loadImageConfig(ctx)
+	if err != nil {
+		g.Log().Warning(ctx, "读取图片处理配置失败，图片规格未删除", g.Map{"path": filePath, "error": err})
+		return
+	}
+	// 逐个删除各输出格式可能对应的文件
+	for _, variant := range config.Variants {
+		for _, format := range variantFormats {
+			key := s.variantKey(filePath, variant.Name, imaging.Extension(format))
+			if err = store.Delete(ctx, key); err != nil {
+				g.Log().Warning(ctx, "删除图片规格失败", g.Map{"path": key, "error": err})
+			}
+		}
+	}
+}
+
+// findVariant 查找文件的图片规格，返回其在存储中的 key 与 Content-Type
+func (s *sAttachment) findVariant(ctx context.Context, store storage.Driver, filePath, name string) (key, contentType string, err error) {
+	for _, format := range variantFormats {
+		key = s.variantKey(filePath, name, imaging.Extension(format))
+		exists, err := store.Exists(ctx, key)
+		if err != nil {
+			return "", "", gerror.Wrap(err, "检查图片规格是否存在失败")
+		}
+		if exists {
+			return key, imaging.ContentType(format), nil
+		}
+	}
+	return "", "", gerror.Newf("图片规格 %s 不存在", name)
+}
+
+// variantKey 图片规格在存储中的 key：variants/{规格名称}/{原文件路径（替换扩展名）}，私有文件的图片规格同样保存在 private 目录下
+func (s *sAttachment) variantKey(filePath, name, extension string) string {
+	dir := ""
+	if rest, ok := strings.CutPrefix(filePath, consts.PrivateStoragePrefix+"/"); ok {
+		dir, filePath = consts.PrivateStoragePrefix, rest
+	}
+	return path.Join(dir, consts.ImageVariantPrefix, name, strings.TrimSuffix(filePath, path.Ext(filePath))+extension)
+}
+
+// loadImageConfig 读取图片处理配置并校验图片规格
+func (s *sAttachment) loadImageConfig(ctx context.Context) (*imageConfig, error) {
+	config := &imageConfig{}
+	value, err := g.Cfg().Get(ctx, "attachment.image")
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取图片处理配置失败")
+	}
+	if !value.IsNil() {
+		if err = value.Scan(config); err != nil {
+			return nil, gerror.Wrap(err, "解析图片处理配置失败")
+		}
+	}
+
+	if len(config.Variants) == 0 {
+		config.Variants = imaging.DefaultVariants
+	}
+	names := make(map[string]bool, len(config.Variants))
+	for _, variant := range config.Variants {
+		if err = variant.Validate(); err != nil {
+			return nil, gerror.Wrap(err, "图片处理配置不正确")
+		}
+		if names[variant.Name] {
+			return nil, gerror.Newf("图片规格名称重复: %s", variant.Name)
+		}
+		names[variant.Name] = true
+	}
+	if config.Thumbnail == "" {
+		config.Thumbnail = consts.ImageThumbnailDefault
+	}
+	if !names[config.Thumbnail] {
+		config.Thumbnail = config.Variants[0].Name
+	}
+	return config, nil
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 
 	v1 "server/app/admin/api/department/v1"
+	"server/app/admin/internal/consts"
 	"server/app/admin/internal/dao"
+	"server/app/admin/internal/logic/attachment"
 )
 
 type sDepartment struct{}
@@ -203,6 +205,11 @@ func (s *sDepartment) Delete(ctx context.Context, in v1.DeleteReq) (err error) {
 		return gerror.New("该部门下还有子部门，无法删除")
 	}
 
+	// 删除部门单独设置的存储配额
+	if err = attachment.New().RemoveQuotas(ctx, consts.QuotaTargetDepartment, in.Id); err != nil {
+		return err
+	}
+
 	// 删除数据
 	_, err = dao.Department.Ctx(ctx).Where(dao.Department.Columns().Id, in.Id).Delete()
 	return gerror.Wrap(err, "删除部门失败")
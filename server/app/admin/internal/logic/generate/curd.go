@@ -13,9 +13,11 @@ import (
 	"server/app/admin/internal/library/generate/curd"
 	"strings"
 
+	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // 代码生成
@@ -45,6 +47,28 @@ func (s *sGenerate) CodeGenRecord(ctx context.Context, req v1.CodeGenRecordReq)
 	}
 	g.Log().Info(ctx, "生成选项:", generateOptions)
 
+	if err := curd.CheckTemplateSet(ctx, generateOptions.TemplateSet); err != nil {
+		return nil, gerror.Wrap(err, "模板集配置错误")
+	}
+
+	// 解析关联表
+	joins, err := convertToJoins(generateOptions.JoinTables)
+	if err != nil {
+		return nil, gerror.Wrap(err, "解析关联表失败")
+	}
+
+	// 解析字段，字段类型取自数据表结构
+	columns, err := convertToColumns(ctx, req.TableName, req.Columns)
+	if err != nil {
+		return nil, gerror.Wrap(err, "解析字段失败")
+	}
+
+	// 解析树表设置
+	tree, err := curd.NewTreeTable(columns, generateOptions)
+	if err != nil {
+		return nil, gerror.Wrap(err, "树表配置错误")
+	}
+
 	// 准备统一的生成配置
 	config := curd.GenerateConfig{
 		TableName:    req.TableName,
@@ -52,7 +76,9 @@ func (s *sGenerate) CodeGenRecord(ctx context.Context, req v1.CodeGenRecordReq)
 		EntityName:   convertToGoField(req.PackageName),
 		PackageName:  req.PackageName,
 		ModuleName:   req.ModuleName,
-		Columns:      convertToColumns(req.Columns),
+		Columns:      columns,
+		Joins:        joins,
+		Tree:         tree,
 		Options:      generateOptions,
 	}
 
@@ -173,6 +199,28 @@ func (s *sGenerate) BatchGenerateFullStack(ctx context.Context, requests []v1.Co
 			return gerror.Wrapf(err, "模块 %s 解析生成选项失败", req.ModuleName)
 		}
 
+		if err := curd.CheckTemplateSet(ctx, generateOptions.TemplateSet); err != nil {
+			return gerror.Wrapf(err, "模块 %s 模板集配置错误", req.ModuleName)
+		}
+
+		// 解析关联表
+		joins, err := convertToJoins(generateOptions.JoinTables)
+		if err != nil {
+			return gerror.Wrapf(err, "模块 %s 解析关联表失败", req.ModuleName)
+		}
+
+		// 解析字段，字段类型取自数据表结构
+		columns, err := convertToColumns(ctx, req.TableName, req.Columns)
+		if err != nil {
+			return gerror.Wrapf(err, "模块 %s 解析字段失败", req.ModuleName)
+		}
+
+		// 解析树表设置
+		tree, err := curd.NewTreeTable(columns, generateOptions)
+		if err != nil {
+			return gerror.Wrapf(err, "模块 %s 树表配置错误", req.ModuleName)
+		}
+
 		// 准备配置
 		config := curd.GenerateConfig{
 			TableName:    req.TableName,
@@ -180,7 +228,9 @@ func (s *sGenerate) BatchGenerateFullStack(ctx context.Context, requests []v1.Co
 			EntityName:   convertToGoField(req.PackageName),
 			PackageName:  req.PackageName,
 			ModuleName:   req.ModuleName,
-			Columns:      convertToColumns(req.Columns),
+			Columns:      columns,
+			Joins:        joins,
+			Tree:         tree,
 			Options:      generateOptions,
 		}
 
@@ -202,8 +252,66 @@ func (s *sGenerate) BatchGenerateFullStack(ctx context.Context, requests []v1.Co
 	return nil
 }
 
-// convertToColumns 将API字段转换为生成器字段
-func convertToColumns(apiColumns interface{}) []curd.Column {
+// convertToColumns 将API字段转换为生成器字段，字段类型、可空、默认值及校验约束取自数据表结构，
+// 绑定字典的字段以字典值作为可选值
+func convertToColumns(ctx context.Context, tableName string, apiColumns interface{}) ([]curd.Column, error) {
+	metas, err := generate.GetTableColumnsWithDetail(tableName)
+	if err != nil {
+		return nil, err
+	}
+	metaMap := make(map[string]generate.ColumnMeta, len(metas))
+	for _, meta := range metas {
+		metaMap[meta.ColumnName] = meta
+	}
+
+	columns := parseColumns(apiColumns)
+	for i := range columns {
+		meta, ok := metaMap[columns[i].ColumnName]
+		if !ok {
+			// 表中不存在的字段沿用配置的类型
+			if _, ok = generate.GoTypeNameMap[columns[i].GoType]; !ok {
+				columns[i].GoType = generate.GoTypeString
+			}
+			continue
+		}
+		applyColumnMeta(&columns[i], meta)
+	}
+
+	for i := range columns {
+		if columns[i].DictType == "" {
+			continue
+		}
+		values, err := dao.Dict.Ctx(ctx).
+			Where(dao.Dict.Columns().DictType, columns[i].DictType).
+			Where(dao.Dict.Columns().Status, 1).
+			OrderAsc(dao.Dict.Columns().Id).
+			Array(dao.Dict.Columns().DictValue)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "查询字典 %s 失败", columns[i].DictType)
+		}
+		columns[i].EnumValues = gconv.Strings(values)
+	}
+	return columns, nil
+}
+
+// applyColumnMeta 根据字段元数据设置字段类型、可空、默认值及校验约束，
+// 非空且无默认值的字段（自增主键除外）视为必填
+func applyColumnMeta(column *curd.Column, meta generate.ColumnMeta) {
+	column.GoType = generate.ColumnGoType(meta)
+	column.MaxLength = generate.ColumnMaxLength(meta)
+	column.MinValue, column.MaxValue = generate.ColumnRange(meta)
+	column.EnumValues = generate.ColumnEnumValues(meta)
+	column.IsNullable = meta.IsNullable == "YES"
+	if meta.HasDefault {
+		column.DefaultValue = meta.ColumnDefault
+	}
+	if !column.IsNullable && !meta.HasDefault && !strings.Contains(meta.Extra, "auto_increment") {
+		column.IsRequired = true
+	}
+}
+
+// parseColumns 解析API提交的字段配置
+func parseColumns(apiColumns interface{}) []curd.Column {
 	var columns []curd.Column
 
 	// 处理字符串类型的JSON数据
@@ -218,14 +326,16 @@ func convertToColumns(apiColumns interface{}) []curd.Column {
 				ColumnName:     colInfo.FieldName,
 				ColumnComment:  colInfo.FieldComment,
 				GoField:        convertToGoField(colInfo.FieldName),   // 转换为Go字段名
-				GoType:         convertToGoType(colInfo.HtmlType),     // 转换为Go类型
 				JsonField:      convertToJsonField(colInfo.FieldName), // 转换为JSON字段名
-				IsRequired:     colInfo.IsRequired,                    // 根据是否可空判断必填
+				IsRequired:     colInfo.IsRequired,                    // 是否必填，非空字段另由表结构补充
 				IsQuery:        colInfo.IsQuery,                       // 判断是否用于查询
 				IsList:         colInfo.IsList,                        // 判断是否用于列表显示
 				IsPointer:      true,                                  // 可空字段使用指针
 				IsUnique:       colInfo.IsUnique,                      // 是否唯一
 				ValidationRule: colInfo.ValidationRules,               // 生成验证规则
+				DictType:       colInfo.DictType,                      // 绑定的字典
+				QueryType:      colInfo.QueryType,                     // 查询条件
+				HtmlType:       colInfo.HtmlType,                      // 表单组件
 			}
 			columns = append(columns, column)
 		}
@@ -247,6 +357,9 @@ func convertToColumns(apiColumns interface{}) []curd.Column {
 					IsPointer:      getBool(colMap, "isPointer"),
 					IsUnique:       getBool(colMap, "isUnique"),
 					ValidationRule: getString(colMap, "validationRule"),
+					DictType:       getString(colMap, "dictType"),
+					QueryType:      getString(colMap, "queryType"),
+					HtmlType:       getString(colMap, "htmlType"),
 				}
 				columns = append(columns, column)
 			}
@@ -256,6 +369,42 @@ func convertToColumns(apiColumns interface{}) []curd.Column {
 	return columns
 }
 
+// convertToJoins 将关联表配置转换为生成器关联表，一对多子表读取表结构作为可编辑字段
+func convertToJoins(joinTables []consts.JoinTableConfig) ([]curd.JoinTable, error) {
+	var joins []curd.JoinTable
+	for _, joinTable := range joinTables {
+		tableComment, err := generate.GetTableComment(joinTable.TableName)
+		if err != nil {
+			return nil, err
+		}
+
+		var columns []curd.Column
+		if joinTable.Relation == consts.RelationOneToMany {
+			metas, err := generate.GetTableColumnsWithDetail(joinTable.TableName)
+			if err != nil {
+				return nil, err
+			}
+			for _, meta := range metas {
+				columns = append(columns, curd.Column{
+					ColumnName:    meta.ColumnName,
+					ColumnComment: meta.ColumnComment,
+					GoField:       convertToGoField(meta.ColumnName),
+					JsonField:     gstr.CaseCamelLower(meta.ColumnName), // 与实体JSON字段保持一致
+					IsPointer:     true,
+				})
+				applyColumnMeta(&columns[len(columns)-1], meta)
+			}
+		}
+
+		join, err := curd.NewJoinTable(joinTable, tableComment, columns)
+		if err != nil {
+			return nil, err
+		}
+		joins = append(joins, join)
+	}
+	return joins, nil
+}
+
 // 辅助转换函数
 func convertToGoField(columnName string) string {
 	// 将数据库字段名转换为Go字段名（驼峰命名）
@@ -267,24 +416,6 @@ func convertToJsonField(columnName string) string {
 	return gstr.CaseSnake(columnName)
 }
 
-func convertToGoType(dataType string) string {
-	// 根据数据库类型转换为Go类型
-	switch strings.ToLower(dataType) {
-	case "int", "tinyint", "smallint", "mediumint":
-		return "int"
-	case "bigint":
-		return "int64"
-	case "varchar", "char", "text", "longtext", "mediumtext":
-		return "string"
-	case "decimal", "float", "double":
-		return "float64"
-	case "json":
-		return "string"
-	default:
-		return "string"
-	}
-}
-
 // 辅助函数
 func getString(m map[string]interface{}, key string) string {
 	if v, ok := m[key]; ok {
@@ -364,7 +495,7 @@ func (s *sGenerate) executeGfGenCtrl(ctx context.Context) error {
 	return nil
 }
 
-// createMenuIfConfigured 如果配置了菜单信息则创建菜单
+// createMenuIfConfigured 如果配置了菜单信息则创建菜单、按钮权限并授予角色
 func (s *sGenerate) createMenuIfConfigured(ctx context.Context, options *consts.GenerateOptions, config curd.GenerateConfig) error {
 	// 检查是否配置了菜单信息
 	if options.MenuName == "" {
@@ -377,9 +508,42 @@ func (s *sGenerate) createMenuIfConfigured(ctx context.Context, options *consts.
 	componentPath := fmt.Sprintf("%s/index", config.ModuleName)
 	routeName := gstr.CaseCamel(config.ModuleName)
 
+	return dao.Menu.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		menuId, err := s.createModuleMenu(ctx, options, routeName, menuPath, componentPath)
+		if err != nil {
+			return err
+		}
+
+		// 为每个启用的操作创建按钮权限
+		menuIds := []uint64{menuId}
+		for i, button := range generate.GetButtonPermissions(config.ModuleName, options) {
+			buttonId, err := s.createButtonMenu(ctx, menuId, routeName, i, button)
+			if err != nil {
+				return err
+			}
+			menuIds = append(menuIds, buttonId)
+		}
+
+		// 将菜单和按钮权限授予选定的角色
+		return s.grantMenusToRoles(ctx, options.RoleIds, menuIds)
+	})
+}
+
+// createModuleMenu 创建模块菜单，已存在时返回已有菜单ID
+func (s *sGenerate) createModuleMenu(ctx context.Context, options *consts.GenerateOptions, routeName, menuPath, componentPath string) (uint64, error) {
+	// 检查菜单名称是否已存在
+	existId, err := dao.Menu.Ctx(ctx).Where(dao.Menu.Columns().Name, routeName).Value(dao.Menu.Columns().Id)
+	if err != nil {
+		return 0, gerror.Wrap(err, "检查菜单名称失败")
+	}
+	if !existId.IsEmpty() {
+		g.Log().Warningf(ctx, "菜单路由名称 %s 已存在，跳过菜单创建", routeName)
+		return existId.Uint64(), nil
+	}
+
 	// 准备菜单数据
 	menuData := g.Map{
-		"menuType":  0, // 菜单类型：0菜单
+		"menuType":  consts.MenuTypeMenu, // 菜单类型：0菜单
 		"parentId":  options.ParentMenuId,
 		"title":     options.MenuName,
 		"name":      routeName,
@@ -391,21 +555,11 @@ func (s *sGenerate) createMenuIfConfigured(ctx context.Context, options *consts.
 		"keepAlive": 0, // 缓存页面
 	}
 
-	// 检查菜单名称是否已存在
-	count, err := dao.Menu.Ctx(ctx).Where(dao.Menu.Columns().Name, routeName).Count()
-	if err != nil {
-		return gerror.Wrap(err, "检查菜单名称失败")
-	}
-	if count > 0 {
-		g.Log().Warningf(ctx, "菜单路由名称 %s 已存在，跳过菜单创建", routeName)
-		return nil
-	}
-
 	// 检查父级菜单是否存在（如果指定了父级菜单）
 	if options.ParentMenuId > 0 {
 		parentCount, err := dao.Menu.Ctx(ctx).Where(dao.Menu.Columns().Id, options.ParentMenuId).Count()
 		if err != nil {
-			return gerror.Wrap(err, "检查父级菜单失败")
+			return 0, gerror.Wrap(err, "检查父级菜单失败")
 		}
 		if parentCount == 0 {
 			g.Log().Warningf(ctx, "父级菜单ID %d 不存在，将创建为顶级菜单", options.ParentMenuId)
@@ -416,9 +570,89 @@ func (s *sGenerate) createMenuIfConfigured(ctx context.Context, options *consts.
 	// 插入菜单数据
 	id, err := dao.Menu.Ctx(ctx).Data(menuData).InsertAndGetId()
 	if err != nil {
-		return gerror.Wrap(err, "创建菜单失败")
+		return 0, gerror.Wrap(err, "创建菜单失败")
 	}
 
 	g.Log().Infof(ctx, "菜单创建成功，ID: %d, 名称: %s, 路径: %s", id, options.MenuName, menuPath)
+	return uint64(id), nil
+}
+
+// createButtonMenu 在模块菜单下创建按钮权限，相同权限标识已存在时直接复用
+func (s *sGenerate) createButtonMenu(ctx context.Context, menuId uint64, routeName string, rank int, button generate.ButtonPermission) (uint64, error) {
+	existId, err := dao.Menu.Ctx(ctx).
+		Where(dao.Menu.Columns().ParentId, menuId).
+		Where(dao.Menu.Columns().MenuType, consts.MenuTypeButton).
+		Where(dao.Menu.Columns().Auths, button.Auth).
+		Value(dao.Menu.Columns().Id)
+	if err != nil {
+		return 0, gerror.Wrapf(err, "检查按钮权限 %s 失败", button.Auth)
+	}
+	if !existId.IsEmpty() {
+		return existId.Uint64(), nil
+	}
+
+	id, err := dao.Menu.Ctx(ctx).Data(g.Map{
+		"menuType": consts.MenuTypeButton, // 菜单类型：3按钮
+		"parentId": menuId,
+		"title":    button.Title,
+		"name":     routeName + gstr.UcFirst(button.Option),
+		"auths":    button.Auth,
+		"rank":     rank + 1,
+		"showLink": 0,
+	}).InsertAndGetId()
+	if err != nil {
+		return 0, gerror.Wrapf(err, "创建按钮权限 %s 失败", button.Auth)
+	}
+
+	g.Log().Infof(ctx, "按钮权限创建成功，ID: %d, 名称: %s, 权限标识: %s", id, button.Title, button.Auth)
+	return uint64(id), nil
+}
+
+// grantMenusToRoles 将菜单授予角色（跳过已存在的关联）
+func (s *sGenerate) grantMenusToRoles(ctx context.Context, roleIds, menuIds []uint64) error {
+	if len(roleIds) == 0 || len(menuIds) == 0 {
+		return nil
+	}
+
+	// 只授予存在的角色
+	validRoleIds, err := dao.Role.Ctx(ctx).WhereIn(dao.Role.Columns().Id, roleIds).Array(dao.Role.Columns().Id)
+	if err != nil {
+		return gerror.Wrap(err, "查询角色失败")
+	}
+	if len(validRoleIds) != len(roleIds) {
+		g.Log().Warningf(ctx, "部分角色不存在，仅授予已存在的角色: %v", validRoleIds)
+	}
+
+	for _, roleId := range validRoleIds {
+		granted, err := dao.RoleMenu.Ctx(ctx).
+			Where(dao.RoleMenu.Columns().RoleId, roleId).
+			WhereIn(dao.RoleMenu.Columns().MenuId, menuIds).
+			Array(dao.RoleMenu.Columns().MenuId)
+		if err != nil {
+			return gerror.Wrap(err, "查询角色菜单关联失败")
+		}
+		grantedSet := make(map[uint64]bool, len(granted))
+		for _, menuId := range granted {
+			grantedSet[menuId.Uint64()] = true
+		}
+
+		var insertData []g.Map
+		for _, menuId := range menuIds {
+			if grantedSet[menuId] {
+				continue
+			}
+			insertData = append(insertData, g.Map{
+				dao.RoleMenu.Columns().RoleId: roleId.Uint64(),
+				dao.RoleMenu.Columns().MenuId: menuId,
+			})
+		}
+		if len(insertData) == 0 {
+			continue
+		}
+		if _, err = dao.RoleMenu.Ctx(ctx).Data(insertData).Insert(); err != nil {
+			return gerror.Wrap(err, "插入角色菜单关联失败")
+		}
+		g.Log().Infof(ctx, "已为角色 %d 授予 %d 个菜单权限", roleId.Uint64(), len(insertData))
+	}
 	return nil
 }
@@ -0,0 +1,226 @@
+package generate
+
+import (
+	"context"
+	"strings"
+
+	v1 "server/app/admin/api/generate/v1"
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/dao"
+	generateLibrary "server/app/admin/internal/library/generate"
+	"server/app/admin/internal/middleware"
+	"server/app/admin/internal/model/do"
+	"server/app/admin/internal/model/entity"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// PreviewCreateTable 预览建表语句
+func (s *sGenerate) PreviewCreateTable(ctx context.Context, req v1.PreviewCreateTableReq) (res *v1.PreviewCreateTableRes, err error) {
+	sql, err := generateLibrary.CreateTableSQL(toTableDefinition(req.SchemaTable))
+	if err != nil {
+		return nil, gerror.Wrap(err, "表结构定义错误")
+	}
+	return &v1.PreviewCreateTableRes{Sql: sql}, nil
+}
+
+// CreateTable 根据表结构定义创建数据表并记录变更
+func (s *sGenerate) CreateTable(ctx context.Context, req v1.CreateTableReq) (res *v1.CreateTableRes, err error) {
+	if err = checkSchemaDialect(); err != nil {
+		return nil, err
+	}
+	sql, err := generateLibrary.CreateTableSQL(toTableDefinition(req.SchemaTable))
+	if err != nil {
+		return nil, gerror.Wrap(err, "表结构定义错误")
+	}
+
+	exists, err := generateLibrary.ValidateTableExists(req.TableName)
+	if err != nil {
+		return nil, gerror.Wrap(err, "检查数据表失败")
+	}
+	if exists {
+		return nil, gerror.Newf("数据表 %s 已存在", req.TableName)
+	}
+
+	if _, err = g.DB().Exec(ctx, sql); err != nil {
+		return nil, gerror.Wrap(err, "创建数据表失败")
+	}
+	recordTableMigration(ctx, req.TableName, consts.MigrationCreate, sql, req.SchemaTable)
+	return &v1.CreateTableRes{Sql: sql}, nil
+}
+
+// PreviewAlterTable 预览修改表结构语句及与当前表结构的差异
+func (s *sGenerate) PreviewAlterTable(ctx context.Context, req v1.PreviewAlterTableReq) (res *v1.PreviewAlterTableRes, err error) {
+	sql, diffs, err := alterTableSQL(req.SchemaAlter)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PreviewAlterTableRes{Sql: sql, Diffs: diffs}, nil
+}
+
+// AlterTable 修改表结构并记录变更
+func (s *sGenerate) AlterTable(ctx context.Context, req v1.AlterTableReq) (res *v1.AlterTableRes, err error) {
+	if err = checkSchemaDialect(); err != nil {
+		return nil, err
+	}
+	sql, diffs, err := alterTableSQL(req.SchemaAlter)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = g.DB().Exec(ctx, sql); err != nil {
+		return nil, gerror.Wrap(err, "修改表结构失败")
+	}
+	recordTableMigration(ctx, req.TableName, consts.MigrationAlter, sql, req.SchemaAlter)
+	return &v1.AlterTableRes{Sql: sql, Diffs: diffs}, nil
+}
+
+// GetTableSchema 获取表结构定义，用于在表结构设计中编辑已有表
+func (s *sGenerate) GetTableSchema(ctx context.Context, req v1.GetTableSchemaReq) (res *v1.GetTableSchemaRes, err error) {
+	columns, err := generateLibrary.GetTableColumnsWithDetail(req.TableName)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取表字段失败")
+	}
+	if len(columns) == 0 {
+		return nil, gerror.Newf("数据表 %s 不存在", req.TableName)
+	}
+	comment, err := generateLibrary.GetTableComment(req.TableName)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取表注释失败")
+	}
+	indexes, err := generateLibrary.GetTableIndexes(req.TableName)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取表索引失败")
+	}
+
+	res = &v1.GetTableSchemaRes{SchemaTable: v1.SchemaTable{
+		TableName: req.TableName,
+		Comment:   comment,
+		Columns:   make([]v1.SchemaColumn, 0, len(columns)),
+		Indexes:   make([]v1.SchemaIndex, 0, len(indexes)),
+	}}
+	for _, column := range columns {
+		res.Columns = append(res.Columns, v1.SchemaColumn(generateLibrary.ColumnDefinitionFromMeta(column)))
+	}
+	for _, index := range indexes {
+		res.Indexes = append(res.Indexes, v1.SchemaIndex(index))
+	}
+	return res, nil
+}
+
+// GetTableMigrations 获取表结构变更记录
+func (s *sGenerate) GetTableMigrations(ctx context.Context, req v1.GetTableMigrationsReq) (res *v1.GetTableMigrationsRes, err error) {
+	m := dao.TableMigration.Ctx(ctx)
+	if req.TableName != "" {
+		m = m.Where(dao.TableMigration.Columns().TableName, req.TableName)
+	}
+	total, err := m.Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询表结构变更记录总数失败")
+	}
+
+	var list []entity.TableMigration
+	err = m.Page(req.CurrentPage, req.PageSize).
+		OrderDesc(dao.TableMigration.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询表结构变更记录失败")
+	}
+
+	res = &v1.GetTableMigrationsRes{List: make([]v1.TableMigration, 0, len(list))}
+	if err = gconv.Scan(list, &res.List); err != nil {
+		return nil, gerror.Wrap(err, "数据转换失败")
+	}
+	res.Total = total
+	res.CurrentPage = req.CurrentPage
+	return res, nil
+}
+
+// alterTableSQL 读取当前表结构并生成修改表结构语句及差异
+func alterTableSQL(alter v1.SchemaAlter) (string, []v1.SchemaDiff, error) {
+	columns, err := generateLibrary.GetTableColumnsWithDetail(alter.TableName)
+	if err != nil {
+		return "", nil, gerror.Wrap(err, "获取表字段失败")
+	}
+	indexes, err := generateLibrary.GetTableIndexes(alter.TableName)
+	if err != nil {
+		return "", nil, gerror.Wrap(err, "获取表索引失败")
+	}
+
+	definition := generateLibrary.AlterDefinition{
+		TableName:     alter.TableName,
+		AddColumns:    toColumnDefinitions(alter.AddColumns),
+		ModifyColumns: toColumnDefinitions(alter.ModifyColumns),
+		DropColumns:   alter.DropColumns,
+		AddIndexes:    toIndexDefinitions(alter.AddIndexes),
+	}
+	sql, diffs, err := generateLibrary.AlterTableSQL(definition, columns, indexes)
+	if err != nil {
+		return "", nil, gerror.Wrap(err, "表结构变更错误")
+	}
+
+	result := make([]v1.SchemaDiff, 0, len(diffs))
+	for _, diff := range diffs {
+		result = append(result, v1.SchemaDiff(diff))
+	}
+	return sql, result, nil
+}
+
+// toTableDefinition 将接口中的建表定义转换为 DDL 定义
+func toTableDefinition(table v1.SchemaTable) generateLibrary.TableDefinition {
+	return generateLibrary.TableDefinition{
+		TableName: table.TableName,
+		Comment:   table.Comment,
+		Columns:   toColumnDefinitions(table.Columns),
+		Indexes:   toIndexDefinitions(table.Indexes),
+	}
+}
+
+// toColumnDefinitions 将接口中的字段定义转换为 DDL 字段定义
+func toColumnDefinitions(columns []v1.SchemaColumn) []generateLibrary.ColumnDefinition {
+	result := make([]generateLibrary.ColumnDefinition, 0, len(columns))
+	for _, column := range columns {
+		result = append(result, generateLibrary.ColumnDefinition(column))
+	}
+	return result
+}
+
+// toIndexDefinitions 将接口中的索引定义转换为 DDL 索引定义
+func toIndexDefinitions(indexes []v1.SchemaIndex) []generateLibrary.IndexDefinition {
+	result := make([]generateLibrary.IndexDefinition, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, generateLibrary.IndexDefinition(index))
+	}
+	return result
+}
+
+// checkSchemaDialect 表结构设计生成的是 MySQL 方言的 DDL，其他数据库不支持执行
+func checkSchemaDialect() error {
+	dbType := strings.ToLower(g.DB().GetConfig().Type)
+	if dbType != "mysql" && dbType != "mariadb" {
+		return gerror.Newf("表结构设计仅支持 MySQL，当前数据库类型为 %s", dbType)
+	}
+	return nil
+}
+
+// recordTableMigration 记录表结构变更；DDL 已执行无法回滚，记录失败仅输出日志
+func recordTableMigration(ctx context.Context, tableName, action, statement string, definition interface{}) {
+	var definitionJson interface{}
+	if definition != nil {
+		definitionJson = gjson.MustEncodeString(definition)
+	}
+	operatorId, _ := ctx.Value(middleware.CtxUserID).(uint64)
+	_, err := dao.TableMigration.Ctx(ctx).Data(do.TableMigration{
+		TableName:  tableName,
+		Action:     action,
+		Statement:  statement,
+		Definition: definitionJson,
+		OperatorId: operatorId,
+	}).Insert()
+	if err != nil {
+		g.Log().Error(ctx, "记录表结构变更失败", g.Map{"table": tableName, "error": err})
+	}
+}
@@ -3,126 +3,126 @@ package generate
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
 	v1 "server/app/admin/api/generate/v1"
+	"server/app/admin/internal/consts"
+	generateLibrary "server/app/admin/internal/library/generate"
+	"server/app/admin/internal/library/llm"
+	"server/app/admin/internal/library/migrate"
 
 	"github.com/gogf/gf/errors/gerror"
-	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/frame/g"
-	"github.com/guonaihong/gout"
 )
 
-// GenerateSqlRequest 外部API请求结构
-type GenerateSqlRequest struct {
-	Prompt string `json:"prompt"`
-}
+// dangerousKeywordPattern 建表语句中禁止出现的关键词
+var dangerousKeywordPattern = regexp.MustCompile(`(?i)\b(DROP|DELETE|TRUNCATE|ALTER|INSERT)\b`)
 
-// GenerateSqlResponse 外部API响应结构
-type GenerateSqlResponse struct {
-	Code int    `json:"code"`
-	Msg  string `json:"message"`
-	Data struct {
-		Sql string `json:"sql"`
-	} `json:"data"`
-}
+// createTableNamePattern 提取建表语句中的表名
+var createTableNamePattern = regexp.MustCompile("(?i)^CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?`?([a-zA-Z0-9_]+)`?")
 
-// GenerateSql 根据提示词生成SQL语句
-func (s *sGenerate) GenerateSql(ctx context.Context, req v1.GenerateSqlReq) (res *v1.GenerateSqlRes, err error) {
-	// 获取配置
-	var (
-		apiURL     = g.Cfg().MustGet(ctx, "sql.generate.url", "http://deepseek.jingqi888.cn/generate/sql").String()
-		timeout    = g.Cfg().MustGet(ctx, "sql.generate.timeout", 60000).Int() // 默认60秒
-		maxRetries = g.Cfg().MustGet(ctx, "sql.generate.retries", 3).Int()     // 默认重试3次
-	)
+// sqlSystemPrompt 生成建表语句的系统提示词，%s 处填入当前数据库的表结构
+const sqlSystemPrompt = `你是一名 MySQL 数据库设计专家，请根据用户的需求设计一张新的数据表。
+要求：
+1. 只输出一条 MySQL 的 CREATE TABLE 语句，不要输出任何解释，不要输出其他语句；
+2. 表名与字段名使用小写字母加下划线，每个字段和表都需要有中文 COMMENT；
+3. 使用 bigint unsigned 自增主键 id，并包含 created_at、updated_at 时间字段；
+4. 字段命名、类型与注释风格参考当前数据库已有的表，关联已有表时使用对应的主键类型；
+5. 表名不能与已有的表重复。
 
-	// 记录请求信息
-	g.Log().Info(ctx, "SQL生成API请求开始", g.Map{
-		"url":     apiURL,
-		"prompt":  req.Prompt,
-		"timeout": timeout,
+当前数据库的表结构：
+%s`
+
+// GenerateSql 根据提示词调用大模型生成建表语句，生成结果经过校验后返回
+func (s *sGenerate) GenerateSql(ctx context.Context, req v1.GenerateSqlReq) (res *v1.GenerateSqlRes, err error) {
+	provider, err := llm.New(llm.Config{
+		Provider: g.Cfg().MustGet(ctx, "sql.generate.provider", llm.ProviderMock).String(),
+		Url:      g.Cfg().MustGet(ctx, "sql.generate.url").String(),
+		Model:    g.Cfg().MustGet(ctx, "sql.generate.model").String(),
+		ApiKey:   g.Cfg().MustGet(ctx, "sql.generate.apiKey").String(),
+		Timeout:  time.Duration(g.Cfg().MustGet(ctx, "sql.generate.timeout", 60000).Int()) * time.Millisecond,
+		Retries:  g.Cfg().MustGet(ctx, "sql.generate.retries", 3).Int(),
 	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "SQL生成服务配置错误")
+	}
 
-	// 构建请求数据
-	reqData := GenerateSqlRequest{
-		Prompt: req.Prompt,
-	}
-
-	// 重试机制
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		g.Log().Info(ctx, fmt.Sprintf("尝试第%d次请求", attempt))
-
-		// 发送HTTP请求
-		var responseBody string
-		var code int
-		err = gout.POST(apiURL).
-			SetJSON(reqData).
-			SetHeader(gout.H{
-				"Content-Type": "application/json",
-				"Accept":       "application/json",
-				"User-Agent":   "Panda-SQL-Generator/1.0",
-			}).
-			SetTimeout(time.Duration(timeout) * time.Millisecond).
-			BindBody(&responseBody).
-			Code(&code).
-			Do()
-
-		// 如果请求成功，跳出重试循环
-		if err == nil && code == 200 {
-			// 记录成功响应
-			g.Log().Info(ctx, "API响应成功", g.Map{
-				"attempt":  attempt,
-				"response": responseBody,
-			})
-
-			// 检查响应是否为空
-			if responseBody == "" {
-				return nil, fmt.Errorf("API返回空响应")
-			}
+	tables, err := s.GetTablesWithColumns(ctx, v1.GetTablesWithColumnsReq{})
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取当前表结构失败")
+	}
 
-			// 使用GoFrame的JSON解析
-			var response GenerateSqlResponse
-			parseErr := gjson.DecodeTo(responseBody, &response)
-			if parseErr != nil {
-				return nil, fmt.Errorf("JSON解析失败: %v，原始响应: %s", parseErr, responseBody)
-			}
+	g.Log().Info(ctx, "SQL生成请求开始", g.Map{
+		"provider": provider.Name(),
+		"prompt":   req.Prompt,
+	})
+	content, err := provider.Complete(ctx, fmt.Sprintf(sqlSystemPrompt, describeSchema(tables.List)), req.Prompt)
+	if err != nil {
+		g.Log().Warning(ctx, "SQL生成请求失败", g.Map{"provider": provider.Name(), "error": err})
+		return nil, gerror.Wrap(err, "SQL生成请求失败")
+	}
 
-			if response.Code != 0 {
-				return nil, fmt.Errorf("SQL生成API返回错误，错误码: %d，错误信息: %s", response.Code, response.Msg)
-			}
+	sqlStatement, tableName, err := checkCreateTableSql(cleanSqlContent(content))
+	if err != nil {
+		g.Log().Warning(ctx, "生成的SQL校验未通过", g.Map{"content": content, "error": err})
+		return nil, gerror.Wrap(err, "生成的SQL不合法，请调整提示词后重试")
+	}
+	exists, err := generateLibrary.ValidateTableExists(tableName)
+	if err != nil {
+		return nil, gerror.Wrap(err, "检查数据表失败")
+	}
+	if exists {
+		return nil, gerror.New(fmt.Sprintf("生成的数据表 %s 已存在，请调整提示词后重试", tableName))
+	}
 
-			// 清理SQL内容中的markdown标记
-			sqlContent := cleanSqlContent(response.Data.Sql)
+	return &v1.GenerateSqlRes{Sql: sqlStatement}, nil
+}
 
-			// 返回成功结果
-			res = &v1.GenerateSqlRes{
-				Sql: sqlContent,
+// describeSchema 将表结构描述为提示词文本，每张表一行
+func describeSchema(tables []v1.TableWithColumnsInfo) string {
+	if len(tables) == 0 {
+		return "（暂无数据表）"
+	}
+	var builder strings.Builder
+	for _, table := range tables {
+		builder.WriteString(fmt.Sprintf("- %s（%s）：", table.TableName, table.TableComment))
+		for i, column := range table.Columns {
+			if i > 0 {
+				builder.WriteString("，")
 			}
-			return res, nil
-		}
-
-		// 记录失败信息
-		g.Log().Warning(ctx, fmt.Sprintf("第%d次请求失败", attempt), g.Map{
-			"error": err,
-			"code":  code,
-			"body":  responseBody,
-		})
-
-		// 如果不是最后一次尝试，等待后重试
-		if attempt < maxRetries {
-			waitTime := time.Duration(attempt*2) * time.Second // 递增等待时间
-			g.Log().Info(ctx, fmt.Sprintf("等待%v后重试", waitTime))
-			time.Sleep(waitTime)
+			builder.WriteString(fmt.Sprintf("%s %s %s", column.ColumnName, column.ColumnType, column.ColumnComment))
 		}
+		builder.WriteString("\n")
 	}
+	return builder.String()
+}
 
-	// 所有重试都失败
+// checkCreateTableSql 校验SQL为单条不含危险关键词的建表语句，返回去除结尾分号的语句和表名
+func checkCreateTableSql(sql string) (statement, tableName string, err error) {
+	statements, err := migrate.SplitStatements(sql)
 	if err != nil {
-		return nil, fmt.Errorf("SQL生成API请求失败(已重试%d次): %v", maxRetries, err)
+		return "", "", gerror.Wrap(err, "SQL语句解析失败")
+	}
+	if len(statements) != 1 {
+		return "", "", gerror.New(fmt.Sprintf("只允许一条SQL语句，当前为 %d 条", len(statements)))
 	}
+	statement = statements[0]
 
-	return nil, fmt.Errorf("SQL生成API请求失败，(已重试%d次)", maxRetries)
+	// 安全检查：只允许CREATE TABLE语句
+	if !strings.HasPrefix(strings.ToUpper(statement), "CREATE TABLE") {
+		return "", "", gerror.New("为了安全考虑，只允许执行CREATE TABLE语句")
+	}
+	// 额外安全检查：禁止包含危险关键词，按单词匹配，避免误拒 deleted_flag 等字段名
+	if keyword := dangerousKeywordPattern.FindString(statement); keyword != "" {
+		return "", "", gerror.New(fmt.Sprintf("CREATE TABLE语句中不能包含 %s 关键词", strings.ToUpper(keyword)))
+	}
+	match := createTableNamePattern.FindStringSubmatch(statement)
+	if match == nil {
+		return "", "", gerror.New("无法识别建表语句中的表名")
+	}
+	return statement, match[1], nil
 }
 
 // cleanSqlContent 清理SQL内容中的markdown标记
@@ -150,27 +150,13 @@ func (s *sGenerate) ExecuteSql(ctx context.Context, req v1.ExecuteSqlReq) (res *
 		return res, gerror.New("sql语句不能为空")
 	}
 
-	// 转换为大写进行检查
-	sqlUpper := strings.ToUpper(sqlStatement)
-
-	// 安全检查：只允许CREATE TABLE语句
-	if !strings.HasPrefix(sqlUpper, "CREATE TABLE") {
-		g.Log().Warning(ctx, "SQL执行被拒绝：不是CREATE TABLE语句", g.Map{
-			"sql": sqlStatement,
+	sqlStatement, tableName, err := checkCreateTableSql(sqlStatement)
+	if err != nil {
+		g.Log().Warning(ctx, "SQL执行被拒绝", g.Map{
+			"sql":   req.Sql,
+			"error": err,
 		})
-		return res, gerror.New("为了安全考虑，只允许执行CREATE TABLE语句")
-	}
-
-	// 额外安全检查：禁止包含危险关键词
-	dangerousKeywords := []string{"DROP", "DELETE", "TRUNCATE", "ALTER", "INSERT"}
-	for _, keyword := range dangerousKeywords {
-		if strings.Contains(sqlUpper, keyword) {
-			g.Log().Warning(ctx, "SQL执行被拒绝：包含危险关键词", g.Map{
-				"sql":     sqlStatement,
-				"keyword": keyword,
-			})
-			return res, gerror.New(fmt.Sprintf("CREATE TABLE语句中不能包含 %s 关键词", keyword))
-		}
+		return res, err
 	}
 
 	// 获取数据库连接
@@ -194,5 +180,8 @@ func (s *sGenerate) ExecuteSql(ctx context.Context, req v1.ExecuteSqlReq) (res *
 		"affectedRows": affectedRows,
 	})
 
+	// 记录表结构变更
+	recordTableMigration(ctx, tableName, consts.MigrationSql, sqlStatement, nil)
+
 	return res, nil
 }
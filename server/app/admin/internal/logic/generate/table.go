@@ -112,6 +112,7 @@ func (s *sGenerate) ImportTables(ctx context.Context, req v1.ImportTablesReq) (r
 					"hasEdit": true,
 					"hasQuery": true,
 					"hasExport": false,
+					"hasImport": false,
 					"hasBatchDelete": false,
 					"parentMenuId": null,
 					"menuName": "",
@@ -164,13 +165,13 @@ func (s *sGenerate) getTableDetailInfo(tableName string) (*TableDetailInfo, erro
 	var fieldList []map[string]interface{}
 	for i, col := range columns {
 		// 判断字段类型对应的表单组件
-		htmlType := "input"
+		htmlType := generateLibrary.FormModeInput
 		if strings.Contains(strings.ToLower(col.DataType), "text") {
-			htmlType = "textarea"
+			htmlType = generateLibrary.FormModeInputTextarea
 		} else if strings.Contains(strings.ToLower(col.DataType), "int") {
-			htmlType = "number"
+			htmlType = generateLibrary.FormModeInputNumber
 		} else if strings.Contains(strings.ToLower(col.DataType), "date") {
-			htmlType = "date"
+			htmlType = generateLibrary.FormModeDate
 		}
 
 		// 判断是否为主键
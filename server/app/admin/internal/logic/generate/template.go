@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"context"
+	"io"
+
+	v1 "server/app/admin/api/generate/v1"
+	"server/app/admin/internal/library/generate/curd"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// maxTemplateSize 上传模板文件的最大字节数
+const maxTemplateSize = 1 << 20
+
+// GetTemplateSets 获取模板集列表
+func (s *sGenerate) GetTemplateSets(ctx context.Context, req v1.GetTemplateSetsReq) (res *v1.GetTemplateSetsRes, err error) {
+	sets, err := curd.ListTemplateSets(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取模板集列表失败")
+	}
+
+	res = &v1.GetTemplateSetsRes{List: make([]v1.TemplateSet, 0, len(sets))}
+	for _, set := range sets {
+		item := v1.TemplateSet{Name: set.Name, Builtin: set.Builtin}
+		for _, file := range set.Files {
+			item.Files = append(item.Files, v1.TemplateFile{Name: file.Name, Custom: file.Custom})
+		}
+		res.List = append(res.List, item)
+	}
+	return res, nil
+}
+
+// GetTemplate 获取模板内容
+func (s *sGenerate) GetTemplate(ctx context.Context, req v1.GetTemplateReq) (res *v1.GetTemplateRes, err error) {
+	content, custom, err := curd.GetTemplate(ctx, req.Set, req.Name)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取模板失败")
+	}
+	return &v1.GetTemplateRes{Content: content, Custom: custom}, nil
+}
+
+// SaveTemplate 校验并保存模板
+func (s *sGenerate) SaveTemplate(ctx context.Context, req v1.SaveTemplateReq) (res *v1.SaveTemplateRes, err error) {
+	if err = curd.SaveTemplate(ctx, req.Set, req.Name, req.Content); err != nil {
+		return nil, gerror.Wrap(err, "保存模板失败")
+	}
+	return &v1.SaveTemplateRes{}, nil
+}
+
+// UploadTemplate 上传模板文件，校验通过后保存到模板集
+func (s *sGenerate) UploadTemplate(ctx context.Context, req v1.UploadTemplateReq) (res *v1.UploadTemplateRes, err error) {
+	if req.File.Size > maxTemplateSize {
+		return nil, gerror.New("模板文件不能超过1MB")
+	}
+	f, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取模板文件失败")
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取模板文件失败")
+	}
+	if err = curd.SaveTemplate(ctx, req.Set, req.Name, string(content)); err != nil {
+		return nil, gerror.Wrap(err, "保存模板失败")
+	}
+	return &v1.UploadTemplateRes{}, nil
+}
+
+// ValidateTemplate 使用示例数据校验模板
+func (s *sGenerate) ValidateTemplate(ctx context.Context, req v1.ValidateTemplateReq) (res *v1.ValidateTemplateRes, err error) {
+	output, err := curd.ValidateTemplate(ctx, req.Name, req.Content)
+	if err != nil {
+		return nil, gerror.Wrap(err, "模板校验失败")
+	}
+	return &v1.ValidateTemplateRes{Output: output}, nil
+}
+
+// DeleteTemplate 删除自定义模板或模板集
+func (s *sGenerate) DeleteTemplate(ctx context.Context, req v1.DeleteTemplateReq) (res *v1.DeleteTemplateRes, err error) {
+	if err = curd.DeleteTemplate(ctx, req.Set, req.Name); err != nil {
+		return nil, gerror.Wrap(err, "删除模板失败")
+	}
+	return &v1.DeleteTemplateRes{}, nil
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -11,7 +12,10 @@ import (
 	"github.com/gogf/gf/v2/util/gutil"
 
 	v1 "server/app/admin/api/user/v1"
+	"server/app/admin/internal/consts"
 	"server/app/admin/internal/dao"
+	"server/app/admin/internal/library/storage"
+	"server/app/admin/internal/logic/attachment"
 	"server/app/admin/internal/middleware"
 	"server/app/admin/internal/model/do"
 	"server/app/admin/internal/model/entity"
@@ -269,6 +273,14 @@ func (s *sUser) Delete(ctx context.Context, in v1.DeleteReq) (err error) {
 		return gerror.Wrap(err, "删除用户角色关联失败")
 	}
 
+	// 删除用户头像的附件引用及单独设置的存储配额
+	if err = attachment.New().RemoveReferences(ctx, dao.User.Table(), in.Id); err != nil {
+		return err
+	}
+	if err = attachment.New().RemoveQuotas(ctx, consts.QuotaTargetUser, in.Id); err != nil {
+		return err
+	}
+
 	// 删除用户数据
 	_, err = dao.User.Ctx(ctx).Where(dao.User.Columns().Id, in.Id).Delete()
 	return gerror.Wrap(err, "删除用户失败")
@@ -387,6 +399,14 @@ func (s *sUser) BatchDelete(ctx context.Context, in v1.BatchDeleteReq) (err erro
 			return gerror.Wrap(err, "删除用户角色关联失败")
 		}
 
+		// 删除用户头像的附件引用及单独设置的存储配额
+		if err = attachment.New().RemoveReferences(ctx, dao.User.Table(), in.Ids); err != nil {
+			return err
+		}
+		if err = attachment.New().RemoveQuotas(ctx, consts.QuotaTargetUser, in.Ids); err != nil {
+			return err
+		}
+
 		// 批量删除用户数据
 		_, err = tx.Model(dao.User.Table()).Ctx(ctx).Where(dao.User.Columns().Id+" IN (?)", in.Ids).Delete()
 		if err != nil {
@@ -458,7 +478,7 @@ func (s *sUser) AssignRoles(ctx context.Context, in v1.AssignRolesReq) (err erro
 	return nil
 }
 
-// UploadAvatar 上传用户头像
+// UploadAvatar 上传用户头像，头像为附件地址时记录用户对该附件的引用
 func (s *sUser) UploadAvatar(ctx context.Context, req v1.UploadAvatarReq) (*v1.UploadAvatarRes, error) {
 	// 检查用户是否存在
 	var user *entity.User
@@ -470,18 +490,43 @@ func (s *sUser) UploadAvatar(ctx context.Context, req v1.UploadAvatarReq) (*v1.U
 		return nil, gerror.New("用户不存在")
 	}
 
-	// 使用公共工具保存头像
-	avatarUrl, err := utility.SaveBase64Avatar(ctx, req.Avatar, uint64(req.Id))
-	if err != nil {
-		return nil, gerror.Wrap(err, "保存头像失败")
+	// base64 图片使用公共工具保存头像到配置的存储，否则需为已上传的公开图片附件的地址
+	avatarUrl := req.Avatar
+	if strings.HasPrefix(req.Avatar, "data:") {
+		store, err := storage.Default(ctx)
+		if err != nil {
+			return nil, gerror.Wrap(err, "初始化存储失败")
+		}
+		avatarUrl, err = utility.SaveBase64Avatar(ctx, store, req.Avatar, uint64(req.Id))
+		if err != nil {
+			return nil, gerror.Wrap(err, "保存头像失败")
+		}
+	} else {
+		count, err := dao.Attachment.Ctx(ctx).
+			Where(dao.Attachment.Columns().FileUrl, avatarUrl).
+			Where(dao.Attachment.Columns().IsImage, 1).
+			Where(dao.Attachment.Columns().IsPrivate, 0).
+			Count()
+		if err != nil {
+			return nil, gerror.Wrap(err, "查询头像附件失败")
+		}
+		if count == 0 {
+			return nil, gerror.New("头像地址不是有效的图片附件地址")
+		}
 	}
 
-	// 更新用户头像
-	_, err = dao.User.Ctx(ctx).Where(dao.User.Columns().Id, req.Id).Update(g.Map{
-		dao.User.Columns().Avatar: avatarUrl,
+	// 更新用户头像及头像的附件引用
+	err = dao.User.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		_, err := dao.User.Ctx(ctx).Where(dao.User.Columns().Id, req.Id).Update(g.Map{
+			dao.User.Columns().Avatar: avatarUrl,
+		})
+		if err != nil {
+			return gerror.Wrap(err, "更新用户头像失败")
+		}
+		return attachment.New().SyncReferences(ctx, dao.User.Table(), req.Id, dao.User.Columns().Avatar, []string{avatarUrl})
 	})
 	if err != nil {
-		return nil, gerror.Wrap(err, "更新用户头像失败")
+		return nil, err
 	}
 
 	// 返回结果
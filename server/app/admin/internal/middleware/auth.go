@@ -7,6 +7,8 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/golang-jwt/jwt/v5"
+
+	"server/app/admin/internal/consts"
 )
 
 const (
@@ -27,8 +29,9 @@ type JWTClaims struct {
 
 // Auth JWT认证中间件
 func Auth(r *ghttp.Request) {
-	// 跳过登录和刷新令牌接口
-	if r.URL.Path == "/admin/login" || r.URL.Path == "/admin/refresh-token" || r.URL.Path == "/admin/captcha" {
+	// 跳过登录和刷新令牌接口，签名下载地址由签名校验访问权限
+	if r.URL.Path == "/admin/login" || r.URL.Path == "/admin/refresh-token" || r.URL.Path == "/admin/captcha" ||
+		strings.HasPrefix(r.URL.Path, consts.SignedUrlPath) {
 		r.Middleware.Next()
 		return
 	}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+
+	"server/app/admin/internal/dao"
+)
+
+const (
+	// MetaTagAuth 接口 g.Meta 中声明按钮权限标识的标签名
+	MetaTagAuth = "auth"
+	// DeveloperCode 拥有全部权限的开发者用户名/角色编码
+	DeveloperCode = "developer"
+)
+
+// Permission 按钮权限中间件，校验接口 g.Meta 中 auth 标签声明的权限标识
+func Permission(r *ghttp.Request) {
+	handler := r.GetServeHandler()
+	if handler == nil {
+		r.Middleware.Next()
+		return
+	}
+
+	authCode := handler.GetMetaTag(MetaTagAuth)
+	if authCode == "" {
+		r.Middleware.Next()
+		return
+	}
+
+	ctx := r.Context()
+	if username, ok := ctx.Value(CtxUsername).(string); ok && username == DeveloperCode {
+		r.Middleware.Next()
+		return
+	}
+
+	userID, _ := ctx.Value(CtxUserID).(uint64)
+	allowed, err := hasPermission(ctx, userID, authCode)
+	if err != nil {
+		g.Log().Error(ctx, "校验按钮权限失败", g.Map{
+			"auth":  authCode,
+			"error": err,
+		})
+	}
+	if !allowed {
+		r.Response.WriteJsonExit(g.Map{
+			"code":    403,
+			"message": "没有操作权限: " + authCode,
+		})
+		return
+	}
+
+	r.Middleware.Next()
+}
+
+// hasPermission 判断用户的启用角色是否拥有指定的权限标识
+func hasPermission(ctx context.Context, userID uint64, authCode string) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+
+	var (
+		userRole = dao.UserRole.Table()
+		roleMenu = dao.RoleMenu.Table()
+		role     = dao.Role.Table()
+		menu     = dao.Menu.Table()
+	)
+
+	// 开发者角色拥有全部权限
+	count, err := dao.UserRole.Ctx(ctx).
+		InnerJoin(role, fmt.Sprintf("%s.%s=%s.%s", userRole, dao.UserRole.Columns().RoleId, role, dao.Role.Columns().Id)).
+		Where(fmt.Sprintf("%s.%s", userRole, dao.UserRole.Columns().UserId), userID).
+		Where(fmt.Sprintf("%s.%s", role, dao.Role.Columns().Status), 1).
+		Where(fmt.Sprintf("%s.%s", role, dao.Role.Columns().Code), DeveloperCode).
+		Count()
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	// 菜单权限标识可能以逗号分隔多个
+	count, err = dao.UserRole.Ctx(ctx).
+		InnerJoin(role, fmt.Sprintf("%s.%s=%s.%s", userRole, dao.UserRole.Columns().RoleId, role, dao.Role.Columns().Id)).
+		InnerJoin(roleMenu, fmt.Sprintf("%s.%s=%s.%s", userRole, dao.UserRole.Columns().RoleId, roleMenu, dao.RoleMenu.Columns().RoleId)).
+		InnerJoin(menu, fmt.Sprintf("%s.%s=%s.%s", roleMenu, dao.RoleMenu.Columns().MenuId, menu, dao.Menu.Columns().Id)).
+		Where(fmt.Sprintf("%s.%s", userRole, dao.UserRole.Columns().UserId), userID).
+		Where(fmt.Sprintf("%s.%s", role, dao.Role.Columns().Status), 1).
+		Where(fmt.Sprintf("FIND_IN_SET(?, REPLACE(%s.%s, ' ', ''))", menu, dao.Menu.Columns().Auths), authCode).
+		Count()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
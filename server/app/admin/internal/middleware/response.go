@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// streamContentTypes 流式响应的内容类型，不追加统一响应
+var streamContentTypes = map[string]bool{
+	"text/event-stream":         true,
+	"application/octet-stream":  true,
+	"multipart/x-mixed-replace": true,
+}
+
+// HandlerResponse 统一响应中间件，与 ghttp.MiddlewareHandlerResponse 相同，将处理函数的返回值与错误包装为 JSON；
+// 处理函数已自行输出响应并调用 r.ExitAll() 时不再追加，如文件下载的 304、HEAD 请求与空文件没有响应内容
+func HandlerResponse(r *ghttp.Request) {
+	r.Middleware.Next()
+
+	if r.IsExited() || r.Response.BufferLength() > 0 || r.Response.Writer.BytesWritten() > 0 {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Response.Header().Get("Content-Type"))
+	if streamContentTypes[mediaType] {
+		return
+	}
+
+	var (
+		msg  string
+		err  = r.GetError()
+		res  = r.GetHandlerResponse()
+		code = gerror.Code(err)
+	)
+	if err != nil {
+		if code == gcode.CodeNil {
+			code = gcode.CodeInternalError
+		}
+		msg = err.Error()
+	} else {
+		if r.Response.Status > 0 && r.Response.Status != http.StatusOK {
+			switch r.Response.Status {
+			case http.StatusNotFound:
+				code = gcode.CodeNotFound
+			case http.StatusForbidden:
+				code = gcode.CodeNotAuthorized
+			default:
+				code = gcode.CodeUnknown
+			}
+			// 设置错误，便于其他中间件获取
+			err = gerror.NewCode(code, msg)
+			r.SetError(err)
+		} else {
+			code = gcode.CodeOK
+		}
+		msg = code.Message()
+	}
+
+	r.Response.WriteJson(ghttp.DefaultHandlerResponse{
+		Code:    code.Code(),
+		Message: msg,
+		Data:    res,
+	})
+}
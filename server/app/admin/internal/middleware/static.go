@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+
+	"server/app/admin/internal/consts"
+	"server/app/admin/internal/library/storage"
+)
+
+// UploadHeaders 上传文件静态访问的钩子，禁止浏览器猜测内容类型，不能在浏览器中直接打开的文件作为附件下载
+func UploadHeaders(r *ghttp.Request) {
+	r.Response.Header().Set("X-Content-Type-Options", "nosniff")
+	if !storage.Inline(mime.TypeByExtension(path.Ext(r.URL.Path))) {
+		r.Response.Header().Set("Content-Disposition", "attachment")
+	}
+}
+
+// PrivateUploads 返回上传文件静态访问的钩子，拒绝访问 baseUrl 下的私有文件目录，私有文件只能通过签名下载地址访问
+// 按清理后的路径判断，避免通过 // 或 ./ 等形式绕过
+func PrivateUploads(baseUrl string) ghttp.HandlerFunc {
+	prefix := strings.ToLower(strings.TrimRight(baseUrl, "/") + "/" + consts.PrivateStoragePrefix + "/")
+	return func(r *ghttp.Request) {
+		if strings.HasPrefix(strings.ToLower(path.Clean(r.URL.Path)+"/"), prefix) {
+			r.Response.WriteStatus(http.StatusNotFound)
+			r.ExitAll()
+		}
+	}
+}
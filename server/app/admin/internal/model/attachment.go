@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// ReconcileInput 存储对账参数
+type ReconcileInput struct {
+	Action string        // 孤儿文件的处理方式：quarantine（移入隔离目录）、delete（删除），为空时使用 quarantine
+	MinAge time.Duration // 只处理修改时间早于该时长的孤儿文件，0 时使用默认值
+	DryRun bool          // 只报告不处理
+}
+
+// ReconcileOutput 存储对账结果
+type ReconcileOutput struct {
+	Scanned    int                // 扫描的存储文件数（不含隔离目录）
+	Orphans    []ReconcileOrphan  // 没有被任何数据库记录引用的孤儿文件
+	Missing    []ReconcileMissing // 数据库记录引用但存储中不存在的文件
+	Handled    int                // 已隔离或删除的孤儿文件数
+	Quarantine string             // 本次对账的隔离目录，未隔离文件时为空
+}
+
+// ReconcileOrphan 孤儿文件
+type ReconcileOrphan struct {
+	Key     string    // 存储中的 key
+	Size    int64     // 文件大小（字节）
+	ModTime time.Time // 最后修改时间
+	Handled bool      // 是否已隔离或删除
+	Error   string    // 处理失败的原因
+}
+
+// ReconcileMissing 存储中不存在的文件
+type ReconcileMissing struct {
+	Source string // 引用文件的记录：attachment（附件）、user（用户头像）
+	Id     uint64 // 记录ID
+	Key    string // 存储中的 key
+}
@@ -20,8 +20,13 @@ type Attachment struct {
 	FileExt      interface{} // 文件扩展名
 	FilePath     interface{} // 文件路径
 	FileUrl      interface{} // 文件URL
+	FileHash     interface{} // 文件内容SHA-256
+	SourceHash   interface{} // 上传文件的原始内容SHA-256
 	IsImage      interface{} // 是否为图片
 	ThumbnailUrl interface{} // 缩略图URL
+	IsPrivate    interface{} // 是否私有（1私有，0公开）
+	AccessRoles  interface{} // 允许访问的角色编码（逗号分隔，为空时不限制）
+	FolderId     interface{} // 所属文件夹ID（0为根目录）
 	UploaderId   interface{} // 上传者ID
 	UploaderName interface{} // 上传者名称
 	Status       interface{} // 状态（1正常，0禁用/删除）
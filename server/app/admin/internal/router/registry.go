@@ -1,38 +1,52 @@
 package router
 
 import (
-	"server/app/admin/internal/controller/attachment"
-	"server/app/admin/internal/controller/department"
-	"server/app/admin/internal/controller/dict"
-	"server/app/admin/internal/controller/generate"
-	"server/app/admin/internal/controller/menu"
-	"server/app/admin/internal/controller/role"
-	"server/app/admin/internal/controller/user"
+	"context"
+	"sort"
+
+	"github.com/gogf/gf/v2/frame/g"
 )
 
+//go:generate go run ../../hack/registry
+
 // ControllerFactory 控制器工厂函数类型
 type ControllerFactory func() interface{}
 
-// ControllerRegistry 控制器注册表
-var ControllerRegistry = map[string]ControllerFactory{
-	"role":       func() interface{} { return role.NewV1() },
-	"department": func() interface{} { return department.NewV1() },
-	"user":       func() interface{} { return user.NewV1() },
-	"menu":       func() interface{} { return menu.NewV1() },
-	"generate":   func() interface{} { return generate.NewV1() },
-	"dict":       func() interface{} { return dict.NewV1() },
-	"attachment": func() interface{} { return attachment.NewV1() },
-}
+// ControllerRegistry 控制器注册表，默认内容由 registry_gen.go 扫描生成
+var ControllerRegistry = generatedControllers
 
 // GetAllControllers 获取所有控制器实例
 func GetAllControllers() []interface{} {
 	var controllers []interface{}
-	for _, factory := range ControllerRegistry {
-		controllers = append(controllers, factory())
+	for _, name := range GetControllerNames() {
+		controllers = append(controllers, ControllerRegistry[name]())
 	}
 	return controllers
 }
 
+// GetEnabledControllers 获取配置中未禁用的控制器实例及其名称
+func GetEnabledControllers(ctx context.Context) (controllers []interface{}, names []string) {
+	for _, name := range GetControllerNames() {
+		if !IsControllerEnabled(ctx, name) {
+			g.Log().Infof(ctx, "控制器 %s 已在配置中禁用，跳过绑定", name)
+			continue
+		}
+		controllers = append(controllers, ControllerRegistry[name]())
+		names = append(names, name)
+	}
+	return
+}
+
+// IsControllerEnabled 检查控制器是否启用（router.disabled 配置中列出的模块视为禁用）
+func IsControllerEnabled(ctx context.Context, name string) bool {
+	for _, disabled := range g.Cfg().MustGet(ctx, "router.disabled").Strings() {
+		if disabled == name {
+			return false
+		}
+	}
+	return true
+}
+
 // GetController 根据名称获取控制器实例
 func GetController(name string) interface{} {
 	if factory, exists := ControllerRegistry[name]; exists {
@@ -46,12 +60,13 @@ func RegisterController(name string, factory ControllerFactory) {
 	ControllerRegistry[name] = factory
 }
 
-// GetControllerNames 获取所有控制器名称
+// GetControllerNames 获取所有控制器名称（按名称排序）
 func GetControllerNames() []string {
 	var names []string
 	for name := range ControllerRegistry {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -8,22 +8,83 @@ package service
 import (
 	"context"
 	v1 "server/app/admin/api/attachment/v1"
+	"server/app/admin/internal/model"
 )
 
 type (
 	IAttachment interface {
 		// GetList 获取附件列表
 		GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error)
-		// Upload 上传附件
+		// Upload 上传附件，超出用户或所属部门的存储配额时拒绝上传
 		Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error)
+		// InstantUpload 秒传：已存在相同内容的文件时直接创建附件，无需上传文件
+		InstantUpload(ctx context.Context, req *v1.InstantUploadReq) (res *v1.InstantUploadRes, err error)
 		// Update 更新附件
 		Update(ctx context.Context, req *v1.UpdateReq) (res *v1.UpdateRes, err error)
 		// Delete 删除附件
 		Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error)
 		// BatchDelete 批量删除附件
 		BatchDelete(ctx context.Context, req *v1.BatchDeleteReq) (res *v1.BatchDeleteRes, err error)
-		// Download 下载附件
+		// Download 下载附件，文件内容直接写入响应，支持 Range 断点续传与条件请求
 		Download(ctx context.Context, req *v1.DownloadReq) (res *v1.DownloadRes, err error)
+		// ChunkInit 初始化分片上传，提供文件哈希且存在同一文件未完成的上传时返回该上传用于续传
+		ChunkInit(ctx context.Context, req *v1.ChunkInitReq) (res *v1.ChunkInitRes, err error)
+		// ChunkStatus 查询分片上传进度，用于页面刷新后续传
+		ChunkStatus(ctx context.Context, req *v1.ChunkStatusReq) (res *v1.ChunkStatusRes, err error)
+		// ChunkUpload 上传分片，校验分片大小与SHA-256后保存到存储，重复上传同一分片会覆盖
+		ChunkUpload(ctx context.Context, req *v1.ChunkUploadReq) (res *v1.ChunkUploadRes, err error)
+		// ChunkComplete 完成分片上传：按序合并分片保存到存储并创建附件，相同内容的文件已存在时复用已有文件
+		ChunkComplete(ctx context.Context, req *v1.ChunkCompleteReq) (res *v1.ChunkCompleteRes, err error)
+		// ChunkAbort 取消分片上传，删除已上传的分片
+		ChunkAbort(ctx context.Context, req *v1.ChunkAbortReq) (res *v1.ChunkAbortRes, err error)
+		// RegenerateVariants 按当前配置重新生成图片附件的缩略图等图片规格
+		RegenerateVariants(ctx context.Context, req *v1.RegenerateVariantsReq) (res *v1.RegenerateVariantsRes, err error)
+		// SignedUrl 为有访问权限的附件生成签名的限时下载地址
+		SignedUrl(ctx context.Context, req *v1.SignedUrlReq) (res *v1.SignedUrlRes, err error)
+		// SignedDownload 校验签名与有效期后下载附件，不需要登录认证
+		SignedDownload(ctx context.Context, req *v1.SignedDownloadReq) (res *v1.SignedDownloadRes, err error)
+		// SetVisibility 设置附件是否私有及允许访问的角色，可见性变化时将文件移动到对应的存储目录
+		SetVisibility(ctx context.Context, req *v1.SetVisibilityReq) (res *v1.SetVisibilityRes, err error)
+		// FolderList 获取附件文件夹列表，附带各文件夹下的附件数
+		FolderList(ctx context.Context, req *v1.FolderListReq) (res *v1.FolderListRes, err error)
+		// FolderCreate 创建附件文件夹
+		FolderCreate(ctx context.Context, req *v1.FolderCreateReq) (res *v1.FolderCreateRes, err error)
+		// FolderUpdate 更新附件文件夹，修改上级文件夹时不能移动到自身或其子文件夹下
+		FolderUpdate(ctx context.Context, req *v1.FolderUpdateReq) (res *v1.FolderUpdateRes, err error)
+		// FolderDelete 删除附件文件夹，文件夹下还有子文件夹或附件时不能删除
+		FolderDelete(ctx context.Context, req *v1.FolderDeleteReq) (res *v1.FolderDeleteRes, err error)
+		// Move 移动附件到文件夹
+		Move(ctx context.Context, req *v1.MoveReq) (res *v1.MoveRes, err error)
+		// TagList 获取附件标签列表，附带使用各标签的附件数
+		TagList(ctx context.Context, req *v1.TagListReq) (res *v1.TagListRes, err error)
+		// TagDelete 删除附件标签，同时移除附件上的该标签
+		TagDelete(ctx context.Context, req *v1.TagDeleteReq) (res *v1.TagDeleteRes, err error)
+		// SetTags 设置附件标签，整体替换附件的标签，不存在的标签会自动创建
+		SetTags(ctx context.Context, req *v1.SetTagsReq) (res *v1.SetTagsRes, err error)
+		// References 查询附件被哪些业务数据引用
+		References(ctx context.Context, req *v1.ReferencesReq) (res *v1.ReferencesRes, err error)
+		// SyncReferences 更新业务记录字段引用的附件，替换该字段原有的引用，业务记录新增或修改时调用；
+		// urls 可以是附件的文件地址、缩略图地址或私有附件的签名下载地址，不属于任何附件的地址会被忽略
+		SyncReferences(ctx context.Context, bizType string, bizId interface{}, bizField string, urls []string) error
+		// RemoveReferences 删除业务记录的全部附件引用，业务记录删除时调用，bizIds 为单个业务记录ID或ID切片
+		RemoveReferences(ctx context.Context, bizType string, bizIds interface{}) error
+		// QuotaList 获取单独设置的存储配额列表，附带各配额对象的已用空间
+		QuotaList(ctx context.Context, req *v1.QuotaListReq) (res *v1.QuotaListRes, err error)
+		// QuotaSet 设置用户或部门的存储配额，已设置过时覆盖原配额
+		QuotaSet(ctx context.Context, req *v1.QuotaSetReq) (res *v1.QuotaSetRes, err error)
+		// QuotaDelete 删除单独设置的存储配额，删除后使用默认配额
+		QuotaDelete(ctx context.Context, req *v1.QuotaDeleteReq) (res *v1.QuotaDeleteRes, err error)
+		// QuotaUsage 获取当前用户及其所属部门的存储配额与已用空间
+		QuotaUsage(ctx context.Context, req *v1.QuotaUsageReq) (res *v1.QuotaUsageRes, err error)
+		// RemoveQuotas 删除用户或部门单独设置的存储配额，用户或部门删除时调用，targetIds 为单个ID或ID切片
+		RemoveQuotas(ctx context.Context, targetType string, targetIds interface{}) error
+		// Stats 获取附件统计：总量、按文件类型与扩展名统计、上传量最多的上传者及每日上传量
+		Stats(ctx context.Context, req *v1.StatsReq) (res *v1.StatsRes, err error)
+		// CleanupUploadSessions 清理过期未完成的分片上传会话及其分片，返回清理的会话数
+		CleanupUploadSessions(ctx context.Context) (int, error)
+		// Reconcile 比对存储中的文件与数据库记录：找出没有被附件（含图片规格）、用户头像、未完成的分片上传引用的孤儿文件，
+		// 以及附件、用户头像引用但存储中不存在的文件；非试运行时将孤儿文件移入隔离目录或删除
+		Reconcile(ctx context.Context, in model.ReconcileInput) (*model.ReconcileOutput, error)
 	}
 )
 
@@ -27,10 +27,34 @@ type (
 		DeleteCodeGenRecord(ctx context.Context, req v1.DeleteCodeGenRecordReq) (res *v1.DeleteCodeGenRecordRes, err error)
 		// UpdateCodeGenRecord 更新代码生成记录
 		UpdateCodeGenRecord(ctx context.Context, req v1.UpdateCodeGenRecordReq) (res *v1.UpdateCodeGenRecordRes, err error)
+		// GetTemplateSets 获取模板集列表
+		GetTemplateSets(ctx context.Context, req v1.GetTemplateSetsReq) (res *v1.GetTemplateSetsRes, err error)
+		// GetTemplate 获取模板内容
+		GetTemplate(ctx context.Context, req v1.GetTemplateReq) (res *v1.GetTemplateRes, err error)
+		// SaveTemplate 校验并保存模板
+		SaveTemplate(ctx context.Context, req v1.SaveTemplateReq) (res *v1.SaveTemplateRes, err error)
+		// UploadTemplate 上传模板文件
+		UploadTemplate(ctx context.Context, req v1.UploadTemplateReq) (res *v1.UploadTemplateRes, err error)
+		// ValidateTemplate 使用示例数据校验模板
+		ValidateTemplate(ctx context.Context, req v1.ValidateTemplateReq) (res *v1.ValidateTemplateRes, err error)
+		// DeleteTemplate 删除自定义模板或模板集
+		DeleteTemplate(ctx context.Context, req v1.DeleteTemplateReq) (res *v1.DeleteTemplateRes, err error)
 		// GenerateSql 根据提示词生成SQL语句
 		GenerateSql(ctx context.Context, req v1.GenerateSqlReq) (res *v1.GenerateSqlRes, err error)
 		// ExecuteSql 执行SQL语句（仅允许创建表语句）
 		ExecuteSql(ctx context.Context, req v1.ExecuteSqlReq) (res *v1.ExecuteSqlRes, err error)
+		// PreviewCreateTable 预览建表语句
+		PreviewCreateTable(ctx context.Context, req v1.PreviewCreateTableReq) (res *v1.PreviewCreateTableRes, err error)
+		// CreateTable 根据表结构定义创建数据表并记录变更
+		CreateTable(ctx context.Context, req v1.CreateTableReq) (res *v1.CreateTableRes, err error)
+		// PreviewAlterTable 预览修改表结构语句及与当前表结构的差异
+		PreviewAlterTable(ctx context.Context, req v1.PreviewAlterTableReq) (res *v1.PreviewAlterTableRes, err error)
+		// AlterTable 修改表结构并记录变更
+		AlterTable(ctx context.Context, req v1.AlterTableReq) (res *v1.AlterTableRes, err error)
+		// GetTableSchema 获取表结构定义，用于在表结构设计中编辑已有表
+		GetTableSchema(ctx context.Context, req v1.GetTableSchemaReq) (res *v1.GetTableSchemaRes, err error)
+		// GetTableMigrations 获取表结构变更记录
+		GetTableMigrations(ctx context.Context, req v1.GetTableMigrationsReq) (res *v1.GetTableMigrationsRes, err error)
 		// GetTables 获取数据库中所有表信息
 		GetTables(ctx context.Context, req v1.GetTablesReq) (res *v1.GetTablesRes, err error)
 		// ImportTables 导入表信息到代码生成记录
@@ -1,40 +1,49 @@
 package utility
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
-	"path/filepath"
+	"io"
+	"net/http"
 	"strings"
 
 	"github.com/gogf/gf/v2/errors/gerror"
-	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gtime"
 )
 
+// ObjectStorage 保存文件的对象存储，由 app 的存储驱动实现
+type ObjectStorage interface {
+	// Put 保存对象，size 为内容长度
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	// URL 对象的访问地址
+	URL(key string) string
+}
+
 // UploadConfig 上传配置
 type UploadConfig struct {
-	UploadDir    string   // 上传目录
+	KeyPrefix    string   // 对象 key 前缀（存储目录）
 	AllowedTypes []string // 允许的文件类型
 	FilePrefix   string   // 文件名前缀
 }
 
 // DefaultImageConfig 默认图片上传配置
 var DefaultImageConfig = UploadConfig{
-	UploadDir:    "uploads/images",
+	KeyPrefix:    "images",
 	AllowedTypes: []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
 	FilePrefix:   "img",
 }
 
 // AvatarConfig 头像上传配置
 var AvatarConfig = UploadConfig{
-	UploadDir:    "uploads/avatars",
+	KeyPrefix:    "avatars",
 	AllowedTypes: []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
 	FilePrefix:   "avatar",
 }
 
-// SaveBase64Image 保存base64图片到文件系统
-func SaveBase64Image(ctx context.Context, base64Data string, userId uint64, config UploadConfig) (string, error) {
+// SaveBase64Image 保存base64图片到对象存储，返回访问URL
+func SaveBase64Image(ctx context.Context, store ObjectStorage, base64Data string, userId uint64, config UploadConfig) (string, error) {
 	// 检查 context 是否已取消
 	select {
 	case <-ctx.Done():
@@ -87,6 +96,10 @@ func SaveBase64Image(ctx context.Context, base64Data string, userId uint64, conf
 	if err != nil {
 		return "", gerror.Wrap(err, "解码base64数据失败")
 	}
+	// 根据内容识别图片类型，不信任 data URL 中声明的类型
+	if detected, _, _ := strings.Cut(http.DetectContentType(imageData), ";"); detected != mimeType {
+		return "", gerror.New("图片内容与声明的格式不一致")
+	}
 
 	// 再次检查 context
 	select {
@@ -98,27 +111,22 @@ func SaveBase64Image(ctx context.Context, base64Data string, userId uint64, conf
 	// 生成文件名
 	fileName := fmt.Sprintf("%s_%d_%d%s", config.FilePrefix, userId, gtime.Now().Timestamp(), ext)
 
-	// 创建上传目录
-	if err := gfile.Mkdir(config.UploadDir); err != nil {
-		return "", gerror.Wrap(err, "创建上传目录失败")
-	}
-
 	// 保存文件
-	filePath := filepath.Join(config.UploadDir, fileName)
-	if err := gfile.PutBytes(filePath, imageData); err != nil {
+	key := config.KeyPrefix + "/" + fileName
+	if err := store.Put(ctx, key, bytes.NewReader(imageData), int64(len(imageData)), mimeType); err != nil {
 		return "", gerror.Wrap(err, "保存文件失败")
 	}
 
 	// 返回访问URL
-	return "/" + strings.ReplaceAll(filePath, "\\", "/"), nil
+	return store.URL(key), nil
 }
 
 // SaveBase64Avatar 保存base64头像图片（便捷方法）
-func SaveBase64Avatar(ctx context.Context, base64Data string, userId uint64) (string, error) {
-	return SaveBase64Image(ctx, base64Data, userId, AvatarConfig)
+func SaveBase64Avatar(ctx context.Context, store ObjectStorage, base64Data string, userId uint64) (string, error) {
+	return SaveBase64Image(ctx, store, base64Data, userId, AvatarConfig)
 }
 
 // SaveBase64GeneralImage 保存base64通用图片（便捷方法）
-func SaveBase64GeneralImage(ctx context.Context, base64Data string, userId uint64) (string, error) {
-	return SaveBase64Image(ctx, base64Data, userId, DefaultImageConfig)
+func SaveBase64GeneralImage(ctx context.Context, store ObjectStorage, base64Data string, userId uint64) (string, error) {
+	return SaveBase64Image(ctx, store, base64Data, userId, DefaultImageConfig)
 }
\ No newline at end of file
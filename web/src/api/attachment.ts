@@ -1,4 +1,5 @@
 import { http } from "@/utils/http";
+import { Sha256, sha256 } from "@/utils/sha256";
 import { baseUrlApi } from "./common/utils";
 import type { BaseResponse, PageResponse, PageParams } from "./common/types";
 
@@ -20,10 +21,22 @@ export interface AttachmentInfo {
   filePath: string;
   /** 文件URL */
   fileUrl: string;
+  /** 文件内容SHA-256 */
+  fileHash: string;
   /** 是否为图片 */
   isImage: boolean;
   /** 缩略图URL */
   thumbnailUrl?: string;
+  /** 是否私有（1私有，0公开），私有附件的文件与缩略图URL为签名下载地址 */
+  isPrivate: number;
+  /** 允许访问的角色编码（逗号分隔，为空时不限制） */
+  accessRoles: string;
+  /** 所属文件夹ID（0为根目录） */
+  folderId: number;
+  /** 标签 */
+  tags: string[];
+  /** 被业务数据引用的次数 */
+  refCount: number;
   /** 上传者ID */
   uploaderId: number;
   /** 上传者名称 */
@@ -46,6 +59,14 @@ export interface AttachmentListParams extends PageParams {
   fileExt?: string;
   /** 是否为图片 */
   isImage?: boolean;
+  /** 是否私有 */
+  isPrivate?: boolean;
+  /** 所属文件夹ID，0为根目录 */
+  folderId?: number;
+  /** 标签ID */
+  tagId?: number;
+  /** 是否被业务数据引用，为 false 时查询未被引用的附件 */
+  referenced?: boolean;
   /** 状态筛选 */
   status?: number;
 }
@@ -56,12 +77,112 @@ export interface UploadFileParams {
   file: File;
 }
 
-/** 上传文件 - 修改为只接收文件 */
-export const uploadFile = (file: File) => {
+/** 上传可见性参数 */
+export interface UploadVisibility {
+  /** 是否私有（1私有，0公开） */
+  isPrivate?: number;
+  /** 允许访问私有附件的角色编码，逗号分隔 */
+  accessRoles?: string;
+}
+
+/** 上传选项 */
+export interface UploadOptions extends UploadVisibility {
+  /** 所属文件夹ID，为空时保存到根目录 */
+  folderId?: number;
+}
+
+/** 上传结果 */
+export interface UploadResult {
+  /** 附件ID */
+  id: number;
+  /** 访问URL */
+  url: string;
+}
+
+/** 秒传检查参数 */
+export interface InstantUploadParams extends UploadOptions {
+  /** 文件内容SHA-256 */
+  hash: string;
+  /** 原始文件名 */
+  fileName: string;
+  /** 自定义附件名称 */
+  name?: string;
+  /** 备注 */
+  remark?: string;
+}
+
+/** 秒传检查结果 */
+export interface InstantUploadResult {
+  /** 是否秒传成功，为 false 时需要上传文件 */
+  hit: boolean;
+  /** 附件ID */
+  id?: number;
+  /** 访问URL */
+  url?: string;
+}
+
+/** 秒传检查：服务端已存在相同内容的文件时直接创建附件 */
+export const instantUpload = (data: InstantUploadParams) => {
+  return http.request<BaseResponse<InstantUploadResult>>(
+    "post",
+    baseUrlApi("attachment/upload/instant"),
+    { data }
+  );
+};
+
+/** 计算哈希时每次读取的文件大小 */
+const HASH_READ_SIZE = 4 * 1024 * 1024;
+
+/** 分段读取文件计算内容的 SHA-256，不会一次性将整个文件读入内存 */
+export const hashFile = async (file: Blob): Promise<string> => {
+  const hash = new Sha256();
+  for (let offset = 0; offset < file.size; offset += HASH_READ_SIZE) {
+    const part = file.slice(offset, offset + HASH_READ_SIZE);
+    hash.update(new Uint8Array(await part.arrayBuffer()));
+  }
+  return hash.digest();
+};
+
+/** 超过该大小的文件使用分片上传（服务端单次请求体默认限制为 8MB） */
+const CHUNK_UPLOAD_THRESHOLD = 5 * 1024 * 1024;
+/** 分片并行上传数 */
+const CHUNK_CONCURRENCY = 3;
+
+/** 上传文件，服务端已存在相同内容的文件时秒传，大文件使用分片上传 */
+export const uploadFile = async (
+  file: File,
+  onProgress?: (percent: number) => void,
+  options: UploadOptions = {}
+): Promise<BaseResponse<UploadResult>> => {
+  // 哈希计算失败时跳过秒传，分片上传不提供文件哈希
+  const hash = await hashFile(file).catch(() => "");
+  if (hash) {
+    const res = await instantUpload({
+      hash,
+      fileName: file.name,
+      ...options
+    }).catch(() => null);
+    if (res?.code === 0 && res.data.hit) {
+      return { ...res, data: { id: res.data.id, url: res.data.url } };
+    }
+  }
+  if (file.size > CHUNK_UPLOAD_THRESHOLD) {
+    return uploadFileInChunks(file, hash, onProgress, options);
+  }
+
   const formData = new FormData();
   formData.append("file", file);
+  if (options.isPrivate) {
+    formData.append("isPrivate", String(options.isPrivate));
+  }
+  if (options.accessRoles) {
+    formData.append("accessRoles", options.accessRoles);
+  }
+  if (options.folderId) {
+    formData.append("folderId", String(options.folderId));
+  }
 
-  return http.request<BaseResponse<AttachmentInfo>>(
+  return http.request<BaseResponse<UploadResult>>(
     "post",
     baseUrlApi("attachment/upload"),
     { data: formData },
@@ -73,6 +194,147 @@ export const uploadFile = (file: File) => {
   );
 };
 
+/** 分片上传会话 */
+export interface ChunkSession {
+  /** 上传ID */
+  uploadId: string;
+  /** 原始文件名 */
+  fileName: string;
+  /** 文件大小（字节） */
+  fileSize: number;
+  /** 分片大小（字节） */
+  chunkSize: number;
+  /** 分片数量 */
+  chunkCount: number;
+  /** 已上传的分片序号 */
+  uploadedParts: number[];
+  /** 过期时间 */
+  expiresAt: string;
+}
+
+/** 初始化分片上传参数 */
+export interface ChunkInitParams extends UploadOptions {
+  /** 原始文件名 */
+  fileName: string;
+  /** 文件大小（字节） */
+  fileSize: number;
+  /** 文件类型（MIME） */
+  fileType?: string;
+  /** 文件内容SHA-256，提供时可续传同一文件未完成的上传 */
+  fileHash?: string;
+  /** 分片大小（字节） */
+  chunkSize?: number;
+  /** 自定义附件名称 */
+  name?: string;
+  /** 备注 */
+  remark?: string;
+}
+
+/** 初始化分片上传 */
+export const chunkInit = (data: ChunkInitParams) => {
+  return http.request<BaseResponse<ChunkSession>>(
+    "post",
+    baseUrlApi("attachment/chunk/init"),
+    { data }
+  );
+};
+
+/** 查询分片上传进度 */
+export const getChunkStatus = (uploadId: string) => {
+  return http.request<BaseResponse<ChunkSession>>(
+    "get",
+    baseUrlApi(`attachment/chunk/${uploadId}`)
+  );
+};
+
+/** 上传分片 */
+export const chunkUploadPart = (
+  uploadId: string,
+  index: number,
+  checksum: string,
+  part: Blob
+) => {
+  const formData = new FormData();
+  formData.append("index", String(index));
+  formData.append("checksum", checksum);
+  formData.append("file", part, `${index}`);
+
+  return http.request<BaseResponse<{ index: number; size: number }>>(
+    "post",
+    baseUrlApi(`attachment/chunk/${uploadId}/part`),
+    { data: formData },
+    {
+      headers: {
+        "Content-Type": "multipart/form-data"
+      }
+    }
+  );
+};
+
+/** 完成分片上传 */
+export const chunkComplete = (uploadId: string) => {
+  return http.request<BaseResponse<UploadResult>>(
+    "post",
+    baseUrlApi(`attachment/chunk/${uploadId}/complete`)
+  );
+};
+
+/** 取消分片上传 */
+export const chunkAbort = (uploadId: string) => {
+  return http.request<BaseResponse<null>>(
+    "delete",
+    baseUrlApi(`attachment/chunk/${uploadId}`)
+  );
+};
+
+/** 分片上传文件：跳过已上传的分片（续传），并行上传其余分片后合并；hash 为空时不支持续传 */
+export const uploadFileInChunks = async (
+  file: File,
+  hash: string,
+  onProgress?: (percent: number) => void,
+  options: UploadOptions = {}
+): Promise<BaseResponse<UploadResult>> => {
+  const init = await chunkInit({
+    fileName: file.name,
+    fileSize: file.size,
+    fileType: file.type,
+    fileHash: hash || undefined,
+    ...options
+  });
+  if (init.code !== 0) return init as BaseResponse<any>;
+
+  const { uploadId, chunkSize, chunkCount, uploadedParts } = init.data;
+  const uploaded = new Set(uploadedParts);
+  const pending = Array.from({ length: chunkCount }, (_, i) => i).filter(
+    i => !uploaded.has(i)
+  );
+  const report = () =>
+    onProgress?.(Math.floor((uploaded.size / chunkCount) * 100));
+  report();
+
+  let failed: BaseResponse<any> | null = null;
+  const worker = async () => {
+    while (pending.length > 0 && !failed) {
+      const index = pending.shift();
+      const part = file.slice(index * chunkSize, (index + 1) * chunkSize);
+      const checksum = await sha256(await part.arrayBuffer());
+      const res = await chunkUploadPart(uploadId, index, checksum, part);
+      if (res.code !== 0) {
+        failed = res;
+        return;
+      }
+      uploaded.add(index);
+      report();
+    }
+  };
+  await Promise.all(
+    Array.from({ length: Math.min(CHUNK_CONCURRENCY, pending.length) }, worker)
+  );
+  if (failed) return failed;
+
+  return chunkComplete(uploadId);
+};
+
 /** 更新附件参数 */
 export interface UpdateAttachmentParams {
   /** 附件ID */
@@ -130,10 +392,317 @@ export const batchDeleteAttachments = (data: BatchDeleteParams) => {
 export const downloadAttachment = (id: number) => {
   return http.request<Blob>(
     "get",
-    baseUrlApi(`attachment/download/${id}`),
+    baseUrlApi(`attachment/${id}/download`),
     {},
     {
       responseType: "blob"
     }
   );
 };
+
+/** 图片规格 */
+export interface ImageVariant {
+  name: string;
+  url: string;
+  width: number;
+  height: number;
+  size: number;
+}
+
+/** 重新生成缩略图结果 */
+export interface RegenerateVariantsResult {
+  thumbnailUrl: string;
+  variants: ImageVariant[];
+}
+
+/** 重新生成图片附件的缩略图等图片规格 */
+export const regenerateVariants = (id: number) => {
+  return http.request<BaseResponse<RegenerateVariantsResult>>(
+    "post",
+    baseUrlApi(`attachment/${id}/variants`)
+  );
+};
+
+/** 签名下载地址参数 */
+export interface SignedUrlParams {
+  /** 图片规格名称，为空时下载原文件 */
+  variant?: string;
+  /** 有效期（秒），为空时使用服务端默认有效期 */
+  expire?: number;
+}
+
+/** 签名下载地址 */
+export interface SignedUrlResult {
+  /** 签名下载地址，无需登录即可在有效期内访问 */
+  url: string;
+  /** 过期时间 */
+  expiresAt: string;
+}
+
+/** 获取附件的签名下载地址，可用于分享私有附件 */
+export const getSignedUrl = (id: number, data: SignedUrlParams = {}) => {
+  return http.request<BaseResponse<SignedUrlResult>>(
+    "post",
+    baseUrlApi(`attachment/${id}/signed-url`),
+    { data }
+  );
+};
+
+/** 设置附件可见性参数 */
+export interface VisibilityParams {
+  /** 是否私有（1私有，0公开） */
+  isPrivate: number;
+  /** 允许访问私有附件的角色编码，逗号分隔，为空时不限制 */
+  accessRoles?: string;
+}
+
+/** 设置附件是否私有及允许访问的角色 */
+export const setAttachmentVisibility = (id: number, data: VisibilityParams) => {
+  return http.request<BaseResponse<null>>(
+    "put",
+    baseUrlApi(`attachment/${id}/visibility`),
+    { data }
+  );
+};
+
+/** 附件文件夹 */
+export interface FolderInfo {
+  /** 文件夹ID */
+  id: number;
+  /** 上级文件夹ID（0为根目录） */
+  parentId: number;
+  /** 文件夹名称 */
+  name: string;
+  /** 排序 */
+  sort: number;
+  /** 文件夹下的附件数（不含子文件夹） */
+  count: number;
+  /** 创建时间 */
+  createdAt: string;
+}
+
+/** 文件夹参数 */
+export interface FolderParams {
+  /** 上级文件夹ID，0为根目录 */
+  parentId?: number;
+  /** 文件夹名称 */
+  name?: string;
+  /** 排序 */
+  sort?: number;
+}
+
+/** 获取附件文件夹列表（平铺，由前端构建树） */
+export const getFolderList = () => {
+  return http.request<BaseResponse<{ list: FolderInfo[] }>>(
+    "get",
+    baseUrlApi("attachment/folder")
+  );
+};
+
+/** 创建附件文件夹 */
+export const createFolder = (data: FolderParams) => {
+  return http.request<BaseResponse<{ id: number }>>(
+    "post",
+    baseUrlApi("attachment/folder"),
+    { data }
+  );
+};
+
+/** 更新附件文件夹 */
+export const updateFolder = (id: number, data: FolderParams) => {
+  return http.request<BaseResponse<null>>(
+    "put",
+    baseUrlApi(`attachment/folder/${id}`),
+    { data }
+  );
+};
+
+/** 删除附件文件夹，文件夹下还有子文件夹或附件时不能删除 */
+export const deleteFolder = (id: number) => {
+  return http.request<BaseResponse<null>>(
+    "delete",
+    baseUrlApi(`attachment/folder/${id}`)
+  );
+};
+
+/** 移动附件到文件夹，folderId 为 0 时移动到根目录 */
+export const moveAttachments = (ids: number[], folderId: number) => {
+  return http.request<BaseResponse<null>>(
+    "put",
+    baseUrlApi("attachment/move"),
+    { data: { ids, folderId } }
+  );
+};
+
+/** 附件标签 */
+export interface TagInfo {
+  /** 标签ID */
+  id: number;
+  /** 标签名称 */
+  name: string;
+  /** 使用该标签的附件数 */
+  count: number;
+}
+
+/** 获取附件标签列表 */
+export const getTagList = () => {
+  return http.request<BaseResponse<{ list: TagInfo[] }>>(
+    "get",
+    baseUrlApi("attachment/tag")
+  );
+};
+
+/** 删除附件标签，同时移除附件上的该标签 */
+export const deleteTag = (id: number) => {
+  return http.request<BaseResponse<null>>(
+    "delete",
+    baseUrlApi(`attachment/tag/${id}`)
+  );
+};
+
+/** 设置附件标签，整体替换，不存在的标签会自动创建 */
+export const setAttachmentTags = (id: number, tags: string[]) => {
+  return http.request<BaseResponse<null>>(
+    "put",
+    baseUrlApi(`attachment/${id}/tags`),
+    { data: { tags } }
+  );
+};
+
+/** 附件引用 */
+export interface ReferenceInfo {
+  /** 业务类型（业务表名） */
+  bizType: string;
+  /** 业务记录ID */
+  bizId: string;
+  /** 业务字段 */
+  bizField: string;
+  /** 引用时间 */
+  createdAt: string;
+}
+
+/** 查询附件被哪些业务数据引用 */
+export const getAttachmentReferences = (id: number) => {
+  return http.request<BaseResponse<{ list: ReferenceInfo[] }>>(
+    "get",
+    baseUrlApi(`attachment/${id}/references`)
+  );
+};
+
+/** 存储配额 */
+export interface QuotaInfo {
+  /** 配额ID */
+  id: number;
+  /** 配额对象类型：user（用户）、department（部门） */
+  targetType: "user" | "department";
+  /** 配额对象ID */
+  targetId: number;
+  /** 配额对象名称 */
+  targetName: string;
+  /** 配额大小（字节），0为不限制 */
+  quotaSize: number;
+  /** 已使用空间（字节） */
+  usedSize: number;
+  /** 更新时间 */
+  updatedAt: string;
+}
+
+/** 存储配额列表 */
+export interface QuotaListResult {
+  list: QuotaInfo[];
+  /** 用户的默认配额（字节），0为不限制 */
+  defaultUser: number;
+  /** 部门的默认配额（字节），0为不限制 */
+  defaultDepartment: number;
+}
+
+/** 设置存储配额参数 */
+export interface QuotaParams {
+  targetType: "user" | "department";
+  targetId: number;
+  /** 配额大小（字节），0为不限制 */
+  quotaSize: number;
+}
+
+/** 存储空间使用情况 */
+export interface QuotaUsage {
+  /** 配额大小（字节），0为不限制 */
+  quotaSize: number;
+  /** 已使用空间（字节） */
+  usedSize: number;
+}
+
+/** 获取单独设置的存储配额列表 */
+export const getQuotaList = (params?: { targetType?: string }) => {
+  return http.request<BaseResponse<QuotaListResult>>(
+    "get",
+    baseUrlApi("attachment/quota"),
+    { params }
+  );
+};
+
+/** 设置存储配额，已设置过的配额对象会覆盖原配额 */
+export const setQuota = (data: QuotaParams) => {
+  return http.request<BaseResponse<null>>(
+    "put",
+    baseUrlApi("attachment/quota"),
+    { data }
+  );
+};
+
+/** 删除存储配额，删除后使用默认配额 */
+export const deleteQuota = (id: number) => {
+  return http.request<BaseResponse<null>>(
+    "delete",
+    baseUrlApi(`attachment/quota/${id}`)
+  );
+};
+
+/** 获取当前用户及所属部门的存储空间使用情况 */
+export const getQuotaUsage = () => {
+  return http.request<
+    BaseResponse<{ user: QuotaUsage; department: QuotaUsage | null }>
+  >("get", baseUrlApi("attachment/quota/usage"));
+};
+
+/** 附件分组统计 */
+export interface StatsGroup {
+  name: string;
+  count: number;
+  size: number;
+}
+
+/** 附件统计 */
+export interface AttachmentStats {
+  /** 附件总数 */
+  totalCount: number;
+  /** 附件总大小（字节） */
+  totalSize: number;
+  /** 去重后实际占用的存储空间（字节） */
+  storageSize: number;
+  /** 按文件类型统计 */
+  byType: StatsGroup[];
+  /** 按扩展名统计 */
+  byExt: StatsGroup[];
+  /** 上传量最多的上传者 */
+  topUploaders: {
+    uploaderId: number;
+    uploaderName: string;
+    count: number;
+    size: number;
+  }[];
+  /** 每日上传量 */
+  daily: { date: string; count: number; size: number }[];
+}
+
+/** 获取附件统计，days 为每日统计的天数，top 为排行数量 */
+export const getAttachmentStats = (params?: {
+  days?: number;
+  top?: number;
+}) => {
+  return http.request<BaseResponse<AttachmentStats>>(
+    "get",
+    baseUrlApi("attachment/stats"),
+    { params }
+  );
+};
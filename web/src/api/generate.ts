@@ -375,3 +375,241 @@ export const getTableColumns = (tableName: string) => {
     baseUrlApi(`table/${tableName}/columns`)
   );
 };
+
+// ==================== 模板集相关 ====================
+
+/** 模板集中的模板文件 */
+export interface TemplateFile {
+  /** 模板文件名（相对路径） */
+  name: string;
+  /** 是否由模板集自定义，否则沿用默认模板 */
+  custom: boolean;
+}
+
+/** 代码生成模板集 */
+export interface TemplateSet {
+  /** 模板集名称 */
+  name: string;
+  /** 是否为内置默认模板集（只读） */
+  builtin: boolean;
+  /** 模板文件 */
+  files: TemplateFile[];
+}
+
+/** 获取模板集列表 */
+export const getTemplateSets = () => {
+  return http.request<BaseResponse<{ list: TemplateSet[] }>>(
+    "get",
+    baseUrlApi("generate/template/sets")
+  );
+};
+
+/** 获取模板内容 */
+export const getTemplate = (set: string, name: string) => {
+  return http.request<BaseResponse<{ content: string; custom: boolean }>>(
+    "get",
+    baseUrlApi("generate/template"),
+    { params: { set, name } }
+  );
+};
+
+/** 保存模板（模板集不存在时自动创建） */
+export const saveTemplate = (data: {
+  set: string;
+  name: string;
+  content: string;
+}) => {
+  return http.request<BaseResponse<null>>(
+    "put",
+    baseUrlApi("generate/template"),
+    { data }
+  );
+};
+
+/** 上传模板文件 */
+export const uploadTemplate = (set: string, name: string, file: File) => {
+  const formData = new FormData();
+  formData.append("set", set);
+  formData.append("name", name);
+  formData.append("file", file);
+
+  return http.request<BaseResponse<null>>(
+    "post",
+    baseUrlApi("generate/template/upload"),
+    { data: formData },
+    {
+      headers: {
+        "Content-Type": "multipart/form-data"
+      }
+    }
+  );
+};
+
+/** 使用示例数据校验模板，返回渲染结果 */
+export const validateTemplate = (data: { name: string; content: string }) => {
+  return http.request<BaseResponse<{ output: string }>>(
+    "post",
+    baseUrlApi("generate/template/validate"),
+    { data }
+  );
+};
+
+/** 删除自定义模板（恢复默认），未指定模板文件时删除整个模板集 */
+export const deleteTemplate = (set: string, name?: string) => {
+  return http.request<BaseResponse<null>>(
+    "delete",
+    baseUrlApi("generate/template"),
+    { params: { set, name } }
+  );
+};
+
+// ==================== 表结构设计 ====================
+
+/** 表结构设计中的字段定义 */
+export interface SchemaColumn {
+  /** 字段名 */
+  name: string;
+  /** 数据类型，如 int、varchar、decimal */
+  type: string;
+  /** 长度/精度，varchar、char、decimal 必填 */
+  length: number;
+  /** 小数位数，仅 decimal */
+  scale: number;
+  /** 是否无符号 */
+  unsigned: boolean;
+  /** 是否允许为空 */
+  nullable: boolean;
+  /** 默认值，为 null 时不设置；时间字段可使用 CURRENT_TIMESTAMP */
+  default: string | null;
+  /** 是否自增，仅整数主键 */
+  autoIncrement: boolean;
+  /** 是否主键 */
+  primaryKey: boolean;
+  /** 更新时自动设置为当前时间 */
+  onUpdate: boolean;
+  /** 字段注释 */
+  comment: string;
+  /** 枚举可选值，仅 enum */
+  values: string[];
+}
+
+/** 表结构设计中的索引定义 */
+export interface SchemaIndex {
+  /** 索引名，为空时自动生成 */
+  name: string;
+  /** 索引字段 */
+  columns: string[];
+  /** 是否唯一索引 */
+  unique: boolean;
+}
+
+/** 建表定义 */
+export interface SchemaTable {
+  /** 表名 */
+  tableName: string;
+  /** 表注释 */
+  comment: string;
+  /** 字段 */
+  columns: SchemaColumn[];
+  /** 索引（不含主键） */
+  indexes: SchemaIndex[];
+}
+
+/** 修改表结构定义 */
+export interface SchemaAlter {
+  /** 表名 */
+  tableName: string;
+  /** 新增字段 */
+  addColumns: SchemaColumn[];
+  /** 修改字段 */
+  modifyColumns: SchemaColumn[];
+  /** 删除字段 */
+  dropColumns: string[];
+  /** 新增索引 */
+  addIndexes: SchemaIndex[];
+}
+
+/** 表结构变更差异 */
+export interface SchemaDiff {
+  /** 变更动作：add新增字段 modify修改字段 drop删除字段 index新增索引 */
+  action: "add" | "modify" | "drop" | "index";
+  /** 字段名或索引名 */
+  name: string;
+  /** 变更前定义 */
+  before: string;
+  /** 变更后定义 */
+  after: string;
+}
+
+/** 表结构变更记录 */
+export interface TableMigration {
+  id: number;
+  /** 表名 */
+  tableName: string;
+  /** 变更类型：create建表 alter修改表结构 sql执行SQL */
+  action: string;
+  /** 执行的SQL语句 */
+  statement: string;
+  /** 表结构定义（JSON） */
+  definition: string;
+  /** 操作人ID */
+  operatorId: number;
+  /** 执行时间 */
+  createdAt: string;
+}
+
+/** 预览建表语句 */
+export const previewCreateTable = (data: SchemaTable) => {
+  return http.request<BaseResponse<{ sql: string }>>(
+    "post",
+    baseUrlApi("generate/schema/create/preview"),
+    { data }
+  );
+};
+
+/** 根据表结构定义创建数据表 */
+export const createTable = (data: SchemaTable) => {
+  return http.request<BaseResponse<{ sql: string }>>(
+    "post",
+    baseUrlApi("generate/schema/create"),
+    { data }
+  );
+};
+
+/** 预览修改表结构语句及与当前表结构的差异 */
+export const previewAlterTable = (data: SchemaAlter) => {
+  return http.request<BaseResponse<{ sql: string; diffs: SchemaDiff[] }>>(
+    "post",
+    baseUrlApi("generate/schema/alter/preview"),
+    { data }
+  );
+};
+
+/** 修改表结构 */
+export const alterTable = (data: SchemaAlter) => {
+  return http.request<BaseResponse<{ sql: string; diffs: SchemaDiff[] }>>(
+    "post",
+    baseUrlApi("generate/schema/alter"),
+    { data }
+  );
+};
+
+/** 获取表结构定义 */
+export const getTableSchema = (tableName: string) => {
+  return http.request<BaseResponse<SchemaTable>>(
+    "get",
+    baseUrlApi("generate/schema/table"),
+    { params: { tableName } }
+  );
+};
+
+/** 获取表结构变更记录 */
+export const getTableMigrations = (
+  params: PageParams & { tableName?: string }
+) => {
+  return http.request<PageResponse<TableMigration>>(
+    "get",
+    baseUrlApi("generate/schema/migrations"),
+    { params }
+  );
+};
@@ -0,0 +1,7 @@
+import reDynamicInput from "./src/index.vue";
+import { withInstall } from "@pureadmin/utils";
+
+/** 动态键值对输入组件 */
+export const ReDynamicInput = withInstall(reDynamicInput);
+
+export default ReDynamicInput;
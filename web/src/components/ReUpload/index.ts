@@ -0,0 +1,7 @@
+import reUpload from "./src/index.vue";
+import { withInstall } from "@pureadmin/utils";
+
+/** 上传到附件库并回填文件地址的上传组件 */
+export const ReUpload = withInstall(reUpload);
+
+export default ReUpload;
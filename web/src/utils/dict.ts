@@ -0,0 +1,24 @@
+import { ref, type Ref } from "vue";
+import { getDictOptions } from "@/api/dict";
+
+/** 字典选项 */
+export type DictOption = {
+  label: string;
+  value: string | number;
+};
+
+/**
+ * 加载字典选项，供下拉框、单选框等组件直接使用
+ * @param dictType 字典类型，对应 `GET /dict/options/{dictType}`
+ * @param numeric 字段为数字类型时将字典值转换为数字，保证选中状态能正确回显
+ */
+export function useDict(dictType: string, numeric = false): Ref<DictOption[]> {
+  const options = ref<DictOption[]>([]);
+  getDictOptions(dictType).then(({ data }) => {
+    options.value = (data?.options ?? []).map(item => ({
+      label: item.label,
+      value: numeric ? Number(item.value) : item.value
+    }));
+  });
+  return options;
+}
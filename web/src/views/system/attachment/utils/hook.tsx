@@ -1,26 +1,53 @@
 import dayjs from "dayjs";
 import editForm from "../form.vue";
+import quotaPanel from "../quota.vue";
+import { handleTree } from "@/utils/tree";
 import { message } from "@/utils/message";
-import { ElImage } from "element-plus";
+import {
+  ElImage,
+  ElMessageBox,
+  ElSelect,
+  ElOption,
+  ElTreeSelect,
+  ElTable,
+  ElTableColumn
+} from "element-plus";
 import { addDialog } from "@/components/ReDialog";
 import type { FormItemProps } from "../utils/types";
 import type { PaginationProps } from "@pureadmin/table";
 import { deviceDetection } from "@pureadmin/utils";
-import { reactive, ref, h } from "vue";
+import { reactive, ref, computed, h, onMounted } from "vue";
 import {
   getAttachmentList,
   updateAttachment,
   deleteAttachment,
   batchDeleteAttachments,
   downloadAttachment,
-  type AttachmentInfo
+  regenerateVariants,
+  setAttachmentVisibility,
+  getFolderList,
+  createFolder,
+  updateFolder,
+  deleteFolder,
+  moveAttachments,
+  getTagList,
+  setAttachmentTags,
+  getAttachmentReferences,
+  type AttachmentInfo,
+  type FolderInfo,
+  type TagInfo
 } from "@/api/attachment";
 
 export function useAttachment() {
   const form = reactive({
     fileName: undefined,
     fileExt: undefined,
-    isImage: undefined
+    isImage: undefined,
+    isPrivate: undefined,
+    tagId: undefined,
+    referenced: undefined,
+    // 由文件夹树选择，不在搜索表单中重置
+    folderId: undefined
   });
 
   const formRef = ref();
@@ -29,6 +56,14 @@ export function useAttachment() {
   const selectedNum = ref(0);
   // 添加选中的附件数据存储
   const selectedAttachments = ref<AttachmentInfo[]>([]);
+  const folderList = ref<FolderInfo[]>([]);
+  const folderLoading = ref(true);
+  const tagOptions = ref<TagInfo[]>([]);
+
+  // 文件夹树，根目录作为顶层节点
+  const folderTree = computed(() => [
+    { id: 0, name: "根目录", children: handleTree(folderList.value) }
+  ]);
 
   const pagination = reactive<PaginationProps>({
     total: 0,
@@ -57,7 +92,7 @@ export function useAttachment() {
         if (row.isImage && row.fileUrl) {
           return (
             <ElImage
-              src={row.fileUrl}
+              src={row.thumbnailUrl || row.fileUrl}
               preview-src-list={[row.fileUrl]}
               fit="cover"
               class="w-[40px] h-[40px] rounded cursor-pointer"
@@ -112,6 +147,51 @@ export function useAttachment() {
         </el-tag>
       )
     },
+    {
+      label: "可见性",
+      prop: "isPrivate",
+      width: 90,
+      cellRenderer: ({ row, props }) => (
+        <el-tag
+          size={props.size}
+          type={row.isPrivate === 1 ? "warning" : "info"}
+          effect="plain"
+        >
+          {row.isPrivate === 1 ? "私有" : "公开"}
+        </el-tag>
+      )
+    },
+    {
+      label: "标签",
+      prop: "tags",
+      minWidth: 140,
+      cellRenderer: ({ row, props }) => (
+        <div class="flex flex-wrap justify-center gap-1">
+          {(row.tags || []).map(tag => (
+            <el-tag size={props.size} effect="plain">
+              {tag}
+            </el-tag>
+          ))}
+        </div>
+      )
+    },
+    {
+      label: "引用",
+      prop: "refCount",
+      width: 80,
+      cellRenderer: ({ row, props }) =>
+        row.refCount > 0 ? (
+          <el-link
+            size={props.size}
+            type="primary"
+            onClick={() => handleReferences(row)}
+          >
+            {row.refCount}
+          </el-link>
+        ) : (
+          <span class="text-gray-400">0</span>
+        )
+    },
     {
       label: "上传者",
       prop: "uploaderName",
@@ -141,7 +221,7 @@ export function useAttachment() {
     {
       label: "操作",
       fixed: "right",
-      width: 200,
+      width: 420,
       slot: "operation"
     }
   ];
@@ -174,7 +254,11 @@ export function useAttachment() {
         pageSize: pagination.pageSize,
         fileName: form.fileName,
         fileExt: form.fileExt,
-        isImage: form.isImage
+        isImage: form.isImage,
+        isPrivate: form.isPrivate,
+        folderId: form.folderId,
+        tagId: form.tagId,
+        referenced: form.referenced
       });
       dataList.value = data.list || [];
       pagination.total = data.total;
@@ -267,6 +351,41 @@ export function useAttachment() {
     }
   }
 
+  async function handleRegenerateVariants(row: AttachmentInfo) {
+    try {
+      const result = await regenerateVariants(row.id);
+      if (result.code === 0) {
+        message(`已重新生成"${row.fileName}"的缩略图`, { type: "success" });
+        onSearch();
+      } else {
+        message(result.message || "生成缩略图失败", { type: "error" });
+      }
+    } catch (error) {
+      console.error("生成缩略图异常:", error);
+      message("生成缩略图失败，请稍后重试", { type: "error" });
+    }
+  }
+
+  async function handleToggleVisibility(row: AttachmentInfo) {
+    const isPrivate = row.isPrivate === 1 ? 0 : 1;
+    const label = isPrivate === 1 ? "私有" : "公开";
+    try {
+      const result = await setAttachmentVisibility(row.id, {
+        isPrivate,
+        accessRoles: row.accessRoles
+      });
+      if (result.code === 0) {
+        message(`已将"${row.fileName}"设为${label}`, { type: "success" });
+        onSearch();
+      } else {
+        message(result.message || `设为${label}失败`, { type: "error" });
+      }
+    } catch (error) {
+      console.error("设置附件可见性异常:", error);
+      message(`设为${label}失败，请稍后重试`, { type: "error" });
+    }
+  }
+
   async function onbatchDel() {
     if (selectedAttachments.value.length === 0) {
       message("请先选择要删除的附件", { type: "warning" });
@@ -299,6 +418,214 @@ export function useAttachment() {
     }
   }
 
+  async function loadFolders() {
+    folderLoading.value = true;
+    try {
+      const { data } = await getFolderList();
+      folderList.value = data?.list || [];
+    } finally {
+      folderLoading.value = false;
+    }
+  }
+
+  async function loadTags() {
+    const { data } = await getTagList();
+    tagOptions.value = data?.list || [];
+  }
+
+  function onFolderSelect({ id, selected }) {
+    form.folderId = selected ? id : undefined;
+    pagination.currentPage = 1;
+    onSearch();
+  }
+
+  /** 输入文件夹名称，取消时返回 null */
+  async function promptFolderName(title: string, name = "") {
+    try {
+      const { value } = await ElMessageBox.prompt("请输入文件夹名称", title, {
+        inputValue: name,
+        inputValidator: val => !!val?.trim() || "文件夹名称不能为空",
+        confirmButtonText: "确定",
+        cancelButtonText: "取消",
+        draggable: true
+      });
+      return value.trim();
+    } catch {
+      return null;
+    }
+  }
+
+  async function handleFolderCreate(parent: FolderInfo) {
+    const name = await promptFolderName(
+      parent.id ? `在"${parent.name}"下新建文件夹` : "新建文件夹"
+    );
+    if (!name) return;
+    const result = await createFolder({ parentId: parent.id, name });
+    if (result.code === 0) {
+      message(`已创建文件夹"${name}"`, { type: "success" });
+      loadFolders();
+    } else {
+      message(result.message || "创建文件夹失败", { type: "error" });
+    }
+  }
+
+  async function handleFolderRename(folder: FolderInfo) {
+    const name = await promptFolderName("重命名文件夹", folder.name);
+    if (!name || name === folder.name) return;
+    const result = await updateFolder(folder.id, { name });
+    if (result.code === 0) {
+      message("重命名文件夹成功", { type: "success" });
+      loadFolders();
+    } else {
+      message(result.message || "重命名文件夹失败", { type: "error" });
+    }
+  }
+
+  function handleFolderDelete(folder: FolderInfo) {
+    ElMessageBox.confirm(`是否确认删除文件夹"${folder.name}"？`, "系统提示", {
+      confirmButtonText: "确定",
+      cancelButtonText: "取消",
+      type: "warning",
+      draggable: true
+    })
+      .then(async () => {
+        const result = await deleteFolder(folder.id);
+        if (result.code === 0) {
+          message(`已删除文件夹"${folder.name}"`, { type: "success" });
+          if (form.folderId === folder.id) {
+            form.folderId = undefined;
+            onSearch();
+          }
+          loadFolders();
+        } else {
+          message(result.message || "删除文件夹失败", { type: "error" });
+        }
+      })
+      .catch(() => {});
+  }
+
+  /** 移动附件到文件夹，未传入附件时移动选中的附件 */
+  function handleMove(row?: AttachmentInfo) {
+    const rows = row ? [row] : selectedAttachments.value;
+    if (rows.length === 0) {
+      message("请先选择要移动的附件", { type: "warning" });
+      return;
+    }
+    const target = ref(row?.folderId ?? 0);
+    addDialog({
+      title: rows.length > 1 ? `移动 ${rows.length} 个附件` : "移动附件",
+      width: "30%",
+      draggable: true,
+      closeOnClickModal: false,
+      fullscreen: deviceDetection(),
+      contentRenderer: () => (
+        <ElTreeSelect
+          v-model={target.value}
+          class="w-full"
+          data={folderTree.value}
+          props={{ label: "name", children: "children" }}
+          node-key="id"
+          check-strictly
+          default-expand-all
+          render-after-expand={false}
+        />
+      ),
+      beforeSure: async done => {
+        const result = await moveAttachments(
+          rows.map(item => item.id),
+          target.value
+        );
+        if (result.code === 0) {
+          message("移动附件成功", { type: "success" });
+          done();
+          loadFolders();
+          onSearch();
+        } else {
+          message(result.message || "移动附件失败", { type: "error" });
+        }
+      }
+    });
+  }
+
+  function handleTags(row: AttachmentInfo) {
+    const tags = ref<string[]>([...(row.tags || [])]);
+    addDialog({
+      title: `设置"${row.fileName}"的标签`,
+      width: "30%",
+      draggable: true,
+      closeOnClickModal: false,
+      fullscreen: deviceDetection(),
+      contentRenderer: () => (
+        <ElSelect
+          v-model={tags.value}
+          class="w-full"
+          multiple
+          filterable
+          allow-create
+          default-first-option
+          reserve-keyword={false}
+          placeholder="选择或输入标签，回车创建新标签"
+        >
+          {tagOptions.value.map(tag => (
+            <ElOption key={tag.id} label={tag.name} value={tag.name} />
+          ))}
+        </ElSelect>
+      ),
+      beforeSure: async done => {
+        const result = await setAttachmentTags(row.id, tags.value);
+        if (result.code === 0) {
+          message("设置标签成功", { type: "success" });
+          done();
+          loadTags();
+          onSearch();
+        } else {
+          message(result.message || "设置标签失败", { type: "error" });
+        }
+      }
+    });
+  }
+
+  async function handleReferences(row: AttachmentInfo) {
+    const { code, data, message: msg } = await getAttachmentReferences(row.id);
+    if (code !== 0) {
+      message(msg || "查询附件引用失败", { type: "error" });
+      return;
+    }
+    addDialog({
+      title: `"${row.fileName}"的引用`,
+      width: "50%",
+      draggable: true,
+      hideFooter: true,
+      fullscreen: deviceDetection(),
+      contentRenderer: () => (
+        <ElTable data={data.list} border max-height="400">
+          <ElTableColumn prop="bizType" label="业务类型" />
+          <ElTableColumn prop="bizId" label="业务记录ID" />
+          <ElTableColumn prop="bizField" label="业务字段" />
+          <ElTableColumn
+            prop="createdAt"
+            label="引用时间"
+            formatter={({ createdAt }) =>
+              dayjs(createdAt).format("YYYY-MM-DD HH:mm:ss")
+            }
+          />
+        </ElTable>
+      )
+    });
+  }
+
+  /** 管理用户、部门的存储配额 */
+  function handleQuota() {
+    addDialog({
+      title: "存储配额",
+      width: "60%",
+      draggable: true,
+      hideFooter: true,
+      fullscreen: deviceDetection(),
+      contentRenderer: () => h(quotaPanel)
+    });
+  }
+
   function handleSizeChange(val: number) {
     pagination.pageSize = val;
     onSearch();
@@ -309,11 +636,19 @@ export function useAttachment() {
     onSearch();
   }
 
+  onMounted(() => {
+    loadFolders();
+    loadTags();
+  });
+
   return {
     form,
     loading,
     columns,
     dataList,
+    folderTree,
+    folderLoading,
+    tagOptions,
     selectedNum,
     pagination,
     onSearch,
@@ -321,6 +656,16 @@ export function useAttachment() {
     openDialog,
     handleDelete,
     handleDownload,
+    handleRegenerateVariants,
+    handleToggleVisibility,
+    onFolderSelect,
+    handleFolderCreate,
+    handleFolderRename,
+    handleFolderDelete,
+    handleMove,
+    handleTags,
+    handleReferences,
+    handleQuota,
     onbatchDel,
     handleSizeChange,
     handleCurrentChange,
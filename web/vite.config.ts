@@ -31,7 +31,9 @@ export default ({ mode }: ConfigEnv): UserConfigExport => {
         //   changeOrigin: true,
         //   rewrite: path => path.replace(/^\/admin/, "")
         // }
-        "/admin": "http://localhost:8000"
+        "/admin": "http://localhost:8000",
+        // 本地存储的上传文件
+        "/uploads": "http://localhost:8000"
       },
       // 预热文件以提前转换和缓存结果，降低启动期间的初始页面加载时长并防止转换瀑布
       warmup: {